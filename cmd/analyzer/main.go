@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/args"
@@ -16,9 +20,9 @@ import (
 var CommitID string
 
 func main() {
-	// Load .env file
+	// Load .env file if exists. config file can be used instead
 	err := godotenv.Load()
-	if err != nil {
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -30,23 +34,31 @@ func main() {
 		return
 	}
 
-	// Parse Environment Variables
-	var cfg envs.Config
-	if err := env.Parse(&cfg); err != nil {
+	// Load config from config file, environment variables and flags
+	cfg, err := envs.Load(&envs.LoadOptions{
+		File:      args.Config,
+		Profile:   args.Profile,
+		Overrides: args.Set,
+	})
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
 	// Register for initialization of dependencies
-	reg := registry.NewRegistry(&cfg, appCode, CommitID, args)
+	reg := registry.NewRegistry(cfg, appCode, CommitID, args)
 	app, err := reg.InitializeApp()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	// Cancel context by SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
 	// Execute application
-	err = app.Run()
+	err = app.Run(ctx)
+	stop()
 	if err != nil {
 		reg.Close()
 		fmt.Println(err)
@@ -0,0 +1,2 @@
+-- store bookmark comment on UserURLs
+ALTER TABLE UserURLs ADD COLUMN IF NOT EXISTS comment TEXT DEFAULT '';
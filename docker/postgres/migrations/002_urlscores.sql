@@ -0,0 +1,29 @@
+-- suspicion score per URL
+CREATE TABLE IF NOT EXISTS URLScores (
+    url_id INT PRIMARY KEY,
+    score FLOAT DEFAULT 0,
+    private_user_rate FLOAT DEFAULT 0,
+    new_user_rate FLOAT DEFAULT 0,
+    deleted_user_rate FLOAT DEFAULT 0,
+    burstiness FLOAT DEFAULT 0,
+    user_overlap FLOAT DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (url_id) REFERENCES URLs (url_id)
+);
+
+CREATE OR REPLACE FUNCTION set_timestamp_urlscores()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS before_update_urlscores ON URLScores;
+CREATE TRIGGER before_update_urlscores
+BEFORE UPDATE ON URLScores
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp_urlscores();
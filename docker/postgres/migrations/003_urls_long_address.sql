@@ -0,0 +1,30 @@
+-- store full-length url and title. unique key is moved to sha256 hash of url_address
+-- because btree index can't store too long text
+ALTER TABLE URLs ALTER COLUMN url_address TYPE TEXT;
+ALTER TABLE URLs ALTER COLUMN title TYPE TEXT;
+
+-- hash of raw address is provisional. application hashes normalized address, so
+-- `merge-duplicate-urls` must run after this migration to rehash urls and merge duplicates (see `make migrate-db`)
+ALTER TABLE URLs ADD COLUMN IF NOT EXISTS url_hash CHAR(64);
+UPDATE URLs SET url_hash = encode(sha256(convert_to(url_address, 'UTF8')), 'hex') WHERE url_hash IS NULL;
+ALTER TABLE URLs ALTER COLUMN url_hash SET NOT NULL;
+
+ALTER TABLE URLs DROP CONSTRAINT IF EXISTS urls_url_address_key;
+ALTER TABLE URLs DROP CONSTRAINT IF EXISTS urls_url_hash_key;
+ALTER TABLE URLs ADD CONSTRAINT urls_url_hash_key UNIQUE (url_hash);
+
+-- hashes are given by application
+DROP PROCEDURE IF EXISTS public.bulk_insert_urls(text[], text[], boolean[]);
+CREATE OR REPLACE PROCEDURE public.bulk_insert_urls(_urls text[], _hashes text[], _categories text[], _is_all boolean[])
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    i INT;
+BEGIN
+    FOR i IN 1 .. array_length(_urls, 1) LOOP
+        INSERT INTO URLs (url_address, url_hash, category_code, is_all)
+        VALUES (_urls[i], _hashes[i], _categories[i], _is_all[i])
+        ON CONFLICT (url_hash) DO NOTHING;
+    END LOOP;
+END;
+$$;
@@ -0,0 +1,7 @@
+-- staging table to upsert Users and UserURLs of url in bulk. rows are deleted in same transaction
+CREATE UNLOGGED TABLE IF NOT EXISTS UserURLStagings (
+    url_id INT NOT NULL,
+    user_name VARCHAR(100) NOT NULL,
+    comment TEXT DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_userurlstagings_url_id ON UserURLStagings (url_id);
@@ -0,0 +1,3 @@
+-- track removed bookmarks
+ALTER TABLE UserURLs ADD COLUMN IF NOT EXISTS removed_at TIMESTAMP;
+ALTER TABLE UserURLStagings ADD COLUMN IF NOT EXISTS is_deleted BOOLEAN DEFAULT FALSE;
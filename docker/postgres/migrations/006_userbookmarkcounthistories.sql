@@ -0,0 +1,16 @@
+-- every observation of user's bookmark count
+CREATE TABLE IF NOT EXISTS UserBookmarkCountHistories (
+    history_id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    bookmark_count INT NOT NULL,
+    observed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES Users (user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_userbookmarkcounthistories_user_id ON UserBookmarkCountHistories (user_id, observed_at);
+CREATE INDEX IF NOT EXISTS idx_userbookmarkcounthistories_observed_at ON UserBookmarkCountHistories (observed_at);
+
+-- current bookmark counts are kept as first observation
+INSERT INTO UserBookmarkCountHistories (user_id, bookmark_count, observed_at)
+SELECT user_id, bookmark_count, updated_at FROM Users
+WHERE bookmark_count > 0
+AND NOT EXISTS (SELECT 1 FROM UserBookmarkCountHistories h WHERE h.user_id = Users.user_id);
@@ -0,0 +1,2 @@
+-- updated_at of Users is when bookmark_count was refreshed last
+CREATE INDEX IF NOT EXISTS idx_users_updated_at ON Users (updated_at) WHERE is_deleted = FALSE;
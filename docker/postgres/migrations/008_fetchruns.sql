@@ -0,0 +1,21 @@
+-- run of fetch command. items are url addresses or user names
+CREATE TABLE IF NOT EXISTS FetchRuns (
+    run_id SERIAL PRIMARY KEY,
+    command VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'running', -- running, completed, failed, interrupted
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- progress of each item in run
+CREATE TABLE IF NOT EXISTS FetchRunItems (
+    run_item_id SERIAL PRIMARY KEY,
+    run_id INT NOT NULL,
+    item TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending', -- pending, done, failed
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (run_id) REFERENCES FetchRuns (run_id),
+    UNIQUE (run_id, item)
+);
+CREATE INDEX IF NOT EXISTS idx_fetchrunitems_run_id_status ON FetchRunItems (run_id, status);
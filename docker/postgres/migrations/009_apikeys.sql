@@ -0,0 +1,23 @@
+-- API key of web server. only sha256 hash of key is stored
+CREATE TABLE IF NOT EXISTS ApiKeys (
+    api_key_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- first characters of key to identify it
+    key_hash CHAR(64) NOT NULL UNIQUE, -- sha256 hex of key
+    scope VARCHAR(16) NOT NULL DEFAULT 'read', -- read, write
+    rate_limit INT NOT NULL DEFAULT 60, -- requests per minute. 0 is unlimited
+    daily_quota INT NOT NULL DEFAULT 0, -- requests per day. 0 is unlimited
+    is_revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- requests per API key per day to enforce quota
+CREATE TABLE IF NOT EXISTS ApiKeyUsages (
+    api_key_id INT NOT NULL,
+    usage_date DATE NOT NULL,
+    request_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, usage_date),
+    FOREIGN KEY (api_key_id) REFERENCES ApiKeys (api_key_id)
+);
@@ -0,0 +1,11 @@
+-- alert sent for url. same rule of url is not alerted again within dedup window
+CREATE TABLE IF NOT EXISTS Alerts (
+    alert_id SERIAL PRIMARY KEY,
+    url_id INT NOT NULL,
+    rule VARCHAR(32) NOT NULL, -- private_user_rate, new_user_rate, deletion_spike, burst
+    value DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (url_id) REFERENCES URLs (url_id)
+);
+CREATE INDEX IF NOT EXISTS idx_alerts_url_id_created_at ON Alerts (url_id, created_at);
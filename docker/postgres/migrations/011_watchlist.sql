@@ -0,0 +1,17 @@
+-- watched url is refreshed by every fetch-bookmark. url may not be stored in URLs yet
+CREATE TABLE IF NOT EXISTS WatchedURLs (
+    watched_url_id SERIAL PRIMARY KEY,
+    url_address TEXT NOT NULL,
+    url_hash CHAR(64) NOT NULL UNIQUE, -- sha256 hex of url_address
+    note TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- bookmarks of watched user stored after user is watched are highlighted in reports
+CREATE TABLE IF NOT EXISTS WatchedUsers (
+    watched_user_id SERIAL PRIMARY KEY,
+    user_name VARCHAR(100) NOT NULL UNIQUE,
+    note TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_userurls_created_at ON UserURLs (created_at);
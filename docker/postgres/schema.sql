@@ -3,10 +3,22 @@ CREATE TABLE Users (
     user_id SERIAL PRIMARY KEY,
     user_name VARCHAR(100) NOT NULL UNIQUE,
     bookmark_count INT DEFAULT 0,
-    is_deleted BOOLEAN DEFAULT FALSE,
+    is_deleted BOOLEAN DEFAULT FALSE, -- true when user's page no longer exists
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP -- when bookmark_count was refreshed last
+);
+CREATE INDEX idx_users_updated_at ON Users (updated_at) WHERE is_deleted = FALSE;
+
+-- every observation of user's bookmark count
+CREATE TABLE UserBookmarkCountHistories (
+    history_id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    bookmark_count INT NOT NULL,
+    observed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES Users (user_id)
 );
+CREATE INDEX idx_userbookmarkcounthistories_user_id ON UserBookmarkCountHistories (user_id, observed_at);
+CREATE INDEX idx_userbookmarkcounthistories_observed_at ON UserBookmarkCountHistories (observed_at);
 
 CREATE TABLE Categories (
     category_id SERIAL PRIMARY KEY,
@@ -19,10 +31,11 @@ CREATE TABLE Categories (
 
 CREATE TABLE URLs (
     url_id SERIAL PRIMARY KEY,
-    url_address VARCHAR(256) NOT NULL UNIQUE,
+    url_address TEXT NOT NULL,
+    url_hash CHAR(64) NOT NULL UNIQUE, -- sha256 hex of url_address
     category_code VARCHAR(32) DEFAULT 'unknown',
     is_all BOOLEAN DEFAULT FALSE,
-    title VARCHAR(256) DEFAULT '',
+    title TEXT DEFAULT '',
     bookmark_count INT DEFAULT 0,
     named_user_count INT DEFAULT 0,
     private_user_rate FLOAT DEFAULT 0,
@@ -35,13 +48,113 @@ CREATE TABLE UserURLs (
     user_url_id SERIAL PRIMARY KEY,
     user_id INT NOT NULL,
     url_id INT NOT NULL,
-    is_deleted BOOLEAN DEFAULT FALSE,
+    comment TEXT DEFAULT '',
+    is_deleted BOOLEAN DEFAULT FALSE, -- true when user removed bookmark
+    removed_at TIMESTAMP, -- when removed bookmark was detected
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (user_id) REFERENCES Users (user_id),
     FOREIGN KEY (url_id) REFERENCES URLs (url_id),
     UNIQUE (user_id, url_id)
 );
+CREATE INDEX idx_userurls_created_at ON UserURLs (created_at);
+
+-- staging table to upsert Users and UserURLs of url in bulk. rows are deleted in same transaction
+CREATE UNLOGGED TABLE UserURLStagings (
+    url_id INT NOT NULL,
+    user_name VARCHAR(100) NOT NULL,
+    comment TEXT DEFAULT '',
+    is_deleted BOOLEAN DEFAULT FALSE
+);
+CREATE INDEX idx_userurlstagings_url_id ON UserURLStagings (url_id);
+
+CREATE TABLE URLScores (
+    url_id INT PRIMARY KEY,
+    score FLOAT DEFAULT 0,
+    private_user_rate FLOAT DEFAULT 0,
+    new_user_rate FLOAT DEFAULT 0,
+    deleted_user_rate FLOAT DEFAULT 0,
+    burstiness FLOAT DEFAULT 0,
+    user_overlap FLOAT DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (url_id) REFERENCES URLs (url_id)
+);
+
+-- run of fetch command. items are url addresses or user names
+CREATE TABLE FetchRuns (
+    run_id SERIAL PRIMARY KEY,
+    command VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'running', -- running, completed, failed, interrupted
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- progress of each item in run
+CREATE TABLE FetchRunItems (
+    run_item_id SERIAL PRIMARY KEY,
+    run_id INT NOT NULL,
+    item TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending', -- pending, done, failed
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (run_id) REFERENCES FetchRuns (run_id),
+    UNIQUE (run_id, item)
+);
+CREATE INDEX idx_fetchrunitems_run_id_status ON FetchRunItems (run_id, status);
+
+-- API key of web server. only sha256 hash of key is stored
+CREATE TABLE ApiKeys (
+    api_key_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL, -- first characters of key to identify it
+    key_hash CHAR(64) NOT NULL UNIQUE, -- sha256 hex of key
+    scope VARCHAR(16) NOT NULL DEFAULT 'read', -- read, write
+    rate_limit INT NOT NULL DEFAULT 60, -- requests per minute. 0 is unlimited
+    daily_quota INT NOT NULL DEFAULT 0, -- requests per day. 0 is unlimited
+    is_revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- requests per API key per day to enforce quota
+CREATE TABLE ApiKeyUsages (
+    api_key_id INT NOT NULL,
+    usage_date DATE NOT NULL,
+    request_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, usage_date),
+    FOREIGN KEY (api_key_id) REFERENCES ApiKeys (api_key_id)
+);
+
+-- alert sent for url. same rule of url is not alerted again within dedup window
+CREATE TABLE Alerts (
+    alert_id SERIAL PRIMARY KEY,
+    url_id INT NOT NULL,
+    rule VARCHAR(32) NOT NULL, -- private_user_rate, new_user_rate, deletion_spike, burst
+    value DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (url_id) REFERENCES URLs (url_id)
+);
+CREATE INDEX idx_alerts_url_id_created_at ON Alerts (url_id, created_at);
+
+-- watched url is refreshed by every fetch-bookmark. url may not be stored in URLs yet
+CREATE TABLE WatchedURLs (
+    watched_url_id SERIAL PRIMARY KEY,
+    url_address TEXT NOT NULL,
+    url_hash CHAR(64) NOT NULL UNIQUE, -- sha256 hex of url_address
+    note TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- bookmarks of watched user stored after user is watched are highlighted in reports
+CREATE TABLE WatchedUsers (
+    watched_user_id SERIAL PRIMARY KEY,
+    user_name VARCHAR(100) NOT NULL UNIQUE,
+    note TEXT DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
 
 -- Users table trigger function
 CREATE OR REPLACE FUNCTION set_timestamp_users()
@@ -90,3 +203,19 @@ CREATE TRIGGER before_update_userurls
 BEFORE UPDATE ON UserURLs
 FOR EACH ROW
 EXECUTE FUNCTION set_timestamp_userurls();
+
+-- URLScores table trigger function
+CREATE OR REPLACE FUNCTION set_timestamp_urlscores()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER before_update_urlscores
+BEFORE UPDATE ON URLScores
+FOR EACH ROW
+EXECUTE FUNCTION set_timestamp_urlscores();
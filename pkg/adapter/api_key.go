@@ -0,0 +1,58 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
+)
+
+func APIKeyToEntityModel(apiKey *sqlcgen.GetApiKeysRow) *entities.APIKey {
+	return &entities.APIKey{
+		ID:         apiKey.ApiKeyID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.KeyPrefix,
+		Scope:      entities.APIKeyScope(apiKey.Scope),
+		RateLimit:  int(apiKey.RateLimit),
+		DailyQuota: int(apiKey.DailyQuota),
+		IsRevoked:  apiKey.IsRevoked,
+		CreatedAt:  apiKey.CreatedAt.Time,
+		LastUsedAt: timestampToPointer(apiKey.LastUsedAt),
+		RevokedAt:  timestampToPointer(apiKey.RevokedAt),
+		TodayCount: int(apiKey.RequestCount),
+	}
+}
+
+func APIKeysToEntityModel(apiKeys []sqlcgen.GetApiKeysRow) []entities.APIKey {
+	entityAPIKeys := make([]entities.APIKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		entityAPIKeys = append(entityAPIKeys, *APIKeyToEntityModel(&apiKey))
+	}
+	return entityAPIKeys
+}
+
+func APIKeyByHashToEntityModel(apiKey *sqlcgen.GetApiKeyByHashRow) *entities.APIKey {
+	return &entities.APIKey{
+		ID:         apiKey.ApiKeyID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.KeyPrefix,
+		Scope:      entities.APIKeyScope(apiKey.Scope),
+		RateLimit:  int(apiKey.RateLimit),
+		DailyQuota: int(apiKey.DailyQuota),
+		IsRevoked:  apiKey.IsRevoked,
+		CreatedAt:  apiKey.CreatedAt.Time,
+		LastUsedAt: timestampToPointer(apiKey.LastUsedAt),
+		RevokedAt:  timestampToPointer(apiKey.RevokedAt),
+	}
+}
+
+// nullable timestamp to pointer
+func timestampToPointer(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
+	}
+	t := timestamp.Time
+	return &t
+}
@@ -0,0 +1,32 @@
+package adapter
+
+import (
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
+)
+
+func AllCommentsToEntityModel(comments []sqlcgen.GetAllCommentsRow) []entities.Comment {
+	var commentModels []entities.Comment
+	for _, comment := range comments {
+		commentModels = append(commentModels, entities.Comment{
+			URL:           comment.UrlAddress,
+			UserName:      comment.UserName,
+			BookmarkCount: int(comment.BookmarkCount.Int32),
+			Text:          comment.Comment.String,
+		})
+	}
+	return commentModels
+}
+
+func CommentsByURLsToEntityModel(comments []sqlcgen.GetCommentsByURLsRow) []entities.Comment {
+	var commentModels []entities.Comment
+	for _, comment := range comments {
+		commentModels = append(commentModels, entities.Comment{
+			URL:           comment.UrlAddress,
+			UserName:      comment.UserName,
+			BookmarkCount: int(comment.BookmarkCount.Int32),
+			Text:          comment.Comment.String,
+		})
+	}
+	return commentModels
+}
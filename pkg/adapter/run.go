@@ -0,0 +1,44 @@
+package adapter
+
+import (
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
+)
+
+func FetchRunToEntityModel(run *sqlcgen.GetFetchRunRow) *entities.Run {
+	entityRun := &entities.Run{
+		ID:          run.RunID,
+		Command:     entities.RunCommand(run.Command),
+		Status:      entities.RunStatus(run.Status),
+		TotalCount:  int(run.TotalCount),
+		DoneCount:   int(run.DoneCount),
+		FailedCount: int(run.FailedCount),
+		CreatedAt:   run.CreatedAt.Time,
+		UpdatedAt:   run.UpdatedAt.Time,
+	}
+	if run.FinishedAt.Valid {
+		finishedAt := run.FinishedAt.Time
+		entityRun.FinishedAt = &finishedAt
+	}
+	return entityRun
+}
+
+func FetchRunsToEntityModel(runs []sqlcgen.GetFetchRunsRow) []entities.Run {
+	entityRuns := make([]entities.Run, 0, len(runs))
+	for _, run := range runs {
+		row := sqlcgen.GetFetchRunRow(run)
+		entityRuns = append(entityRuns, *FetchRunToEntityModel(&row))
+	}
+	return entityRuns
+}
+
+func CreateInsertFetchRunItemsParams(runID int32, items []string) []sqlcgen.InsertFetchRunItemsParams {
+	params := make([]sqlcgen.InsertFetchRunItemsParams, 0, len(items))
+	for _, item := range items {
+		params = append(params, sqlcgen.InsertFetchRunItemsParams{
+			RunID: runID,
+			Item:  item,
+		})
+	}
+	return params
+}
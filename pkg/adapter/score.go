@@ -0,0 +1,40 @@
+package adapter
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
+)
+
+func URLScoresToEntityModel(scores []sqlcgen.GetAllURLScoresRow) []entities.SuspicionScore {
+	var scoreModels []entities.SuspicionScore
+	for _, score := range scores {
+		scoreModels = append(scoreModels, entities.SuspicionScore{
+			URLID: score.UrlID,
+			URL:   score.UrlAddress,
+			Title: score.Title.String,
+			Score: score.Score.Float64,
+			Signals: entities.SuspicionSignals{
+				PrivateUserRate: score.PrivateUserRate.Float64,
+				NewUserRate:     score.NewUserRate.Float64,
+				DeletedUserRate: score.DeletedUserRate.Float64,
+				Burstiness:      score.Burstiness.Float64,
+				UserOverlap:     score.UserOverlap.Float64,
+			},
+		})
+	}
+	return scoreModels
+}
+
+func CreateUpsertURLScoreParams(score *entities.SuspicionScore) sqlcgen.UpsertURLScoreParams {
+	return sqlcgen.UpsertURLScoreParams{
+		UrlID:           score.URLID,
+		Score:           pgtype.Float8{Float64: score.Score, Valid: true},
+		PrivateUserRate: pgtype.Float8{Float64: score.Signals.PrivateUserRate, Valid: true},
+		NewUserRate:     pgtype.Float8{Float64: score.Signals.NewUserRate, Valid: true},
+		DeletedUserRate: pgtype.Float8{Float64: score.Signals.DeletedUserRate, Valid: true},
+		Burstiness:      pgtype.Float8{Float64: score.Signals.Burstiness, Valid: true},
+		UserOverlap:     pgtype.Float8{Float64: score.Signals.UserOverlap, Valid: true},
+	}
+}
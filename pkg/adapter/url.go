@@ -13,6 +13,7 @@ func AllURLsToEntityModel(urls []sqlcgen.GetAllURLsRow) []entities.URL {
 		urlModels = append(urlModels, entities.URL{
 			ID:              url.UrlID,
 			Address:         url.UrlAddress,
+			Hash:            url.UrlHash,
 			CategoryCode:    entities.CategoryCode(url.CategoryCode.String),
 			Title:           url.Title.String,
 			BookmarkCount:   url.BookmarkCount.Int32,
@@ -23,12 +24,26 @@ func AllURLsToEntityModel(urls []sqlcgen.GetAllURLsRow) []entities.URL {
 	return urlModels
 }
 
+func AllURLAddressesToEntityModel(urls []sqlcgen.GetAllURLAddressesRow) []entities.URL {
+	urlModels := make([]entities.URL, 0, len(urls))
+	for _, url := range urls {
+		urlModels = append(urlModels, entities.URL{
+			ID:            url.UrlID,
+			Address:       url.UrlAddress,
+			Hash:          url.UrlHash,
+			BookmarkCount: url.BookmarkCount.Int32,
+		})
+	}
+	return urlModels
+}
+
 func URLsByURLAddressesToEntityModel(urls []sqlcgen.GetURLsByURLAddressesRow) []entities.URL {
 	var urlModels []entities.URL
 	for _, url := range urls {
 		urlModels = append(urlModels, entities.URL{
 			ID:              url.UrlID,
 			Address:         url.UrlAddress,
+			Hash:            url.UrlHash,
 			CategoryCode:    entities.CategoryCode(url.CategoryCode.String),
 			Title:           url.Title.String,
 			BookmarkCount:   url.BookmarkCount.Int32,
@@ -61,8 +76,41 @@ func CreateInsertURLsParams(category string, urls []string) []sqlcgen.InsertURLs
 	for _, url := range urls {
 		params = append(params, sqlcgen.InsertURLsParams{
 			UrlAddress:   url,
+			UrlHash:      entities.NewURLHash(url),
 			CategoryCode: pgtextCategory,
 		})
 	}
 	return params
 }
+
+func URLsByFilterToEntityModel(urls []sqlcgen.GetURLsByFilterRow) []entities.URL {
+	var urlModels []entities.URL
+	for _, url := range urls {
+		urlModels = append(urlModels, entities.URL{
+			ID:              url.UrlID,
+			Address:         url.UrlAddress,
+			Hash:            url.UrlHash,
+			CategoryCode:    entities.CategoryCode(url.CategoryCode.String),
+			Title:           url.Title.String,
+			BookmarkCount:   url.BookmarkCount.Int32,
+			NamedUserCount:  url.NamedUserCount.Int32,
+			PrivateUserRate: url.PrivateUserRate.Float64,
+		})
+	}
+	return urlModels
+}
+
+// timestamp on DB is stored as UTC without time zone
+func CreateGetURLsByFilterParams(filter *entities.URLFilter) sqlcgen.GetURLsByFilterParams {
+	return sqlcgen.GetURLsByFilterParams{
+		CategoryCode: pgtype.Text{
+			String: filter.CategoryCode.String(),
+			Valid:  filter.CategoryCode != "",
+		},
+		FromDate:   pgtype.Timestamp{Time: filter.From.UTC(), Valid: !filter.From.IsZero()},
+		ToDate:     pgtype.Timestamp{Time: filter.To.UTC(), Valid: !filter.To.IsZero()},
+		OrderBy:    string(filter.OrderBy),
+		PageOffset: int32(filter.Offset),
+		PageLimit:  pgtype.Int4{Int32: int32(filter.Limit), Valid: filter.Limit != 0},
+	}
+}
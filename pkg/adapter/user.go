@@ -1,6 +1,8 @@
 package adapter
 
 import (
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
 )
@@ -15,3 +17,91 @@ func DBUsersToEntityModel(users []sqlcgen.GetUsersByURLRow) []entities.RDBUser {
 	}
 	return userModels
 }
+
+func CreateInsertUserURLStagingsParams(
+	urlID int32,
+	users []entities.BookmarkUser,
+) []sqlcgen.InsertUserURLStagingsParams {
+	params := make([]sqlcgen.InsertUserURLStagingsParams, 0, len(users))
+	for _, user := range users {
+		params = append(params, sqlcgen.InsertUserURLStagingsParams{
+			UrlID:     urlID,
+			UserName:  user.Name,
+			Comment:   pgtype.Text{String: user.Comment, Valid: true},
+			IsDeleted: pgtype.Bool{Bool: user.IsDeleted, Valid: true},
+		})
+	}
+	return params
+}
+
+func UserToEntityModel(user *sqlcgen.User) *entities.UserProfile {
+	return &entities.UserProfile{
+		Name:          user.UserName,
+		BookmarkCount: int(user.BookmarkCount.Int32),
+		IsDeleted:     user.IsDeleted.Bool,
+		CreatedAt:     user.CreatedAt.Time,
+		UpdatedAt:     user.UpdatedAt.Time,
+	}
+}
+
+func UserURLsToEntityModel(userURLs []sqlcgen.GetUserURLsByUserNameRow) []entities.UserBookmark {
+	bookmarks := make([]entities.UserBookmark, 0, len(userURLs))
+	for _, userURL := range userURLs {
+		bookmark := entities.UserBookmark{
+			URL:          userURL.UrlAddress,
+			Title:        userURL.Title.String,
+			CategoryCode: entities.CategoryCode(userURL.CategoryCode.String),
+			Comment:      userURL.Comment.String,
+			BookmarkedAt: userURL.CreatedAt.Time,
+			IsDeleted:    userURL.IsDeleted.Bool,
+			Score:        userURL.Score,
+		}
+		if userURL.RemovedAt.Valid {
+			removedAt := userURL.RemovedAt.Time
+			bookmark.RemovedAt = &removedAt
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks
+}
+
+func SharedUsersToEntityModel(users []sqlcgen.GetUsersSharingSuspiciousURLsRow) []entities.SharedUser {
+	sharedUsers := make([]entities.SharedUser, 0, len(users))
+	for _, user := range users {
+		sharedUsers = append(sharedUsers, entities.SharedUser{
+			Name:           user.UserName,
+			SharedURLCount: int(user.SharedUrlCount),
+		})
+	}
+	return sharedUsers
+}
+
+func BookmarkCountHistoryToEntityModel(
+	history []sqlcgen.GetUserBookmarkCountHistoryRow,
+) []entities.BookmarkCountPoint {
+	points := make([]entities.BookmarkCountPoint, 0, len(history))
+	for _, point := range history {
+		points = append(points, entities.BookmarkCountPoint{
+			BookmarkCount: int(point.BookmarkCount),
+			ObservedAt:    point.ObservedAt.Time,
+		})
+	}
+	return points
+}
+
+func UserGrowthsToEntityModel(growths []sqlcgen.GetUserGrowthsRow) []entities.UserGrowth {
+	userGrowths := make([]entities.UserGrowth, 0, len(growths))
+	for _, growth := range growths {
+		userGrowths = append(userGrowths, entities.UserGrowth{
+			Name:             growth.UserName,
+			FirstCount:       int(growth.FirstCount),
+			LastCount:        int(growth.LastCount),
+			Growth:           int(growth.Growth),
+			MaxJump:          int(growth.MaxJump),
+			FirstObservedAt:  growth.FirstObservedAt.Time,
+			LastObservedAt:   growth.LastObservedAt.Time,
+			ObservationCount: int(growth.ObservationCount),
+		})
+	}
+	return userGrowths
+}
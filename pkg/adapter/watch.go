@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb/sqlcgen"
+)
+
+func WatchedURLsToEntityModel(urls []sqlcgen.GetWatchedURLsRow) []entities.WatchedURL {
+	entityURLs := make([]entities.WatchedURL, 0, len(urls))
+	for _, url := range urls {
+		entityURLs = append(entityURLs, entities.WatchedURL{
+			Address:       url.UrlAddress,
+			Note:          url.Note.String,
+			Title:         url.Title,
+			BookmarkCount: int(url.BookmarkCount),
+			FetchedAt:     timestampToPointer(url.FetchedAt),
+			CreatedAt:     url.CreatedAt.Time,
+		})
+	}
+	return entityURLs
+}
+
+func WatchedUsersToEntityModel(users []sqlcgen.GetWatchedUsersRow) []entities.WatchedUser {
+	entityUsers := make([]entities.WatchedUser, 0, len(users))
+	for _, user := range users {
+		entityUsers = append(entityUsers, entities.WatchedUser{
+			Name:             user.UserName,
+			Note:             user.Note.String,
+			BookmarkCount:    int(user.BookmarkCount),
+			NewBookmarkCount: int(user.NewBookmarkCount),
+			CreatedAt:        user.CreatedAt.Time,
+		})
+	}
+	return entityUsers
+}
+
+func WatchedUserNewBookmarksToEntityModel(
+	bookmarks []sqlcgen.GetWatchedUserNewBookmarksRow,
+) []entities.WatchedUserBookmark {
+	entityBookmarks := make([]entities.WatchedUserBookmark, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		entityBookmarks = append(entityBookmarks, entities.WatchedUserBookmark{
+			UserName:  bookmark.UserName,
+			URLID:     bookmark.UrlID,
+			URL:       bookmark.UrlAddress,
+			Title:     bookmark.Title.String,
+			Comment:   bookmark.Comment.String,
+			CreatedAt: bookmark.CreatedAt.Time,
+		})
+	}
+	return entityBookmarks
+}
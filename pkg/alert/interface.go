@@ -0,0 +1,77 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+)
+
+// Sink sends alerts of a run to destination
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, alerts []*entities.Alert) error
+}
+
+//
+// Sink Utility
+//
+
+type SinkMode int
+
+const (
+	SinkModeUnknown SinkMode = iota
+	SinkModeStdout           // lines to stdout
+	SinkModeWebhook          // JSON to generic webhook
+	SinkModeSlack            // text to Slack compatible incoming webhook
+	SinkModeSMTP             // email
+)
+
+func ValidateSinkEnv(value string) SinkMode {
+	switch value {
+	case "stdout":
+		return SinkModeStdout
+	case "webhook":
+		return SinkModeWebhook
+	case "slack":
+		return SinkModeSlack
+	case "smtp":
+		return SinkModeSMTP
+	default:
+		return SinkModeUnknown
+	}
+}
+
+// multiSink sends alerts to all sinks
+// it fails only if all sinks failed, so that alerts delivered by any sink are not sent again
+type multiSink struct {
+	logger logger.Logger
+	sinks  []Sink
+}
+
+func NewMultiSink(logger logger.Logger, sinks ...Sink) *multiSink {
+	return &multiSink{
+		logger: logger,
+		sinks:  sinks,
+	}
+}
+
+func (m *multiSink) Name() string {
+	return "multi"
+}
+
+func (m *multiSink) Send(ctx context.Context, alerts []*entities.Alert) error {
+	var errs []error
+	for _, sink := range m.sinks {
+		if err := sink.Send(ctx, alerts); err != nil {
+			m.logger.Warn("failed to send alerts", "sink", sink.Name(), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	if len(errs) == len(m.sinks) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+)
+
+type fakeSink struct {
+	name string
+	err  error
+	sent []*entities.Alert
+}
+
+func (f *fakeSink) Name() string {
+	return f.name
+}
+
+func (f *fakeSink) Send(_ context.Context, alerts []*entities.Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, alerts...)
+	return nil
+}
+
+func TestMultiSinkSend(t *testing.T) {
+	sendErr := errors.New("connection refused")
+	tests := []struct {
+		name     string
+		errs     []error
+		wantErr  bool
+		wantSent []bool
+	}{
+		{name: "all succeeded", errs: []error{nil, nil}, wantErr: false, wantSent: []bool{true, true}},
+		// alerts delivered by any sink must not be sent again
+		{name: "one failed", errs: []error{sendErr, nil}, wantErr: false, wantSent: []bool{false, true}},
+		{name: "all failed", errs: []error{sendErr, sendErr}, wantErr: true, wantSent: []bool{false, false}},
+	}
+	alerts := []*entities.Alert{{URLID: 1, Rule: entities.AlertRuleBurst}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks := make([]Sink, 0, len(tt.errs))
+			fakeSinks := make([]*fakeSink, 0, len(tt.errs))
+			for i, err := range tt.errs {
+				sink := &fakeSink{name: string(rune('a' + i)), err: err}
+				sinks = append(sinks, sink)
+				fakeSinks = append(fakeSinks, sink)
+			}
+
+			err := NewMultiSink(logger.NewNoopLogger(), sinks...).Send(context.Background(), alerts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, sendErr) {
+				t.Errorf("Send() error = %v, want to wrap %v", err, sendErr)
+			}
+			for i, sink := range fakeSinks {
+				if sent := len(sink.sent) != 0; sent != tt.wantSent[i] {
+					t.Errorf("sink %s sent = %v, want %v", sink.name, sent, tt.wantSent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStdoutSinkSend(t *testing.T) {
+	var out bytes.Buffer
+	sink := &stdoutSink{out: &out}
+	alerts := []*entities.Alert{
+		{URL: "https://example.com/a", Title: "a", Rule: entities.AlertRuleBurst, Value: 0.9, Threshold: 0.8},
+		{URL: "https://example.com/b", Title: "b", Rule: entities.AlertRuleNewUserRate, Value: 0.6, Threshold: 0.5},
+	}
+	if err := sink.Send(context.Background(), alerts); err != nil {
+		t.Fatal(err)
+	}
+	want := "[ALERT] burst 0.90 > 0.80: a (https://example.com/a)\n" +
+		"[ALERT] new_user_rate 0.60 > 0.50: b (https://example.com/b)\n"
+	if got := out.String(); got != want {
+		t.Errorf("Send() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+)
+
+// SMTPConfig is server and addresses of email
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // PLAIN auth is used if it's given
+	Password string
+	From     string
+	To       []string
+}
+
+type smtpSink struct {
+	conf *SMTPConfig
+}
+
+func NewSMTPSink(conf *SMTPConfig) *smtpSink {
+	return &smtpSink{conf: conf}
+}
+
+func (s *smtpSink) Name() string {
+	return "smtp"
+}
+
+// net/smtp doesn't take context, so ctx is only checked before sending
+func (s *smtpSink) Send(ctx context.Context, alerts []*entities.Alert) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if s.conf.Username != "" {
+		auth = smtp.PlainAuth("", s.conf.Username, s.conf.Password, s.conf.Host)
+	}
+	addr := net.JoinHostPort(s.conf.Host, strconv.Itoa(s.conf.Port))
+	return smtp.SendMail(addr, auth, s.conf.From, s.conf.To, s.message(alerts))
+}
+
+func (s *smtpSink) message(alerts []*entities.Alert) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", s.conf.From)
+	fmt.Fprintf(&sb, "To: %s\r\n", strings.Join(s.conf.To, ", "))
+	fmt.Fprintf(&sb, "Subject: [hatena-analyzer] %d suspicious URL alerts\r\n", len(alerts))
+	fmt.Fprintf(&sb, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	for _, alert := range alerts {
+		// title may have line breaks which break message
+		sb.WriteString(strings.NewReplacer("\r", " ", "\n", " ").Replace(alert.Message()))
+		sb.WriteString("\r\n")
+	}
+	return []byte(sb.String())
+}
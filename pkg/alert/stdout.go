@@ -0,0 +1,31 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+)
+
+type stdoutSink struct {
+	out io.Writer
+}
+
+func NewStdoutSink() *stdoutSink {
+	return &stdoutSink{out: os.Stdout}
+}
+
+func (s *stdoutSink) Name() string {
+	return "stdout"
+}
+
+func (s *stdoutSink) Send(_ context.Context, alerts []*entities.Alert) error {
+	for _, alert := range alerts {
+		if _, err := fmt.Fprintf(s.out, "[ALERT] %s\n", alert.Message()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
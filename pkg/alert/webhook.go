@@ -0,0 +1,104 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+)
+
+const webhookTimeout = 10 * time.Second
+
+//
+// webhookSink posts alerts as JSON
+// e.g. {"alerts": [{"url_id": 1, "url": "https://...", "rule": "burst", "value": 0.8, "threshold": 0.5, ...}]}
+//
+
+type webhookSink struct {
+	url string
+}
+
+func NewWebhookSink(url string) *webhookSink {
+	return &webhookSink{url: url}
+}
+
+func (w *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (w *webhookSink) Send(ctx context.Context, alerts []*entities.Alert) error {
+	return postJSON(ctx, w.url, map[string]any{"alerts": alerts})
+}
+
+//
+// slackSink posts alerts as text of Slack compatible incoming webhook
+//
+
+type slackSink struct {
+	url string
+}
+
+func NewSlackSink(url string) *slackSink {
+	return &slackSink{url: url}
+}
+
+func (s *slackSink) Name() string {
+	return "slack"
+}
+
+func (s *slackSink) Send(ctx context.Context, alerts []*entities.Alert) error {
+	lines := make([]string, 0, len(alerts)+1)
+	lines = append(lines, fmt.Sprintf(":warning: %d suspicious URL alerts", len(alerts)))
+	for _, alert := range alerts {
+		lines = append(lines, fmt.Sprintf(
+			"• *%s* %.2f > %.2f <%s|%s>",
+			alert.Rule, alert.Value, alert.Threshold, alert.URL, slackEscape(alert.Title),
+		))
+	}
+	return postJSON(ctx, s.url, map[string]string{"text": strings.Join(lines, "\n")})
+}
+
+// characters of control sequences of Slack message
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "|", "¦").Replace(text)
+}
+
+func postJSON(ctx context.Context, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// span is recorded, but trace context is not propagated to external webhook by headers
+	client := &http.Client{Transport: otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator()),
+	)}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
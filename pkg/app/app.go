@@ -2,11 +2,17 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/handler"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
 )
 
 ///
@@ -14,17 +20,35 @@ import (
 ///
 
 type cliApp struct {
+	logger        logger.Logger
 	targetHandler handler.Handler
+	tracer        tracer.Tracer
+	metrics       metrics.Metrics
 }
 
-func NewCLIApp(handler handler.Handler) Application {
+func NewCLIApp(
+	logger logger.Logger,
+	handler handler.Handler,
+	tracer tracer.Tracer,
+	metrics metrics.Metrics,
+) Application {
 	return &cliApp{
+		logger:        logger,
 		targetHandler: handler,
+		tracer:        tracer,
+		metrics:       metrics,
 	}
 }
 
-func (c *cliApp) Run() error {
-	return c.targetHandler.Handler(context.Background())
+// Run executes handler. ctx is canceled by SIGINT/SIGTERM
+// spans are flushed and metrics of the run are pushed to Pushgateway if it's configured
+func (c *cliApp) Run(ctx context.Context) error {
+	err := c.targetHandler.Handler(ctx)
+	closeTracer(ctx, c.logger, c.tracer)
+	if pushErr := c.metrics.Push(context.WithoutCancel(ctx)); pushErr != nil {
+		c.logger.Warn("failed to push metrics", "error", pushErr)
+	}
+	return err
 }
 
 ///
@@ -32,18 +56,83 @@ func (c *cliApp) Run() error {
 ///
 
 type webApp struct {
-	ginEngine *gin.Engine
-	port      uint
+	logger          logger.Logger
+	ginEngine       *gin.Engine
+	tracer          tracer.Tracer
+	port            uint
+	shutdownTimeout time.Duration
+	onShutdown      func() // e.g. close long-lived streams which Shutdown doesn't wait for
 }
 
-func NewWebApp(ginEngine *gin.Engine, port uint) Application {
+func NewWebApp(
+	logger logger.Logger,
+	ginEngine *gin.Engine,
+	tracer tracer.Tracer,
+	port uint,
+	shutdownTimeout time.Duration,
+	onShutdown func(),
+) Application {
 	// create web application
 	if port == 0 {
 		port = 8080
 	}
-	return &webApp{ginEngine: ginEngine, port: port}
+	if shutdownTimeout == 0 {
+		shutdownTimeout = 10 * time.Second
+	}
+	return &webApp{
+		logger:          logger,
+		ginEngine:       ginEngine,
+		tracer:          tracer,
+		port:            port,
+		shutdownTimeout: shutdownTimeout,
+		onShutdown:      onShutdown,
+	}
 }
 
-func (c *webApp) Run() error {
-	return c.ginEngine.Run(fmt.Sprintf(":%d", c.port))
+// Run serves until ctx is canceled by SIGINT/SIGTERM
+// then in-flight requests are waited for until shutdown timeout
+func (c *webApp) Run(ctx context.Context) error {
+	// tracer is shared by all requests. spans are flushed when server is stopped
+	defer closeTracer(ctx, c.logger, c.tracer)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", c.port),
+		Handler:           c.ginEngine,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if c.onShutdown != nil {
+		server.RegisterOnShutdown(c.onShutdown)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		c.logger.Info("web server started", "port", c.port)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	c.logger.Info("web server is shutting down", "timeout", c.shutdownTimeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		c.logger.Error("failed to shutdown web server gracefully", "error", err)
+		return err
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	c.logger.Info("web server stopped")
+	return nil
+}
+
+// closeTracer flushes buffered spans. ctx may be already canceled
+func closeTracer(ctx context.Context, logger logger.Logger, tracer tracer.Tracer) {
+	if err := tracer.Close(context.WithoutCancel(ctx)); err != nil {
+		logger.Warn("failed to close tracer", "error", err)
+	}
 }
@@ -7,9 +7,22 @@ const (
 	AppCodeFetchHatenaPageURLs    = AppCode("FetchHatenaPageURLs")
 	AppCodeFetchBookmarkEntities  = AppCode("FetchBookmarkEntities")
 	AppCodeFetchUserBookmarkCount = AppCode("FetchUserBookmarkCount")
+	AppCodeCalcSuspicionScore     = AppCode("CalcSuspicionScore")
 	AppCodeViewTimeSeries         = AppCode("ViewTimeSeries")
 	AppCodeViewBookmarkDetails    = AppCode("ViewBookmarkDetails")
 	AppCodeViewSummary            = AppCode("ViewSummary")
+	AppCodeViewCommentSimilarity  = AppCode("ViewCommentSimilarity")
+	AppCodeViewUser               = AppCode("ViewUser")
+	AppCodeViewUserGrowth         = AppCode("ViewUserGrowth")
+	AppCodeMergeDuplicateURLs     = AppCode("MergeDuplicateURLs")
+	AppCodeListRuns               = AppCode("ListRuns")
+	AppCodeShowConfig             = AppCode("ShowConfig")
+	AppCodeIssueAPIKey            = AppCode("IssueAPIKey")
+	AppCodeRevokeAPIKey           = AppCode("RevokeAPIKey")
+	AppCodeListAPIKeys            = AppCode("ListAPIKeys")
+	AppCodeAddWatch               = AppCode("AddWatch")
+	AppCodeRemoveWatch            = AppCode("RemoveWatch")
+	AppCodeListWatch              = AppCode("ListWatch")
 
 	AppCodeWeb = AppCode("WebServer")
 )
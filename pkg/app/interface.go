@@ -1,5 +1,9 @@
 package app
 
+import (
+	"context"
+)
+
 type Application interface {
-	Run() error
+	Run(ctx context.Context) error
 }
@@ -10,26 +10,125 @@ import (
 
 type SubCommand struct{}
 
+// URLFilterArgs selects urls stored in DB when --urls is not given
+type URLFilterArgs struct {
+	Category string `arg:"--category"` // e.g. it
+	Date     string `arg:"--date"`     // created date e.g. 2025-02-10
+	From     string `arg:"--from"`     // created date range e.g. 2025-02-01
+	To       string `arg:"--to"`       // created date range e.g. 2025-02-10
+	Order    string `arg:"--order"`    // bookmark_count, private_user_rate
+	Limit    int    `arg:"--limit"`    // top N
+	Page     int    `arg:"--page"`     // 1 origin, used with limit
+}
+
 type FetchBookmarkEntitiesSubCmd struct {
-	URLs    string `arg:"--urls"` // e.g. https://www.google.co.jp/,https://chatgpt.com/
-	Verbose bool   `args:"--verbose"`
+	URLFilterArgs
+	URLs     string `arg:"--urls"`    // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Watched  bool   `arg:"--watched"` // fetch only watched urls for frequent run
+	Verbose  bool   `args:"--verbose"`
+	Resume   int32  `arg:"--resume"`   // run id to resume
+	Progress string `arg:"--progress"` // auto, bar, log, none. default: auto
 }
 
 type FetchUserBookmarkCountSubCmd struct {
-	URLs string `arg:"--urls"` // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	URLs       string `arg:"--urls"`        // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	StaleDays  int    `arg:"--stale-days"`  // refresh users not updated in last given days. default: 7
+	RecentDays int    `arg:"--recent-days"` // prioritize users of urls fetched in last given days. default: 1
+	MaxUsers   int    `arg:"--max-users"`   // max number of users refreshed per run. default: 1000
+	Resume     int32  `arg:"--resume"`      // run id to resume
+	Progress   string `arg:"--progress"`    // auto, bar, log, none. default: auto
 }
 
 type ViewTimeSeriesSubCmd struct {
+	URLFilterArgs
 	URLs string `arg:"--urls"` // e.g. https://www.google.co.jp/,https://chatgpt.com/
 }
 
 type ViewBookmarkDetailsSubCmd struct {
-	URLs string `arg:"--urls"` // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	URLFilterArgs
+	URLs    string `arg:"--urls"`    // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Buckets string `arg:"--buckets"` // upper bounds of histogram e.g. 10,100,1000,10000
 }
 
 type ViewSummarySubCmd struct {
 	URLs      string `arg:"--urls"` // e.g. https://www.google.co.jp/,https://chatgpt.com/
 	Threshold uint   `arg:"--threshold"`
+	Sort      string `arg:"--sort"` // private_user_rate, score
+}
+
+type CalcSuspicionScoreSubCmd struct {
+	URLs    string `arg:"--urls"`    // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Weights string `arg:"--weights"` // JSON file path of score weights
+}
+
+type ViewCommentSimilaritySubCmd struct {
+	URLs      string  `arg:"--urls"`      // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Threshold float64 `arg:"--threshold"` // similarity threshold: 0 < threshold <= 1
+}
+
+type ViewUserSubCmd struct {
+	Name     string  `arg:"--name,required"` // user name
+	MinScore float64 `arg:"--min-score"`     // suspicion score to regard url as suspicious
+}
+
+type ViewUserGrowthSubCmd struct {
+	Days  int `arg:"--days"`  // observed period. default: 7
+	Limit int `arg:"--limit"` // number of users. default: 20
+	Jump  int `arg:"--jump"`  // increase between observations regarded as sudden jump. default: 1000
+}
+
+type MergeDuplicateURLsSubCmd struct {
+	DryRun bool `arg:"--dry-run"` // only print urls to be merged
+}
+
+type RunsSubCmd struct {
+	ListCommand *RunsListSubCmd `arg:"subcommand:list"`
+}
+
+type RunsListSubCmd struct {
+	Limit int `arg:"--limit"` // number of latest runs. default: 20
+}
+
+type ConfigSubCmd struct {
+	ShowCommand *SubCommand `arg:"subcommand:show"`
+}
+
+type APIKeySubCmd struct {
+	IssueCommand  *APIKeyIssueSubCmd  `arg:"subcommand:issue"`
+	RevokeCommand *APIKeyRevokeSubCmd `arg:"subcommand:revoke"`
+	ListCommand   *SubCommand         `arg:"subcommand:list"`
+}
+
+type APIKeyIssueSubCmd struct {
+	Name       string `arg:"--name,required"`           // name to identify client
+	Scope      string `arg:"--scope"`                   // read or write. default: read
+	RateLimit  int    `arg:"--rate-limit" default:"60"` // requests per minute. 0 is unlimited
+	DailyQuota int    `arg:"--daily-quota"`             // requests per day. 0 is unlimited
+}
+
+type APIKeyRevokeSubCmd struct {
+	ID int32 `arg:"--id,required"` // api_key_id shown by `api-key list`
+}
+
+type WatchSubCmd struct {
+	AddCommand    *WatchAddSubCmd    `arg:"subcommand:add"`
+	RemoveCommand *WatchRemoveSubCmd `arg:"subcommand:remove"`
+	ListCommand   *WatchListSubCmd   `arg:"subcommand:list"`
+}
+
+type WatchAddSubCmd struct {
+	URLs  string `arg:"--urls"`  // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Users string `arg:"--users"` // hatena user names e.g. user1,user2
+	Note  string `arg:"--note"`  // reason why urls and users are watched
+}
+
+type WatchRemoveSubCmd struct {
+	URLs  string `arg:"--urls"`  // e.g. https://www.google.co.jp/,https://chatgpt.com/
+	Users string `arg:"--users"` // hatena user names e.g. user1,user2
+}
+
+type WatchListSubCmd struct {
+	Days int `arg:"--days"` // show new bookmarks of watched users in last given days. default: 7
 }
 
 type WebSubCmd struct {
@@ -38,6 +137,10 @@ type WebSubCmd struct {
 
 type Args struct {
 	Version bool // global option
+	// configuration. see envs.Load() for priority
+	Config  string   `arg:"--config,env:CONFIG_FILE" help:"YAML or TOML config file"`
+	Profile string   `arg:"--profile,env:CONFIG_PROFILE" help:"profile in config file e.g. dev, prod"`
+	Set     []string `arg:"--set,separate" help:"override config by KEY=VALUE e.g. --set logger=json"`
 	// URLs    []string `arg:"--urls,env:URLS"` // global option
 
 	// fetch URLs from hatena pages
@@ -46,12 +149,30 @@ type Args struct {
 	FetchBookmarkEntitiesCommand *FetchBookmarkEntitiesSubCmd `arg:"subcommand:fetch-bookmark"`
 	// fetch user bookmark count from bookmark url
 	FetchUserBookmarkCountCommand *FetchUserBookmarkCountSubCmd `arg:"subcommand:fetch-user-bm-count"`
+	// calculate suspicion score of url
+	CalcSuspicionScoreCommand *CalcSuspicionScoreSubCmd `arg:"subcommand:calc-suspicion-score"`
 	// view time series of bookmark
 	ViewTimeSeriesCommand *ViewTimeSeriesSubCmd `arg:"subcommand:view-time-series"`
 	// view bookmark details
 	ViewBookmarkDetailsCommand *ViewBookmarkDetailsSubCmd `arg:"subcommand:view-bookmark-details"`
 	// view bookmark summary
 	ViewSummaryCommand *ViewSummarySubCmd `arg:"subcommand:view-summary"`
+	// view similar comments
+	ViewCommentSimilarityCommand *ViewCommentSimilaritySubCmd `arg:"subcommand:view-comment-similarity"`
+	// view user profile
+	ViewUserCommand *ViewUserSubCmd `arg:"subcommand:view-user"`
+	// view fastest-growing users
+	ViewUserGrowthCommand *ViewUserGrowthSubCmd `arg:"subcommand:view-user-growth"`
+	// normalize url addresses and merge duplicated urls
+	MergeDuplicateURLsCommand *MergeDuplicateURLsSubCmd `arg:"subcommand:merge-duplicate-urls"`
+	// list runs of fetch commands
+	RunsCommand *RunsSubCmd `arg:"subcommand:runs"`
+	// show effective configuration
+	ConfigCommand *ConfigSubCmd `arg:"subcommand:config"`
+	// manage API keys of web server
+	APIKeyCommand *APIKeySubCmd `arg:"subcommand:api-key"`
+	// manage watched urls and users
+	WatchCommand *WatchSubCmd `arg:"subcommand:watch"`
 
 	// web server
 	WebCommand *WebSubCmd `arg:"subcommand:web"`
@@ -71,12 +192,38 @@ func getAppCode(args *Args) app.AppCode {
 		return app.AppCodeFetchBookmarkEntities
 	case args.FetchUserBookmarkCountCommand != nil:
 		return app.AppCodeFetchUserBookmarkCount
+	case args.CalcSuspicionScoreCommand != nil:
+		return app.AppCodeCalcSuspicionScore
 	case args.ViewTimeSeriesCommand != nil:
 		return app.AppCodeViewTimeSeries
 	case args.ViewBookmarkDetailsCommand != nil:
 		return app.AppCodeViewBookmarkDetails
 	case args.ViewSummaryCommand != nil:
 		return app.AppCodeViewSummary
+	case args.ViewCommentSimilarityCommand != nil:
+		return app.AppCodeViewCommentSimilarity
+	case args.ViewUserCommand != nil:
+		return app.AppCodeViewUser
+	case args.ViewUserGrowthCommand != nil:
+		return app.AppCodeViewUserGrowth
+	case args.MergeDuplicateURLsCommand != nil:
+		return app.AppCodeMergeDuplicateURLs
+	case args.RunsCommand != nil && args.RunsCommand.ListCommand != nil:
+		return app.AppCodeListRuns
+	case args.ConfigCommand != nil && args.ConfigCommand.ShowCommand != nil:
+		return app.AppCodeShowConfig
+	case args.APIKeyCommand != nil && args.APIKeyCommand.IssueCommand != nil:
+		return app.AppCodeIssueAPIKey
+	case args.APIKeyCommand != nil && args.APIKeyCommand.RevokeCommand != nil:
+		return app.AppCodeRevokeAPIKey
+	case args.APIKeyCommand != nil && args.APIKeyCommand.ListCommand != nil:
+		return app.AppCodeListAPIKeys
+	case args.WatchCommand != nil && args.WatchCommand.AddCommand != nil:
+		return app.AppCodeAddWatch
+	case args.WatchCommand != nil && args.WatchCommand.RemoveCommand != nil:
+		return app.AppCodeRemoveWatch
+	case args.WatchCommand != nil && args.WatchCommand.ListCommand != nil:
+		return app.AppCodeListWatch
 	case args.WebCommand != nil:
 		return app.AppCodeWeb
 	}
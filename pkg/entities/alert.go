@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// AlertRule is signal of URL checked by alert
+type AlertRule string
+
+const (
+	AlertRulePrivateUserRate AlertRule = "private_user_rate" // rate of private users
+	AlertRuleNewUserRate     AlertRule = "new_user_rate"     // rate of users with few bookmarks
+	AlertRuleDeletionSpike   AlertRule = "deletion_spike"    // users deleted since previous fetch
+	AlertRuleBurst           AlertRule = "burst"             // max growth of bookmarks per hour
+)
+
+func (a AlertRule) String() string {
+	return string(a)
+}
+
+// AlertRules are thresholds of signals as 0-1. 0 disables the rule
+type AlertRules struct {
+	PrivateUserRate float64
+	NewUserRate     float64
+	DeletionSpike   float64
+	Burst           float64
+}
+
+// AlertSignals are values of URL compared with AlertRules
+type AlertSignals struct {
+	PrivateUserRate float64
+	NewUserRate     float64
+	DeletionSpike   float64
+	Burst           float64
+}
+
+// Evaluate returns alerts of rules which signals exceed
+func (a *AlertRules) Evaluate(entityURL *URL, signals *AlertSignals) []*Alert {
+	checks := []struct {
+		rule      AlertRule
+		value     float64
+		threshold float64
+	}{
+		{AlertRulePrivateUserRate, signals.PrivateUserRate, a.PrivateUserRate},
+		{AlertRuleNewUserRate, signals.NewUserRate, a.NewUserRate},
+		{AlertRuleDeletionSpike, signals.DeletionSpike, a.DeletionSpike},
+		{AlertRuleBurst, signals.Burst, a.Burst},
+	}
+	var alerts []*Alert
+	for _, check := range checks {
+		if check.threshold == 0 || check.value <= check.threshold {
+			continue
+		}
+		alerts = append(alerts, &Alert{
+			URLID:     entityURL.ID,
+			URL:       entityURL.Address,
+			Title:     entityURL.Title,
+			Rule:      check.rule,
+			Value:     check.value,
+			Threshold: check.threshold,
+		})
+	}
+	return alerts
+}
+
+type Alert struct {
+	URLID     int32     `json:"url_id"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Rule      AlertRule `json:"rule"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Message is one line description of alert
+// e.g. private_user_rate 0.72 > 0.50: Title (https://...)
+func (a *Alert) Message() string {
+	return fmt.Sprintf("%s %.2f > %.2f: %s (%s)", a.Rule, a.Value, a.Threshold, a.Title, a.URL)
+}
+
+// NewUserRate returns rate of users whose bookmark count is less than NewUserBookmarkCount as 0-1
+func NewUserRate(users []RDBUser) float64 {
+	if len(users) == 0 {
+		return 0
+	}
+	var newUserCount int
+	for _, user := range users {
+		if user.BookmarkCount < NewUserBookmarkCount {
+			newUserCount++
+		}
+	}
+	return float64(newUserCount) / float64(len(users))
+}
+
+// DeletionSpike returns users deleted between last two points divided by latest user count as 0-1
+// summaries must be sorted by time
+func DeletionSpike(summaries []*BookmarkSummary) float64 {
+	if len(summaries) < 2 {
+		return 0
+	}
+	latest := summaries[len(summaries)-1]
+	previous := summaries[len(summaries)-2]
+	if latest.UserCount <= 0 {
+		return 0
+	}
+	deleted := latest.DeletedUserCount - previous.DeletedUserCount
+	if deleted <= 0 {
+		return 0
+	}
+	return min(float64(deleted)/float64(latest.UserCount), 1)
+}
@@ -0,0 +1,138 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAlertRulesEvaluate(t *testing.T) {
+	rules := &AlertRules{
+		PrivateUserRate: 0.7,
+		NewUserRate:     0.5,
+		DeletionSpike:   0.1,
+		Burst:           0.8,
+	}
+	const delta = 0.001
+	tests := []struct {
+		name      string
+		rules     *AlertRules
+		signals   *AlertSignals
+		wantRules []AlertRule
+	}{
+		{name: "no signal", rules: rules, signals: &AlertSignals{}, wantRules: nil},
+		// value equal to threshold doesn't alert
+		{name: "private user rate at threshold", rules: rules, signals: &AlertSignals{PrivateUserRate: 0.7}, wantRules: nil},
+		{
+			name:      "private user rate above threshold",
+			rules:     rules,
+			signals:   &AlertSignals{PrivateUserRate: 0.7 + delta},
+			wantRules: []AlertRule{AlertRulePrivateUserRate},
+		},
+		{name: "new user rate at threshold", rules: rules, signals: &AlertSignals{NewUserRate: 0.5}, wantRules: nil},
+		{
+			name:      "new user rate above threshold",
+			rules:     rules,
+			signals:   &AlertSignals{NewUserRate: 0.5 + delta},
+			wantRules: []AlertRule{AlertRuleNewUserRate},
+		},
+		{name: "deletion spike at threshold", rules: rules, signals: &AlertSignals{DeletionSpike: 0.1}, wantRules: nil},
+		{
+			name:      "deletion spike above threshold",
+			rules:     rules,
+			signals:   &AlertSignals{DeletionSpike: 0.1 + delta},
+			wantRules: []AlertRule{AlertRuleDeletionSpike},
+		},
+		{name: "burst at threshold", rules: rules, signals: &AlertSignals{Burst: 0.8}, wantRules: nil},
+		{
+			name:      "burst above threshold",
+			rules:     rules,
+			signals:   &AlertSignals{Burst: 0.8 + delta},
+			wantRules: []AlertRule{AlertRuleBurst},
+		},
+		{
+			name:      "all rules in order",
+			rules:     rules,
+			signals:   &AlertSignals{PrivateUserRate: 1, NewUserRate: 1, DeletionSpike: 1, Burst: 1},
+			wantRules: []AlertRule{AlertRulePrivateUserRate, AlertRuleNewUserRate, AlertRuleDeletionSpike, AlertRuleBurst},
+		},
+		{
+			name:      "zero threshold disables rule",
+			rules:     &AlertRules{PrivateUserRate: 0, Burst: 0.8},
+			signals:   &AlertSignals{PrivateUserRate: 1, Burst: 1},
+			wantRules: []AlertRule{AlertRuleBurst},
+		},
+	}
+	entityURL := &URL{ID: 1, Address: "https://example.com/a", Title: "title"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts := tt.rules.Evaluate(entityURL, tt.signals)
+			if len(alerts) != len(tt.wantRules) {
+				t.Fatalf("Evaluate() returned %d alerts, want %v", len(alerts), tt.wantRules)
+			}
+			for i, alert := range alerts {
+				if alert.Rule != tt.wantRules[i] {
+					t.Errorf("Evaluate()[%d].Rule = %s, want %s", i, alert.Rule, tt.wantRules[i])
+				}
+				if alert.URLID != entityURL.ID || alert.URL != entityURL.Address || alert.Title != entityURL.Title {
+					t.Errorf("Evaluate()[%d] = %+v, want url of %+v", i, alert, entityURL)
+				}
+				if alert.Value <= alert.Threshold {
+					t.Errorf("Evaluate()[%d] value %v must be greater than threshold %v", i, alert.Value, alert.Threshold)
+				}
+			}
+		})
+	}
+}
+
+func TestNewUserRate(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []RDBUser
+		want  float64
+	}{
+		{name: "no users", users: nil, want: 0},
+		{
+			name:  "bookmark count at boundary is not new user",
+			users: []RDBUser{{BookmarkCount: NewUserBookmarkCount - 1}, {BookmarkCount: NewUserBookmarkCount}},
+			want:  0.5,
+		},
+		{name: "all new users", users: []RDBUser{{BookmarkCount: 0}, {BookmarkCount: 1}}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewUserRate(tt.users); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("NewUserRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeletionSpike(t *testing.T) {
+	point := func(userCount, deletedUserCount int) *BookmarkSummary {
+		return &BookmarkSummary{UserCount: userCount, DeletedUserCount: deletedUserCount}
+	}
+	tests := []struct {
+		name      string
+		summaries []*BookmarkSummary
+		want      float64
+	}{
+		{name: "no summaries", summaries: nil, want: 0},
+		{name: "one summary", summaries: []*BookmarkSummary{point(100, 10)}, want: 0},
+		{name: "no deletion", summaries: []*BookmarkSummary{point(100, 10), point(100, 10)}, want: 0},
+		{name: "deleted users decrease", summaries: []*BookmarkSummary{point(100, 10), point(100, 5)}, want: 0},
+		{name: "zero users", summaries: []*BookmarkSummary{point(0, 0), point(0, 5)}, want: 0},
+		{
+			name:      "only last two points are compared",
+			summaries: []*BookmarkSummary{point(100, 0), point(100, 50), point(100, 60)},
+			want:      0.1,
+		},
+		{name: "capped to one", summaries: []*BookmarkSummary{point(10, 0), point(10, 30)}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeletionSpike(tt.summaries); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("DeletionSpike() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
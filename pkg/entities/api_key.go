@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	// APIKeyPrefix is prefix of issued key to find leaked keys easily
+	APIKeyPrefix = "hta_"
+	// length of key shown to identify it
+	apiKeyDisplayLength = 12
+
+	DefaultAPIKeyRateLimit = 60 // requests per minute
+)
+
+// APIKeyScope is permission of API key. write scope includes read scope
+// - read: view-*, users, runs, GET watch
+// - write: fetch-*, calc-suspicion-score, merge-duplicate-urls, POST/DELETE watch
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead  APIKeyScope = "read"
+	APIKeyScopeWrite APIKeyScope = "write"
+)
+
+func ParseAPIKeyScope(value string) (APIKeyScope, error) {
+	switch APIKeyScope(value) {
+	case APIKeyScopeRead, APIKeyScopeWrite:
+		return APIKeyScope(value), nil
+	case "":
+		return APIKeyScopeRead, nil
+	default:
+		return "", fmt.Errorf("scope must be read or write: %s", value)
+	}
+}
+
+func (a APIKeyScope) String() string {
+	return string(a)
+}
+
+// Allows returns true if scope has permission of required scope
+func (a APIKeyScope) Allows(required APIKeyScope) bool {
+	return a == APIKeyScopeWrite || a == required
+}
+
+// APIKey is API key of web server. key itself is not stored
+type APIKey struct {
+	ID         int32       `json:"api_key_id"`
+	Name       string      `json:"name"`
+	Prefix     string      `json:"key_prefix"`
+	Scope      APIKeyScope `json:"scope"`
+	RateLimit  int         `json:"rate_limit"`  // requests per minute. 0 is unlimited
+	DailyQuota int         `json:"daily_quota"` // requests per day. 0 is unlimited
+	IsRevoked  bool        `json:"is_revoked"`
+	CreatedAt  time.Time   `json:"created_at"`
+	LastUsedAt *time.Time  `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time  `json:"revoked_at,omitempty"`
+	// number of requests of today
+	TodayCount int `json:"today_count"`
+}
+
+// NewAPIKey generates random key. returned key is shown only once
+func NewAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// NewAPIKeyHash returns sha256 hex of key which is stored instead of key
+func NewAPIKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyDisplayPrefix returns first characters of key to identify it
+func APIKeyDisplayPrefix(key string) string {
+	if len(key) < apiKeyDisplayLength {
+		return key
+	}
+	return key[:apiKeyDisplayLength]
+}
+
+// APIKeyUsageDate returns date to count requests for daily quota
+func APIKeyUsageDate(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
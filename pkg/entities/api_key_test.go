@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyScopeAllows(t *testing.T) {
+	tests := []struct {
+		scope    APIKeyScope
+		required APIKeyScope
+		want     bool
+	}{
+		{scope: APIKeyScopeRead, required: APIKeyScopeRead, want: true},
+		{scope: APIKeyScopeRead, required: APIKeyScopeWrite, want: false},
+		{scope: APIKeyScopeWrite, required: APIKeyScopeRead, want: true},
+		{scope: APIKeyScopeWrite, required: APIKeyScopeWrite, want: true},
+		// unknown scope stored in DB has no permission
+		{scope: APIKeyScope("admin"), required: APIKeyScopeRead, want: false},
+		{scope: APIKeyScope("admin"), required: APIKeyScopeWrite, want: false},
+		{scope: APIKeyScope(""), required: APIKeyScopeRead, want: false},
+		{scope: APIKeyScope(""), required: APIKeyScopeWrite, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope.String()+"/"+tt.required.String(), func(t *testing.T) {
+			if got := tt.scope.Allows(tt.required); got != tt.want {
+				t.Errorf("APIKeyScope(%q).Allows(%q) = %v, want %v", tt.scope, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAPIKeyScope(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    APIKeyScope
+		wantErr bool
+	}{
+		{value: "read", want: APIKeyScopeRead},
+		{value: "write", want: APIKeyScopeWrite},
+		{value: "", want: APIKeyScopeRead},
+		{value: "admin", wantErr: true},
+		{value: "READ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseAPIKeyScope(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAPIKeyScope(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAPIKeyScope(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		t.Errorf("NewAPIKey() = %q, want prefix %q", key, APIKeyPrefix)
+	}
+	other, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key == other {
+		t.Errorf("NewAPIKey() returned same key twice: %q", key)
+	}
+	if NewAPIKeyHash(key) == key {
+		t.Errorf("NewAPIKeyHash() must not return key itself")
+	}
+}
+
+func TestAPIKeyUsageDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "utc",
+			now:  time.Date(2025, 2, 10, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "date is counted in utc",
+			now:  time.Date(2025, 2, 11, 8, 0, 0, 0, jst),
+			want: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := APIKeyUsageDate(tt.now); !got.Equal(tt.want) {
+				t.Errorf("APIKeyUsageDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 type BookmarkUser struct {
 	Name        string `json:"name"`
 	IsCommented bool   `json:"is_commented"`
+	Comment     string `json:"comment"`
 	IsDeleted   bool   `json:"is_deleted"`
 }
 
@@ -34,3 +35,41 @@ type BookmarkSummary struct {
 	DeletedUserCount int    `json:"deleted_user_count"`
 	Timestamp        time.Time
 }
+
+// TimeSeries is bookmark summaries of url stored in InfluxDB in time order
+type TimeSeries struct {
+	URL    string             `json:"url"`
+	Title  string             `json:"title"`
+	Points []*TimeSeriesPoint `json:"points"`
+}
+
+type TimeSeriesPoint struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Count            int       `json:"count"`
+	UserCount        int       `json:"user_count"`
+	DeletedUserCount int       `json:"deleted_user_count"`
+	PrivateUserRate  float64   `json:"private_user_rate"`
+}
+
+func NewTimeSeries(url string, summaries []*BookmarkSummary) *TimeSeries {
+	timeSeries := &TimeSeries{
+		URL:    url,
+		Points: make([]*TimeSeriesPoint, 0, len(summaries)),
+	}
+	if len(summaries) != 0 {
+		timeSeries.Title = summaries[0].Title
+	}
+	for _, summary := range summaries {
+		point := &TimeSeriesPoint{
+			Timestamp:        summary.Timestamp,
+			Count:            summary.Count,
+			UserCount:        summary.UserCount,
+			DeletedUserCount: summary.DeletedUserCount,
+		}
+		if summary.Count != 0 {
+			point.PrivateUserRate = PrivateUserRate(summary.Count, summary.UserCount)
+		}
+		timeSeries.Points = append(timeSeries.Points, point)
+	}
+	return timeSeries
+}
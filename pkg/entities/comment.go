@@ -0,0 +1,36 @@
+package entities
+
+// Comment is bookmark comment posted by user on URL
+type Comment struct {
+	URL           string `json:"url"`
+	UserName      string `json:"user_name"`
+	BookmarkCount int    `json:"bookmark_count"` // user's total bookmark count
+	Text          string `json:"text"`
+}
+
+// CommentCluster is group of near-identical comments
+type CommentCluster struct {
+	Comments   []Comment `json:"comments"`
+	Similarity float64   `json:"similarity"` // minimum estimated similarity in cluster
+}
+
+func (c *CommentCluster) URLCount() int {
+	urls := make(map[string]struct{})
+	for _, comment := range c.Comments {
+		urls[comment.URL] = struct{}{}
+	}
+	return len(urls)
+}
+
+func (c *CommentCluster) UserNames() []string {
+	userNames := make([]string, 0, len(c.Comments))
+	seen := make(map[string]struct{})
+	for _, comment := range c.Comments {
+		if _, ok := seen[comment.UserName]; ok {
+			continue
+		}
+		seen[comment.UserName] = struct{}{}
+		userNames = append(userNames, comment.UserName)
+	}
+	return userNames
+}
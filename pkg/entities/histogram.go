@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// default upper bounds of user's bookmark count histogram
+var DefaultHistogramBuckets = []int{10, 100, 1000, 10000}
+
+// ParseHistogramBuckets parses comma separated upper bounds. e.g. 10,100,1000
+func ParseHistogramBuckets(s string) ([]int, error) {
+	if s == "" {
+		return DefaultHistogramBuckets, nil
+	}
+	parts := strings.Split(s, ",")
+	buckets := make([]int, 0, len(parts))
+	for _, part := range parts {
+		bound, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket: %s", part)
+		}
+		if bound <= 0 {
+			return nil, errors.New("bucket must be positive")
+		}
+		if len(buckets) != 0 && bound <= buckets[len(buckets)-1] {
+			return nil, errors.New("buckets must be in ascending order")
+		}
+		buckets = append(buckets, bound)
+	}
+	return buckets, nil
+}
+
+// HistogramBin has users whose bookmark count is less than UpperBound.
+// UpperBound of last bin is 0 which means no upper bound
+type HistogramBin struct {
+	LowerBound int     `json:"lower_bound"`
+	UpperBound int     `json:"upper_bound"`
+	Count      int     `json:"count"`
+	Rate       float64 `json:"rate"` // percentage of users
+}
+
+func (h *HistogramBin) Label() string {
+	if h.UpperBound == 0 {
+		return fmt.Sprintf("over %d", h.LowerBound)
+	}
+	return fmt.Sprintf("less %d", h.UpperBound)
+}
+
+func NewHistogram(values []int, buckets []int) []HistogramBin {
+	bins := make([]HistogramBin, len(buckets)+1)
+	lower := 0
+	for i, bound := range buckets {
+		bins[i] = HistogramBin{LowerBound: lower, UpperBound: bound}
+		lower = bound
+	}
+	bins[len(buckets)] = HistogramBin{LowerBound: lower}
+
+	for _, value := range values {
+		idx := sort.SearchInts(buckets, value+1) // first bound greater than value
+		bins[idx].Count++
+	}
+	if len(values) != 0 {
+		for i := range bins {
+			bins[i].Rate = float64(bins[i].Count) / float64(len(values)) * 100
+		}
+	}
+	return bins
+}
+
+type Percentiles struct {
+	P10 float64 `json:"p10"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+}
+
+func NewPercentiles(values []int) Percentiles {
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+	return Percentiles{
+		P10: Percentile(sorted, 10),
+		P50: Percentile(sorted, 50),
+		P90: Percentile(sorted, 90),
+	}
+}
+
+// Percentile returns p-th percentile by linear interpolation. values must be sorted
+func Percentile(sorted []int, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return float64(sorted[lower])
+	}
+	weight := rank - float64(lower)
+	return float64(sorted[lower])*(1-weight) + float64(sorted[upper])*weight
+}
+
+// Distribution of user's bookmark count
+type Distribution struct {
+	UserCount   int            `json:"user_count"`
+	Histogram   []HistogramBin `json:"histogram"`
+	Percentiles Percentiles    `json:"percentiles"`
+}
+
+func NewDistribution(values []int, buckets []int) *Distribution {
+	return &Distribution{
+		UserCount:   len(values),
+		Histogram:   NewHistogram(values, buckets),
+		Percentiles: NewPercentiles(values),
+	}
+}
+
+// Distance returns total variation distance of histogram rates as 0-1
+// both distributions must be created with same buckets
+func (d *Distribution) Distance(other *Distribution) float64 {
+	if len(d.Histogram) != len(other.Histogram) {
+		return 0
+	}
+	var sum float64
+	for i := range d.Histogram {
+		sum += math.Abs(d.Histogram[i].Rate - other.Histogram[i].Rate)
+	}
+	return sum / 2 / 100
+}
+
+type BookmarkDetails struct {
+	URL          string        `json:"url"`
+	Title        string        `json:"title"`
+	CategoryCode CategoryCode  `json:"category_code"`
+	NewUserRate  float64       `json:"new_user_rate"` // percentage of new users
+	Distribution *Distribution `json:"distribution"`
+	Baseline     *Distribution `json:"category_baseline,omitempty"`
+	Distance     float64       `json:"distance_from_baseline"`
+	Users        []RDBUser     `json:"users"` // users of url in ascending order of bookmark count
+}
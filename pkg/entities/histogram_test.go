@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestParseHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []int
+		wantErr bool
+	}{
+		{name: "empty is default", s: "", want: DefaultHistogramBuckets},
+		{name: "buckets", s: "10,100,1000", want: []int{10, 100, 1000}},
+		{name: "spaces", s: " 5, 50 ,500", want: []int{5, 50, 500}},
+		{name: "one bucket", s: "10", want: []int{10}},
+		{name: "not number", s: "10,abc", wantErr: true},
+		{name: "empty bucket", s: "10,,100", wantErr: true},
+		{name: "zero", s: "0,10", wantErr: true},
+		{name: "negative", s: "-10,10", wantErr: true},
+		{name: "same bound", s: "10,10", wantErr: true},
+		{name: "descending", s: "100,10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHistogramBuckets(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHistogramBuckets(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseHistogramBuckets(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHistogram(t *testing.T) {
+	buckets := []int{10, 100}
+	// upper bound is exclusive
+	values := []int{0, 9, 10, 99, 100, 5000}
+
+	bins := NewHistogram(values, buckets)
+	want := []HistogramBin{
+		{LowerBound: 0, UpperBound: 10, Count: 2},
+		{LowerBound: 10, UpperBound: 100, Count: 2},
+		{LowerBound: 100, UpperBound: 0, Count: 2},
+	}
+	if len(bins) != len(want) {
+		t.Fatalf("NewHistogram() returned %d bins, want %d", len(bins), len(want))
+	}
+	for i, bin := range bins {
+		if bin.LowerBound != want[i].LowerBound || bin.UpperBound != want[i].UpperBound || bin.Count != want[i].Count {
+			t.Errorf("NewHistogram()[%d] = %+v, want %+v", i, bin, want[i])
+		}
+		if math.Abs(bin.Rate-100.0/3) > epsilon {
+			t.Errorf("NewHistogram()[%d].Rate = %v, want %v", i, bin.Rate, 100.0/3)
+		}
+	}
+	if got := bins[0].Label(); got != "less 10" {
+		t.Errorf("Label() = %q, want %q", got, "less 10")
+	}
+	if got := bins[2].Label(); got != "over 100" {
+		t.Errorf("Label() = %q, want %q", got, "over 100")
+	}
+
+	// no value
+	for i, bin := range NewHistogram(nil, buckets) {
+		if bin.Count != 0 || bin.Rate != 0 {
+			t.Errorf("NewHistogram(nil)[%d] = %+v, want empty bin", i, bin)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []int
+		p      float64
+		want   float64
+	}{
+		{name: "empty", sorted: nil, p: 50, want: 0},
+		{name: "one value", sorted: []int{7}, p: 90, want: 7},
+		{name: "min", sorted: []int{1, 2, 3, 4, 5}, p: 0, want: 1},
+		{name: "max", sorted: []int{1, 2, 3, 4, 5}, p: 100, want: 5},
+		{name: "median of odd", sorted: []int{1, 2, 3, 4, 5}, p: 50, want: 3},
+		{name: "median of even is interpolated", sorted: []int{1, 2, 3, 4}, p: 50, want: 2.5},
+		{name: "p10 is interpolated", sorted: []int{0, 10, 20, 30, 40, 50}, p: 10, want: 5},
+		{name: "p90 is interpolated", sorted: []int{0, 10, 20, 30, 40, 50}, p: 90, want: 45},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentile(tt.sorted, tt.p); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPercentiles(t *testing.T) {
+	values := []int{50, 0, 40, 10, 30, 20}
+	got := NewPercentiles(values)
+	want := Percentiles{P10: 5, P50: 25, P90: 45}
+	if math.Abs(got.P10-want.P10) > epsilon ||
+		math.Abs(got.P50-want.P50) > epsilon ||
+		math.Abs(got.P90-want.P90) > epsilon {
+		t.Errorf("NewPercentiles() = %+v, want %+v", got, want)
+	}
+	// given values are not sorted in place
+	if !slices.Equal(values, []int{50, 0, 40, 10, 30, 20}) {
+		t.Errorf("NewPercentiles() modified values: %v", values)
+	}
+}
+
+func TestDistributionDistance(t *testing.T) {
+	buckets := []int{10}
+	tests := []struct {
+		name string
+		a, b []int
+		want float64
+	}{
+		{name: "same", a: []int{1, 20}, b: []int{2, 30}, want: 0},
+		{name: "disjoint", a: []int{1, 2}, b: []int{20, 30}, want: 1},
+		{name: "half", a: []int{1, 2}, b: []int{1, 20}, want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewDistribution(tt.a, buckets)
+			b := NewDistribution(tt.b, buckets)
+			if got := a.Distance(b); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+)
+
+// RunCommand is command whose progress is persisted to be resumed
+type RunCommand string
+
+const (
+	RunCommandFetchBookmark          RunCommand = "fetch-bookmark"
+	RunCommandFetchUserBookmarkCount RunCommand = "fetch-user-bm-count"
+)
+
+func (r RunCommand) String() string {
+	return string(r)
+}
+
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "running"
+	RunStatusCompleted RunStatus = "completed"
+	RunStatusFailed    RunStatus = "failed"
+	// canceled by SIGINT/SIGTERM
+	RunStatusInterrupted RunStatus = "interrupted"
+)
+
+type RunItemStatus string
+
+const (
+	RunItemStatusPending RunItemStatus = "pending"
+	RunItemStatusDone    RunItemStatus = "done"
+	RunItemStatusFailed  RunItemStatus = "failed"
+)
+
+// Run is run of fetch command. items are url addresses or user names
+type Run struct {
+	ID          int32      `json:"run_id"`
+	Command     RunCommand `json:"command"`
+	Status      RunStatus  `json:"status"`
+	TotalCount  int        `json:"total_count"`
+	DoneCount   int        `json:"done_count"`
+	FailedCount int        `json:"failed_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// PendingCount returns number of items which are not processed yet
+func (r *Run) PendingCount() int {
+	return r.TotalCount - r.DoneCount - r.FailedCount
+}
+
+// Duration returns elapsed time of run. last update is used for unfinished run
+func (r *Run) Duration() time.Duration {
+	if r.FinishedAt != nil {
+		return r.FinishedAt.Sub(r.CreatedAt)
+	}
+	return r.UpdatedAt.Sub(r.CreatedAt)
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// user whose bookmark count is less than this value is regarded as new user
+const NewUserBookmarkCount = 10
+
+// SuspicionSignals has each signal normalized to 0-1
+type SuspicionSignals struct {
+	PrivateUserRate float64 `json:"private_user_rate"`
+	NewUserRate     float64 `json:"new_user_rate"`
+	DeletedUserRate float64 `json:"deleted_user_rate"`
+	Burstiness      float64 `json:"burstiness"`
+	UserOverlap     float64 `json:"user_overlap"`
+}
+
+// ScoreWeights is weight of each signal
+type ScoreWeights struct {
+	PrivateUserRate float64 `json:"private_user_rate"`
+	NewUserRate     float64 `json:"new_user_rate"`
+	DeletedUserRate float64 `json:"deleted_user_rate"`
+	Burstiness      float64 `json:"burstiness"`
+	UserOverlap     float64 `json:"user_overlap"`
+}
+
+func DefaultScoreWeights() *ScoreWeights {
+	return &ScoreWeights{
+		PrivateUserRate: 1,
+		NewUserRate:     1,
+		DeletedUserRate: 1,
+		Burstiness:      1,
+		UserOverlap:     1,
+	}
+}
+
+func (w *ScoreWeights) Validate() error {
+	for _, weight := range w.values() {
+		if weight < 0 {
+			return errors.New("weight must not be negative")
+		}
+	}
+	if w.total() == 0 {
+		return errors.New("at least one weight must be positive")
+	}
+	return nil
+}
+
+// Contributions returns points of each signal. sum of them is score (0-100)
+func (w *ScoreWeights) Contributions(signals *SuspicionSignals) *SuspicionSignals {
+	total := w.total()
+	if total == 0 {
+		return &SuspicionSignals{}
+	}
+	return &SuspicionSignals{
+		PrivateUserRate: w.PrivateUserRate * signals.PrivateUserRate / total * 100,
+		NewUserRate:     w.NewUserRate * signals.NewUserRate / total * 100,
+		DeletedUserRate: w.DeletedUserRate * signals.DeletedUserRate / total * 100,
+		Burstiness:      w.Burstiness * signals.Burstiness / total * 100,
+		UserOverlap:     w.UserOverlap * signals.UserOverlap / total * 100,
+	}
+}
+
+// Score returns weighted average of signals as 0-100
+func (w *ScoreWeights) Score(signals *SuspicionSignals) float64 {
+	c := w.Contributions(signals)
+	return c.PrivateUserRate + c.NewUserRate + c.DeletedUserRate + c.Burstiness + c.UserOverlap
+}
+
+func (w *ScoreWeights) values() []float64 {
+	return []float64{w.PrivateUserRate, w.NewUserRate, w.DeletedUserRate, w.Burstiness, w.UserOverlap}
+}
+
+func (w *ScoreWeights) total() float64 {
+	var total float64
+	for _, weight := range w.values() {
+		total += weight
+	}
+	return total
+}
+
+type SuspicionScore struct {
+	URLID   int32            `json:"url_id"`
+	URL     string           `json:"url"`
+	Title   string           `json:"title"`
+	Score   float64          `json:"score"`
+	Signals SuspicionSignals `json:"signals"`
+}
+
+// BurstWindow is time window which growth of bookmarks is normalized to
+// growth over longer interval between observations is scaled down, so that burstiness doesn't depend on fetch interval
+const BurstWindow = time.Hour
+
+// Burstiness returns max growth per BurstWindow between two points divided by latest count as 0-1
+// interval shorter than BurstWindow is regarded as BurstWindow
+// summaries must be sorted by time
+func Burstiness(summaries []*BookmarkSummary) float64 {
+	if len(summaries) < 2 {
+		return 0
+	}
+	latest := summaries[len(summaries)-1].Count
+	if latest <= 0 {
+		return 0
+	}
+	var maxGrowth float64
+	for i := 1; i < len(summaries); i++ {
+		growth := summaries[i].Count - summaries[i-1].Count
+		if growth <= 0 {
+			continue
+		}
+		elapsed := max(summaries[i].Timestamp.Sub(summaries[i-1].Timestamp), BurstWindow)
+		if rate := float64(growth) * float64(BurstWindow) / float64(elapsed); rate > maxGrowth {
+			maxGrowth = rate
+		}
+	}
+	return min(maxGrowth/float64(latest), 1)
+}
@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const epsilon = 1e-9
+
+func TestScoreWeightsScore(t *testing.T) {
+	allOne := &SuspicionSignals{
+		PrivateUserRate: 1,
+		NewUserRate:     1,
+		DeletedUserRate: 1,
+		Burstiness:      1,
+		UserOverlap:     1,
+	}
+	tests := []struct {
+		name    string
+		weights *ScoreWeights
+		signals *SuspicionSignals
+		want    float64
+	}{
+		{name: "all zero signals", weights: DefaultScoreWeights(), signals: &SuspicionSignals{}, want: 0},
+		{name: "all one signals", weights: DefaultScoreWeights(), signals: allOne, want: 100},
+		{
+			name:    "average of default weights",
+			weights: DefaultScoreWeights(),
+			signals: &SuspicionSignals{PrivateUserRate: 0.5, NewUserRate: 1},
+			want:    30,
+		},
+		{
+			name:    "only weighted signal counts",
+			weights: &ScoreWeights{Burstiness: 2},
+			signals: &SuspicionSignals{PrivateUserRate: 1, Burstiness: 0.25},
+			want:    25,
+		},
+		{
+			name:    "weighted average",
+			weights: &ScoreWeights{PrivateUserRate: 3, UserOverlap: 1},
+			signals: &SuspicionSignals{PrivateUserRate: 1, UserOverlap: 0},
+			want:    75,
+		},
+		{name: "zero weights", weights: &ScoreWeights{}, signals: allOne, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.weights.Score(tt.signals); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreWeightsContributions(t *testing.T) {
+	weights := &ScoreWeights{
+		PrivateUserRate: 1,
+		NewUserRate:     2,
+		DeletedUserRate: 0,
+		Burstiness:      1,
+		UserOverlap:     1,
+	}
+	signals := &SuspicionSignals{
+		PrivateUserRate: 0.5,
+		NewUserRate:     1,
+		DeletedUserRate: 1,
+		Burstiness:      0.2,
+		UserOverlap:     0,
+	}
+	want := &SuspicionSignals{
+		PrivateUserRate: 10,
+		NewUserRate:     40,
+		DeletedUserRate: 0,
+		Burstiness:      4,
+		UserOverlap:     0,
+	}
+	got := weights.Contributions(signals)
+	for _, v := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"private_user_rate", got.PrivateUserRate, want.PrivateUserRate},
+		{"new_user_rate", got.NewUserRate, want.NewUserRate},
+		{"deleted_user_rate", got.DeletedUserRate, want.DeletedUserRate},
+		{"burstiness", got.Burstiness, want.Burstiness},
+		{"user_overlap", got.UserOverlap, want.UserOverlap},
+	} {
+		if math.Abs(v.got-v.want) > epsilon {
+			t.Errorf("Contributions() %s = %v, want %v", v.name, v.got, v.want)
+		}
+	}
+	if score := weights.Score(signals); math.Abs(score-54) > epsilon {
+		t.Errorf("Score() = %v, want sum of contributions 54", score)
+	}
+}
+
+func TestScoreWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights *ScoreWeights
+		wantErr bool
+	}{
+		{name: "default", weights: DefaultScoreWeights(), wantErr: false},
+		{name: "only one positive", weights: &ScoreWeights{UserOverlap: 1}, wantErr: false},
+		{name: "all zero", weights: &ScoreWeights{}, wantErr: true},
+		{name: "negative", weights: &ScoreWeights{PrivateUserRate: 1, Burstiness: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.weights.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBurstiness(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// point returns summary observed `hours` after base
+	point := func(hours float64, count int) *BookmarkSummary {
+		return &BookmarkSummary{Count: count, Timestamp: base.Add(time.Duration(hours * float64(time.Hour)))}
+	}
+	tests := []struct {
+		name      string
+		summaries []*BookmarkSummary
+		want      float64
+	}{
+		{name: "no summaries", summaries: nil, want: 0},
+		{name: "one summary", summaries: []*BookmarkSummary{point(0, 100)}, want: 0},
+		{name: "latest count is zero", summaries: []*BookmarkSummary{point(0, 0), point(1, 0)}, want: 0},
+		{name: "no growth", summaries: []*BookmarkSummary{point(0, 100), point(1, 100)}, want: 0},
+		{name: "decrease", summaries: []*BookmarkSummary{point(0, 100), point(1, 80)}, want: 0},
+		{name: "growth in an hour", summaries: []*BookmarkSummary{point(0, 50), point(1, 100)}, want: 0.5},
+		{name: "same growth in a day is scaled down", summaries: []*BookmarkSummary{point(0, 50), point(24, 100)}, want: 0.5 / 24},
+		{name: "interval shorter than window is not scaled up", summaries: []*BookmarkSummary{point(0, 50), point(0.25, 100)}, want: 0.5},
+		{
+			name:      "max growth rate among intervals",
+			summaries: []*BookmarkSummary{point(0, 10), point(24, 70), point(25, 90), point(26, 100)},
+			want:      0.2,
+		},
+		{name: "capped to one", summaries: []*BookmarkSummary{point(0, 100), point(1, 300), point(2, 150)}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Burstiness(tt.summaries); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("Burstiness() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package entities
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/urlnorm"
+)
+
 type URLIDAddress struct {
 	ID      int32
 	Address string
@@ -13,9 +20,30 @@ func FilterURLAddress(urls []URL) []string {
 	return urlAddresses
 }
 
+// NewURLHash returns sha256 hex of normalized url address which is unique key of URLs table
+// url address itself can't be unique key because it may be too long for index,
+// and variants of the same url such as http and https share the key
+// invalid address is hashed as it is
+func NewURLHash(address string) string {
+	if normalized, err := urlnorm.Normalize(address); err == nil {
+		address = normalized
+	}
+	sum := sha256.Sum256([]byte(address))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewURLHashes(addresses []string) []string {
+	hashes := make([]string, len(addresses))
+	for i, address := range addresses {
+		hashes[i] = NewURLHash(address)
+	}
+	return hashes
+}
+
 type URL struct {
 	ID              int32
 	Address         string
+	Hash            string
 	CategoryCode    CategoryCode
 	Title           string
 	BookmarkCount   int32
@@ -23,9 +51,57 @@ type URL struct {
 	PrivateUserRate float64
 }
 
+// DuplicateURLs are urls which have same normalized address
+// UserURLs of Duplicates are merged into Kept, and hash of Kept is updated with Hash
+type DuplicateURLs struct {
+	Hash       string // hash of normalized address
+	Kept       URL
+	Duplicates []URL
+}
+
+// ResolveURLAddresses replaces given addresses with stored addresses which have the same hash
+// stored address is key of time series and entities, and used to fetch bookmark
+func ResolveURLAddresses(addresses []string, stored []URL) []string {
+	storedAddresses := make(map[string]string, len(stored))
+	for _, url := range stored {
+		storedAddresses[NewURLHash(url.Address)] = url.Address
+	}
+	resolved := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if storedAddress, ok := storedAddresses[NewURLHash(address)]; ok {
+			address = storedAddress
+		}
+		resolved = append(resolved, address)
+	}
+	return resolved
+}
+
 type AveragePrivateUserRate struct {
-	CategoryCode           CategoryCode
-	AveragePrivateUserRate float64
+	CategoryCode           CategoryCode `json:"category_code"`
+	AveragePrivateUserRate float64      `json:"average_private_user_rate"`
+}
+
+// URLSummary is url with suspicion metrics
+type URLSummary struct {
+	URLID           int32             `json:"url_id"`
+	URL             string            `json:"url"`
+	Title           string            `json:"title"`
+	CategoryCode    CategoryCode      `json:"category_code"`
+	BookmarkCount   int32             `json:"bookmark_count"`
+	UserCount       int32             `json:"user_count"`
+	PrivateUserRate float64           `json:"private_user_rate"`
+	SuspicionScore  float64           `json:"suspicion_score"`   // 0 if calc-suspicion-score has not run
+	Signals         *SuspicionSignals `json:"signals,omitempty"` // signals of suspicion score
+	IsWatched       bool              `json:"is_watched"`
+	WatchedUsers    []string          `json:"watched_users,omitempty"` // watched users newly bookmarked url
+}
+
+// Summary is urls over threshold of private user rate and averages per category
+// new bookmarks of watched users are included regardless of threshold
+type Summary struct {
+	URLs                 []*URLSummary            `json:"urls"`
+	CategoryAverages     []AveragePrivateUserRate `json:"category_averages"`
+	WatchedUserBookmarks []WatchedUserBookmark    `json:"watched_user_bookmarks"`
 }
 
 type LinkInfo struct {
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type URLOrder string
+
+const (
+	URLOrderNone            URLOrder = ""
+	URLOrderBookmarkCount   URLOrder = "bookmark_count"
+	URLOrderPrivateUserRate URLOrder = "private_user_rate"
+)
+
+func ToURLOrder(s string) (URLOrder, error) {
+	switch URLOrder(s) {
+	case URLOrderNone, URLOrderBookmarkCount, URLOrderPrivateUserRate:
+		return URLOrder(s), nil
+	default:
+		return "", fmt.Errorf("invalid order: %s", s)
+	}
+}
+
+// URLFilter selects urls stored in DB. zero value selects all urls
+type URLFilter struct {
+	CategoryCode CategoryCode // empty means all categories
+	From         time.Time    // created_at >= From
+	To           time.Time    // created_at < To
+	OrderBy      URLOrder
+	Limit        int // 0 means no limit
+	Offset       int
+}
+
+// date format of filter e.g. 2025-02-10
+const URLFilterDateFormat = "2006-01-02"
+
+// NewURLFilter creates filter from command parameters
+// - date: target day. it can't be used with from, to
+// - from, to: date range, to is inclusive
+// - page: 1 origin, used with limit
+func NewURLFilter(
+	category, date, from, to, orderBy string,
+	limit, page int,
+) (*URLFilter, error) {
+	var filter URLFilter
+	if category != "" {
+		categoryCode, err := ToCategoryCode(category)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryCode = categoryCode
+	}
+
+	if date != "" {
+		if from != "" || to != "" {
+			return nil, errors.New("date can't be used with from or to")
+		}
+		from, to = date, date
+	}
+	if from != "" {
+		t, err := parseFilterDate(from)
+		if err != nil {
+			return nil, err
+		}
+		filter.From = t
+	}
+	if to != "" {
+		t, err := parseFilterDate(to)
+		if err != nil {
+			return nil, err
+		}
+		// inclusive
+		filter.To = t.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	order, err := ToURLOrder(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	filter.OrderBy = order
+
+	if limit < 0 || page < 0 {
+		return nil, errors.New("limit and page must not be negative")
+	}
+	if page > 1 && limit == 0 {
+		return nil, errors.New("page requires limit")
+	}
+	filter.Limit = limit
+	if page > 1 {
+		filter.Offset = (page - 1) * limit
+	}
+	return &filter, nil
+}
+
+// IsEmpty returns true if no condition is set
+func (f *URLFilter) IsEmpty() bool {
+	return f == nil || *f == URLFilter{}
+}
+
+// date is treated as JST because hot entries are listed in JST
+func parseFilterDate(s string) (time.Time, error) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		loc = time.FixedZone("JST", 9*60*60)
+	}
+	t, err := time.ParseInLocation(URLFilterDateFormat, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewURLFilter(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, jst)
+	}
+
+	type params struct {
+		category, date, from, to, orderBy string
+		limit, page                       int
+	}
+	tests := []struct {
+		name    string
+		params  params
+		want    URLFilter
+		wantErr bool
+	}{
+		{name: "empty", params: params{}, want: URLFilter{}},
+		{name: "category", params: params{category: "it"}, want: URLFilter{CategoryCode: IT}},
+		{name: "invalid category", params: params{category: "sports"}, wantErr: true},
+		{
+			// to is inclusive
+			name:   "date",
+			params: params{date: "2025-02-10"},
+			want:   URLFilter{From: date(2025, 2, 10), To: date(2025, 2, 11)},
+		},
+		{name: "date with from", params: params{date: "2025-02-10", from: "2025-02-01"}, wantErr: true},
+		{name: "date with to", params: params{date: "2025-02-10", to: "2025-02-11"}, wantErr: true},
+		{
+			name:   "from and to",
+			params: params{from: "2025-02-01", to: "2025-02-28"},
+			want:   URLFilter{From: date(2025, 2, 1), To: date(2025, 3, 1)},
+		},
+		{name: "only from", params: params{from: "2025-02-01"}, want: URLFilter{From: date(2025, 2, 1)}},
+		{name: "only to", params: params{to: "2025-02-01"}, want: URLFilter{To: date(2025, 2, 2)}},
+		{
+			name:   "same from and to",
+			params: params{from: "2025-02-01", to: "2025-02-01"},
+			want:   URLFilter{From: date(2025, 2, 1), To: date(2025, 2, 2)},
+		},
+		{name: "from after to", params: params{from: "2025-02-02", to: "2025-02-01"}, wantErr: true},
+		{name: "invalid date", params: params{date: "2025/02/10"}, wantErr: true},
+		{name: "invalid from", params: params{from: "2025-02-30"}, wantErr: true},
+		{
+			name:   "order by bookmark count",
+			params: params{orderBy: "bookmark_count"},
+			want:   URLFilter{OrderBy: URLOrderBookmarkCount},
+		},
+		{
+			name:   "order by private user rate",
+			params: params{orderBy: "private_user_rate"},
+			want:   URLFilter{OrderBy: URLOrderPrivateUserRate},
+		},
+		{name: "invalid order", params: params{orderBy: "title"}, wantErr: true},
+		{name: "limit", params: params{limit: 20}, want: URLFilter{Limit: 20}},
+		{name: "first page", params: params{limit: 20, page: 1}, want: URLFilter{Limit: 20}},
+		{name: "third page", params: params{limit: 20, page: 3}, want: URLFilter{Limit: 20, Offset: 40}},
+		{name: "page without limit", params: params{page: 2}, wantErr: true},
+		{name: "first page without limit", params: params{page: 1}, want: URLFilter{}},
+		{name: "negative limit", params: params{limit: -1}, wantErr: true},
+		{name: "negative page", params: params{limit: 20, page: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.params
+			got, err := NewURLFilter(p.category, p.date, p.from, p.to, p.orderBy, p.limit, p.page)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewURLFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.CategoryCode != tt.want.CategoryCode ||
+				!got.From.Equal(tt.want.From) ||
+				!got.To.Equal(tt.want.To) ||
+				got.OrderBy != tt.want.OrderBy ||
+				got.Limit != tt.want.Limit ||
+				got.Offset != tt.want.Offset {
+				t.Errorf("NewURLFilter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLFilterIsEmpty(t *testing.T) {
+	var nilFilter *URLFilter
+	if !nilFilter.IsEmpty() {
+		t.Error("IsEmpty() of nil filter = false, want true")
+	}
+	if !(&URLFilter{}).IsEmpty() {
+		t.Error("IsEmpty() of zero filter = false, want true")
+	}
+	if (&URLFilter{Limit: 1}).IsEmpty() {
+		t.Error("IsEmpty() of filter with limit = true, want false")
+	}
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestNewURLHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "normalized address", address: "https://example.com/a", want: sha256Hex("https://example.com/a")},
+		{name: "address is normalized before hash", address: "http://Example.com/a/?utm_source=x#top", want: sha256Hex("https://example.com/a")},
+		{name: "invalid address is hashed as it is", address: "ftp://example.com/a", want: sha256Hex("ftp://example.com/a")},
+		{name: "empty address is hashed as it is", address: "", want: sha256Hex("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewURLHash(tt.address); got != tt.want {
+				t.Errorf("NewURLHash(%q) = %q, want %q", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveURLAddresses(t *testing.T) {
+	stored := []URL{
+		{Address: "http://example.com/a/"},
+		{Address: "https://example.com/b"},
+	}
+	tests := []struct {
+		name      string
+		addresses []string
+		want      []string
+	}{
+		{name: "empty", addresses: nil, want: []string{}},
+		{
+			name:      "address which has same normalized address is replaced with stored one",
+			addresses: []string{"https://example.com/a", "https://example.com/b/"},
+			want:      []string{"http://example.com/a/", "https://example.com/b"},
+		},
+		{
+			name:      "address which is not stored is kept",
+			addresses: []string{"https://example.com/c", "https://example.com/A"},
+			want:      []string{"https://example.com/c", "https://example.com/A"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveURLAddresses(tt.addresses, stored); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveURLAddresses() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,156 @@
 package entities
 
+import (
+	"sort"
+	"time"
+)
+
 type RDBUser struct {
-	UserName      string
-	BookmarkCount int
+	UserName      string `json:"name"`
+	BookmarkCount int    `json:"bookmark_count"`
 }
 
 func PrivateUserRate(totalCount, userCount int) float64 {
 	// private user rate
 	return float64(totalCount-userCount) / float64(totalCount) * 100
 }
+
+// default score to regard url as suspicious
+const DefaultSuspiciousScore = 50
+
+// UserProfile is stored information of a user
+type UserProfile struct {
+	Name               string               `json:"name"`
+	BookmarkCount      int                  `json:"bookmark_count"`
+	IsDeleted          bool                 `json:"is_deleted"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"` // last time bookmark count was stored
+	History            []BookmarkCountPoint `json:"bookmark_count_history"`
+	Bookmarks          []UserBookmark       `json:"bookmarks"`
+	Categories         []CategoryCount      `json:"categories"`
+	RemovedCount       int                  `json:"removed_count"`
+	SuspiciousURLCount int                  `json:"suspicious_url_count"`
+	SharedUsers        []SharedUser         `json:"shared_users"` // users sharing suspicious urls
+}
+
+// UserBookmark is url bookmarked by user
+type UserBookmark struct {
+	URL          string       `json:"url"`
+	Title        string       `json:"title"`
+	CategoryCode CategoryCode `json:"category_code"`
+	Comment      string       `json:"comment"`
+	BookmarkedAt time.Time    `json:"bookmarked_at"` // when bookmark was found first
+	IsDeleted    bool         `json:"is_deleted"`
+	RemovedAt    *time.Time   `json:"removed_at,omitempty"`
+	Score        float64      `json:"score"`
+}
+
+type CategoryCount struct {
+	CategoryCode CategoryCode `json:"category_code"`
+	Count        int          `json:"count"`
+}
+
+type SharedUser struct {
+	Name           string `json:"name"`
+	SharedURLCount int    `json:"shared_url_count"`
+}
+
+// Aggregate counts categories, removed bookmarks and suspicious urls from Bookmarks
+func (u *UserProfile) Aggregate(minScore float64) {
+	counts := make(map[CategoryCode]int)
+	u.RemovedCount = 0
+	u.SuspiciousURLCount = 0
+	for _, bookmark := range u.Bookmarks {
+		counts[bookmark.CategoryCode]++
+		if bookmark.IsDeleted {
+			u.RemovedCount++
+		}
+		if bookmark.Score >= minScore {
+			u.SuspiciousURLCount++
+		}
+	}
+
+	u.Categories = make([]CategoryCount, 0, len(counts))
+	for code, count := range counts {
+		u.Categories = append(u.Categories, CategoryCount{CategoryCode: code, Count: count})
+	}
+	sort.Slice(u.Categories, func(i, j int) bool {
+		if u.Categories[i].Count != u.Categories[j].Count {
+			return u.Categories[i].Count > u.Categories[j].Count
+		}
+		return u.Categories[i].CategoryCode < u.Categories[j].CategoryCode
+	})
+}
+
+// BookmarkCountPoint is an observation of user's bookmark count
+type BookmarkCountPoint struct {
+	BookmarkCount int       `json:"bookmark_count"`
+	ObservedAt    time.Time `json:"observed_at"`
+}
+
+// UserGrowth is growth of user's bookmark count in observed period
+type UserGrowth struct {
+	Name             string    `json:"name"`
+	FirstCount       int       `json:"first_count"`
+	LastCount        int       `json:"last_count"`
+	Growth           int       `json:"growth"`
+	MaxJump          int       `json:"max_jump"` // max increase between consecutive observations
+	FirstObservedAt  time.Time `json:"first_observed_at"`
+	LastObservedAt   time.Time `json:"last_observed_at"`
+	ObservationCount int       `json:"observation_count"`
+}
+
+// GrowthPerDay returns average increase of bookmark count per day
+func (u *UserGrowth) GrowthPerDay() float64 {
+	days := u.LastObservedAt.Sub(u.FirstObservedAt).Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return float64(u.Growth) / days
+}
+
+// IsJumped returns true if bookmark count increased suddenly at least once
+func (u *UserGrowth) IsJumped(threshold int) bool {
+	return threshold > 0 && u.MaxJump >= threshold
+}
+
+const (
+	DefaultStaleDays  = 7
+	DefaultRecentDays = 1
+	DefaultMaxUsers   = 1000
+)
+
+// UserRefreshPolicy decides which users' bookmark count is refreshed when no url is given
+type UserRefreshPolicy struct {
+	StaleDays  int // users not refreshed in last given days are refreshed
+	RecentDays int // users tied to urls fetched in last given days are refreshed first
+	MaxUsers   int // budget of users refreshed per run
+}
+
+// NewUserRefreshPolicy returns policy. 0 means default value
+func NewUserRefreshPolicy(staleDays, recentDays, maxUsers int) *UserRefreshPolicy {
+	if staleDays == 0 {
+		staleDays = DefaultStaleDays
+	}
+	if recentDays == 0 {
+		recentDays = DefaultRecentDays
+	}
+	if maxUsers == 0 {
+		maxUsers = DefaultMaxUsers
+	}
+	return &UserRefreshPolicy{
+		StaleDays:  staleDays,
+		RecentDays: recentDays,
+		MaxUsers:   maxUsers,
+	}
+}
+
+// StaleBefore returns time before which users are regarded as stale
+func (u *UserRefreshPolicy) StaleBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -u.StaleDays)
+}
+
+// RecentSince returns time since which fetched urls are regarded as recent
+func (u *UserRefreshPolicy) RecentSince(now time.Time) time.Time {
+	return now.AddDate(0, 0, -u.RecentDays)
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUserGrowthGrowthPerDay(t *testing.T) {
+	first := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		growth UserGrowth
+		want   float64
+	}{
+		{
+			name:   "one day",
+			growth: UserGrowth{Growth: 30, FirstObservedAt: first, LastObservedAt: first.AddDate(0, 0, 1)},
+			want:   30,
+		},
+		{
+			name:   "several days",
+			growth: UserGrowth{Growth: 30, FirstObservedAt: first, LastObservedAt: first.AddDate(0, 0, 3)},
+			want:   10,
+		},
+		{
+			name:   "less than a day",
+			growth: UserGrowth{Growth: 30, FirstObservedAt: first, LastObservedAt: first.Add(12 * time.Hour)},
+			want:   60,
+		},
+		{
+			name:   "decrease",
+			growth: UserGrowth{Growth: -10, FirstObservedAt: first, LastObservedAt: first.AddDate(0, 0, 2)},
+			want:   -5,
+		},
+		{
+			name:   "same observed time",
+			growth: UserGrowth{Growth: 30, FirstObservedAt: first, LastObservedAt: first},
+			want:   0,
+		},
+		{name: "no observation", growth: UserGrowth{}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.growth.GrowthPerDay(); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("GrowthPerDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserGrowthIsJumped(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxJump   int
+		threshold int
+		want      bool
+	}{
+		{name: "below threshold", maxJump: 99, threshold: 100, want: false},
+		{name: "at threshold", maxJump: 100, threshold: 100, want: true},
+		{name: "above threshold", maxJump: 101, threshold: 100, want: true},
+		{name: "zero threshold is disabled", maxJump: 100, threshold: 0, want: false},
+		{name: "negative threshold is disabled", maxJump: 100, threshold: -1, want: false},
+		{name: "no jump", maxJump: 0, threshold: 1, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth := UserGrowth{MaxJump: tt.maxJump}
+			if got := growth.IsJumped(tt.threshold); got != tt.want {
+				t.Errorf("IsJumped(%d) with max jump %d = %v, want %v", tt.threshold, tt.maxJump, got, tt.want)
+			}
+		})
+	}
+}
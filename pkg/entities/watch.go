@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// days of new bookmarks of watched users shown in reports
+const DefaultWatchDays = 7
+
+// hatena user name: 3-32 characters of alphanumeric, hyphen and underscore
+var userNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{2,31}$`)
+
+func ValidateUserName(userName string) error {
+	if !userNameRegexp.MatchString(userName) {
+		return fmt.Errorf("invalid user name: %s", userName)
+	}
+	return nil
+}
+
+// WatchedURL is URL refreshed by every fetch-bookmark
+type WatchedURL struct {
+	Address       string     `json:"url"`
+	Note          string     `json:"note"`
+	Title         string     `json:"title"`          // empty if url is not fetched yet
+	BookmarkCount int        `json:"bookmark_count"` // 0 if url is not fetched yet
+	FetchedAt     *time.Time `json:"fetched_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// WatchedUser is user whose new bookmarks are highlighted in reports
+type WatchedUser struct {
+	Name             string    `json:"user_name"`
+	Note             string    `json:"note"`
+	BookmarkCount    int       `json:"bookmark_count"`
+	NewBookmarkCount int       `json:"new_bookmark_count"` // bookmarks stored after user is watched
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// WatchedUserBookmark is bookmark of watched user stored after user is watched
+type WatchedUserBookmark struct {
+	UserName  string    `json:"user_name"`
+	URLID     int32     `json:"url_id"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Watchlist struct {
+	URLs         []WatchedURL          `json:"urls"`
+	Users        []WatchedUser         `json:"users"`
+	NewBookmarks []WatchedUserBookmark `json:"new_bookmarks"`
+}
+
+// WatchedUsersByURLID groups user names of bookmarks by url
+func WatchedUsersByURLID(bookmarks []WatchedUserBookmark) map[int32][]string {
+	userNames := make(map[int32][]string)
+	for _, bookmark := range bookmarks {
+		if !slices.Contains(userNames[bookmark.URLID], bookmark.UserName) {
+			userNames[bookmark.URLID] = append(userNames[bookmark.URLID], bookmark.UserName)
+		}
+	}
+	return userNames
+}
+
+// WatchChange is urls and users actually added to or removed from watchlist
+type WatchChange struct {
+	URLs  []string `json:"urls"`
+	Users []string `json:"users"`
+}
+
+func (w *WatchChange) IsEmpty() bool {
+	return len(w.URLs) == 0 && len(w.Users) == 0
+}
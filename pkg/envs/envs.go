@@ -1,24 +1,73 @@
 package envs
 
+import (
+	"time"
+)
+
 type Config struct {
 	IsDebug bool `env:"IS_DEBUG"`
 	// URLS    []string `env:"URLS"`
 	// Logger
 	Logger string `env:"LOGGER,required"` // json, console, none
+	// log file with rotation in CLI mode. stdout/stderr is used if empty
+	LogFile           string `env:"LOG_FILE"`
+	LogFileMaxSizeMB  int    `env:"LOG_FILE_MAX_SIZE_MB" envDefault:"100"`
+	LogFileMaxBackups int    `env:"LOG_FILE_MAX_BACKUPS" envDefault:"5"`
+	LogFileMaxAgeDays int    `env:"LOG_FILE_MAX_AGE_DAYS" envDefault:"30"`
+	LogFileCompress   bool   `env:"LOG_FILE_COMPRESS"`
 	// Tracer
-	Tracer            string `env:"TRACER,required"` // jaeger, datadog, none
+	Tracer            string `env:"TRACER,required"` // jaeger_http, jaeger_grpc, otlp, otlp_grpc, datadog, stdout, none
 	TracerServiceName string `env:"TRACER_SERVICE_NAME,required"`
 	TracerVersion     string `env:"TRACER_VERSION,required"`
+	// collector host:port. default port depends on protocol of TRACER
+	TracerEndpoint string            `env:"TRACER_ENDPOINT"`
+	TracerHeaders  map[string]string `env:"TRACER_HEADERS" envKeyValSeparator:"=" secret:"true"` // key1=value1,key2=value2
+	TracerInsecure bool              `env:"TRACER_INSECURE" envDefault:"true"`                   // false enables TLS
+	TracerCAFile   string            `env:"TRACER_CA_FILE"`                                      // system CA is used if empty
+	// always_on, always_off, traceidratio, parentbased_always_on, parentbased_traceidratio
+	TracerSampler     string  `env:"TRACER_SAMPLER" envDefault:"always_on"`
+	TracerSampleRatio float64 `env:"TRACER_SAMPLE_RATIO" envDefault:"1.0"`
+	// key1=value1,key2=value2 added to resource of spans
+	TracerResourceAttributes map[string]string `env:"TRACER_RESOURCE_ATTRIBUTES" envKeyValSeparator:"="`
+	TracerFile               string            `env:"TRACER_FILE"` // output file of stdout mode. stdout if empty
+	// Metrics
+	Metrics        string `env:"METRICS" envDefault:"none"` // prometheus, none
+	MetricsPushURL string `env:"METRICS_PUSH_URL"`          // Pushgateway URL for CLI e.g. http://localhost:9091
+	MetricsPublic  bool   `env:"METRICS_PUBLIC"`            // /metrics doesn't require API key
 	// Database
 	DBMaxConnections  int32  `env:"DB_MAX_CONNECTIONS,required"`
-	PostgresURL       string `env:"POSTGRES_URL,required"`
+	PostgresURL       string `env:"POSTGRES_URL,required" secret:"true"`
 	InfluxdbURL       string `env:"INFLUXDB_URL,required"`
-	InfluxdbToken     string `env:"INFLUXDB_TOKEN,required"`
+	InfluxdbToken     string `env:"INFLUXDB_TOKEN,required" secret:"true"`
 	InfluxdbBucket    string `env:"INFLUXDB_BUCKET,required"`
 	InfluxdbOrg       string `env:"INFLUXDB_ORG,required"`
-	MongodbURL        string `env:"MONGODB_URL,required"`
+	MongodbURL        string `env:"MONGODB_URL,required" secret:"true"`
 	MongodbDB         string `env:"MONGODB_DB,required"`
 	MongodbCollection string `env:"MONGODB_COLLECTION,required"`
 	// Fetcher
 	MaxWorkers int64 `env:"MAX_WORKERS,required"`
+	// Web server
+	WebShutdownTimeout time.Duration `env:"WEB_SHUTDOWN_TIMEOUT" envDefault:"10s"` // wait for in-flight requests
+	WebAPIAuth         bool          `env:"WEB_API_AUTH" envDefault:"true"`        // API key is required for /api/v1
+	// Score
+	ScoreWeightsFile string `env:"SCORE_WEIGHTS_FILE"` // JSON file of suspicion score weights
+	// Alert after fetch-bookmark. alerting is disabled if no sink is given
+	AlertSinks []string `env:"ALERT_SINKS"` // stdout, webhook, slack, smtp
+	// thresholds of rules as 0-1. 0 disables the rule
+	AlertPrivateUserRate float64       `env:"ALERT_PRIVATE_USER_RATE" envDefault:"0.7"`
+	AlertNewUserRate     float64       `env:"ALERT_NEW_USER_RATE" envDefault:"0.5"`
+	AlertDeletionSpike   float64       `env:"ALERT_DELETION_SPIKE" envDefault:"0.1"`
+	AlertBurst           float64       `env:"ALERT_BURST" envDefault:"0.8"`
+	AlertDedupWindow     time.Duration `env:"ALERT_DEDUP_WINDOW" envDefault:"24h"` // same rule of url is alerted once
+	AlertWebhookURL      string        `env:"ALERT_WEBHOOK_URL" secret:"true"`
+	AlertSlackWebhookURL string        `env:"ALERT_SLACK_WEBHOOK_URL" secret:"true"`
+	AlertSMTPHost        string        `env:"ALERT_SMTP_HOST"`
+	AlertSMTPPort        int           `env:"ALERT_SMTP_PORT" envDefault:"587"`
+	AlertSMTPUsername    string        `env:"ALERT_SMTP_USERNAME"`
+	AlertSMTPPassword    string        `env:"ALERT_SMTP_PASSWORD" secret:"true"`
+	AlertSMTPFrom        string        `env:"ALERT_SMTP_FROM"`
+	AlertSMTPTo          []string      `env:"ALERT_SMTP_TO"` // a@example.com,b@example.com
+
+	// where each value comes from. it's set by Load()
+	sources map[string]Source
 }
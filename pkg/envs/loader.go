@@ -0,0 +1,279 @@
+package envs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+//
+// Layered configuration
+// default < config file < profile in config file < environment variables (.env) < --set flags
+//
+
+// Source is where value of config comes from
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceProfile Source = "profile"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// LoadOptions are given by global CLI options
+type LoadOptions struct {
+	File      string   // YAML or TOML config file. optional
+	Profile   string   // profile in config file e.g. dev, prod. optional
+	Overrides []string // KEY=VALUE given by --set. key is case insensitive e.g. logger=json
+}
+
+// Load builds Config from config file, environment variables and flag overrides, then validates it
+func Load(opts *LoadOptions) (*Config, error) {
+	keys := configKeys()
+	values := make(map[string]string)
+	sources := make(map[string]Source)
+
+	// config file and profile
+	if opts.File != "" {
+		base, profiles, err := readConfigFile(opts.File)
+		if err != nil {
+			return nil, err
+		}
+		if err := setValues(values, sources, base, SourceFile, keys); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", opts.File, err)
+		}
+		if opts.Profile != "" {
+			profile, ok := profiles[opts.Profile]
+			if !ok {
+				return nil, fmt.Errorf(
+					"profile %q is not found in config file %s. available profiles: %s",
+					opts.Profile, opts.File, strings.Join(sortedKeys(profiles), ", "),
+				)
+			}
+			if err := setValues(values, sources, profile, SourceProfile, keys); err != nil {
+				return nil, fmt.Errorf("invalid profile %q in config file %s: %w", opts.Profile, opts.File, err)
+			}
+		}
+	} else if opts.Profile != "" {
+		return nil, errors.New("--profile requires config file given by --config")
+	}
+
+	// environment variables
+	for key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			values[key] = value
+			sources[key] = SourceEnv
+		}
+	}
+
+	// flags
+	for _, override := range opts.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("--set must be KEY=VALUE: %s", override)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if _, ok := keys[key]; !ok {
+			return nil, unknownKeyError(key, keys)
+		}
+		values[key] = value
+		sources[key] = SourceFlag
+	}
+
+	var cfg Config
+	if err := env.ParseWithOptions(&cfg, env.Options{Environment: values}); err != nil {
+		return nil, explainParseError(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.sources = sources
+	return &cfg, nil
+}
+
+// read config file by extension. top level keys except `profiles` are base values
+func readConfigFile(file string) (map[string]any, map[string]map[string]any, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]any
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, nil, fmt.Errorf("config file must be .yaml, .yml or .toml: %s", file)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file %s: %w", file, err)
+	}
+
+	profiles := make(map[string]map[string]any)
+	if rawProfiles, ok := raw["profiles"]; ok {
+		profileMap, ok := rawProfiles.(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("`profiles` must be map of profile name in config file %s", file)
+		}
+		for name, rawProfile := range profileMap {
+			profile, ok := rawProfile.(map[string]any)
+			if !ok {
+				return nil, nil, fmt.Errorf("profile %q must be map in config file %s", name, file)
+			}
+			profiles[name] = profile
+		}
+		delete(raw, "profiles")
+	}
+	return raw, profiles, nil
+}
+
+// keys in config file are environment variable names in any case e.g. postgres_url
+func setValues(
+	values map[string]string,
+	sources map[string]Source,
+	raw map[string]any,
+	source Source,
+	keys map[string]configField,
+) error {
+	for rawKey, rawValue := range raw {
+		key := strings.ToUpper(rawKey)
+		if _, ok := keys[key]; !ok {
+			return unknownKeyError(rawKey, keys)
+		}
+		value, err := stringify(rawValue)
+		if err != nil {
+			return fmt.Errorf("invalid value of %s: %w", rawKey, err)
+		}
+		values[key] = value
+		sources[key] = source
+	}
+	return nil
+}
+
+// convert value in config file to environment variable format
+// - list: a,b
+// - map: k1=v1,k2=v2
+func stringify(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			str, err := stringify(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, str)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		items := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			str, err := stringify(v[key])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, key+"="+str)
+		}
+		return strings.Join(items, ","), nil
+	case string, bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+func unknownKeyError(key string, keys map[string]configField) error {
+	return fmt.Errorf(
+		"unknown key %q. available keys: %s",
+		key, strings.ToLower(strings.Join(sortedKeys(keys), ", ")),
+	)
+}
+
+// make error of env library readable for users of config file
+func explainParseError(err error) error {
+	var aggErr env.AggregateError
+	if !errors.As(err, &aggErr) {
+		return err
+	}
+	errs := make([]error, 0, len(aggErr.Errors))
+	for _, e := range aggErr.Errors {
+		var notSetErr env.VarIsNotSetError
+		var parseErr env.ParseError
+		switch {
+		case errors.As(e, &notSetErr):
+			errs = append(errs, fmt.Errorf(
+				"%s is required: set `%s` in config file, %s environment variable or --set %s=VALUE",
+				notSetErr.Key, strings.ToLower(notSetErr.Key), notSetErr.Key, strings.ToLower(notSetErr.Key),
+			))
+		case errors.As(e, &parseErr):
+			errs = append(errs, fmt.Errorf(
+				"%s must be %s: %w",
+				fieldKey(parseErr.Name), parseErr.Type, parseErr.Err,
+			))
+		default:
+			errs = append(errs, e)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+//
+// fields of Config
+//
+
+type configField struct {
+	index  int
+	secret bool
+}
+
+// configKeys returns fields of Config by environment variable name
+func configKeys() map[string]configField {
+	keys := make(map[string]configField)
+	typ := reflect.TypeOf(Config{})
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		keys[name] = configField{
+			index:  i,
+			secret: field.Tag.Get("secret") == "true",
+		}
+	}
+	return keys
+}
+
+// fieldKey returns environment variable name of field
+func fieldKey(fieldName string) string {
+	field, ok := reflect.TypeOf(Config{}).FieldByName(fieldName)
+	if !ok {
+		return fieldName
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+	return name
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,179 @@
+package envs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const maskedValue = "********"
+
+var (
+	validLoggers = []string{"json", "console", "none"}
+	validTracers = []string{
+		"jaeger_http", "jaeger_grpc", "otlp", "otlp_http", "otlp_grpc", "datadog", "stdout", "none",
+		"jaeger", // deprecated alias of jaeger_http
+	}
+	validMetrics  = []string{"prometheus", "none"}
+	validSinks    = []string{"stdout", "webhook", "slack", "smtp"}
+	validSamplers = []string{
+		"always_on", "always_off", "traceidratio", "parentbased_always_on", "parentbased_traceidratio",
+	}
+)
+
+// Validate checks values which can't be checked by parsing
+func (c *Config) Validate() error {
+	var errs []error
+	oneOf := func(key, value string, valid []string) {
+		if !slices.Contains(valid, value) {
+			errs = append(errs, fmt.Errorf("%s must be one of %s: %q", key, strings.Join(valid, ", "), value))
+		}
+	}
+	positive := func(key string, value int64) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive: %d", key, value))
+		}
+	}
+	validURL := func(key, value string, schemes ...string) {
+		if value == "" {
+			return
+		}
+		u, err := url.Parse(value)
+		if err != nil || !slices.Contains(schemes, u.Scheme) || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s must be URL of %s", key, strings.Join(schemes, ", ")))
+		}
+	}
+
+	oneOf("LOGGER", c.Logger, validLoggers)
+	if c.LogFile != "" {
+		positive("LOG_FILE_MAX_SIZE_MB", int64(c.LogFileMaxSizeMB))
+	}
+	if c.LogFileMaxBackups < 0 || c.LogFileMaxAgeDays < 0 {
+		errs = append(errs, errors.New("LOG_FILE_MAX_BACKUPS and LOG_FILE_MAX_AGE_DAYS must not be negative"))
+	}
+	oneOf("TRACER", c.Tracer, validTracers)
+	oneOf("TRACER_SAMPLER", c.TracerSampler, validSamplers)
+	if c.TracerSampleRatio < 0 || c.TracerSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TRACER_SAMPLE_RATIO must be between 0 and 1: %v", c.TracerSampleRatio))
+	}
+	oneOf("METRICS", c.Metrics, validMetrics)
+	validURL("METRICS_PUSH_URL", c.MetricsPushURL, "http", "https")
+	positive("DB_MAX_CONNECTIONS", int64(c.DBMaxConnections))
+	validURL("POSTGRES_URL", c.PostgresURL, "postgres", "postgresql")
+	validURL("INFLUXDB_URL", c.InfluxdbURL, "http", "https")
+	validURL("MONGODB_URL", c.MongodbURL, "mongodb", "mongodb+srv")
+	positive("MAX_WORKERS", c.MaxWorkers)
+	if c.WebShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("WEB_SHUTDOWN_TIMEOUT must not be negative: %s", c.WebShutdownTimeout))
+	}
+	errs = append(errs, c.validateAlert()...)
+
+	return errors.Join(errs...)
+}
+
+func (c *Config) validateAlert() []error {
+	var errs []error
+	required := func(key, value, sink string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required by %s of ALERT_SINKS", key, sink))
+		}
+	}
+	for _, sink := range c.AlertSinks {
+		if !slices.Contains(validSinks, sink) {
+			errs = append(errs, fmt.Errorf("ALERT_SINKS must be some of %s: %q", strings.Join(validSinks, ", "), sink))
+		}
+		switch sink {
+		case "webhook":
+			required("ALERT_WEBHOOK_URL", c.AlertWebhookURL, sink)
+		case "slack":
+			required("ALERT_SLACK_WEBHOOK_URL", c.AlertSlackWebhookURL, sink)
+		case "smtp":
+			required("ALERT_SMTP_HOST", c.AlertSMTPHost, sink)
+			required("ALERT_SMTP_FROM", c.AlertSMTPFrom, sink)
+			required("ALERT_SMTP_TO", strings.Join(c.AlertSMTPTo, ","), sink)
+		}
+	}
+	rate := func(key string, value float64) {
+		if value < 0 || value > 1 {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and 1: %v", key, value))
+		}
+	}
+	rate("ALERT_PRIVATE_USER_RATE", c.AlertPrivateUserRate)
+	rate("ALERT_NEW_USER_RATE", c.AlertNewUserRate)
+	rate("ALERT_DELETION_SPIKE", c.AlertDeletionSpike)
+	rate("ALERT_BURST", c.AlertBurst)
+	if c.AlertDedupWindow < 0 {
+		errs = append(errs, fmt.Errorf("ALERT_DEDUP_WINDOW must not be negative: %s", c.AlertDedupWindow))
+	}
+	return errs
+}
+
+// Entry is value of config to show
+type Entry struct {
+	Key    string
+	Value  string
+	Source Source
+}
+
+// Entries returns effective config with secrets masked in order of fields
+func (c *Config) Entries() []Entry {
+	keys := configKeys()
+	value := reflect.ValueOf(*c)
+
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range orderedKeys(keys) {
+		field := keys[key]
+		str := fmt.Sprint(value.Field(field.index).Interface())
+		if m, ok := value.Field(field.index).Interface().(map[string]string); ok {
+			str = formatMap(m, field.secret)
+		} else if list, ok := value.Field(field.index).Interface().([]string); ok {
+			str = strings.Join(list, ",")
+		} else if field.secret {
+			str = mask(str)
+		}
+		source, ok := c.sources[key]
+		if !ok {
+			source = SourceDefault
+		}
+		entries = append(entries, Entry{Key: key, Value: str, Source: source})
+	}
+	return entries
+}
+
+func orderedKeys(keys map[string]configField) []string {
+	ordered := sortedKeys(keys)
+	slices.SortFunc(ordered, func(a, b string) int {
+		return keys[a].index - keys[b].index
+	})
+	return ordered
+}
+
+// password of URL is masked, path of URL without password such as webhook URL is masked,
+// otherwise whole value is masked
+func mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+		return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, maskedValue)
+	}
+	return maskedValue
+}
+
+func formatMap(m map[string]string, secret bool) string {
+	items := make([]string, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		value := m[key]
+		if secret {
+			value = maskedValue
+		}
+		items = append(items, key+"="+value)
+	}
+	return strings.Join(items, ",")
+}
@@ -9,16 +9,19 @@ import (
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 )
 
 type entityJSONFetcher struct {
 	logger    logger.Logger
+	metrics   metrics.Metrics
 	entityURL string
 }
 
-func NewEntityJSONFetcher(logger logger.Logger) *entityJSONFetcher {
+func NewEntityJSONFetcher(logger logger.Logger, metrics metrics.Metrics) *entityJSONFetcher {
 	return &entityJSONFetcher{
 		logger:    logger,
+		metrics:   metrics,
 		entityURL: "https://b.hatena.ne.jp/entry/json/",
 	}
 }
@@ -43,7 +46,7 @@ func (e *entityJSONFetcher) Fetch(ctx context.Context, url string) (*entities.Bo
 
 	// warning: net/http.Get must not be called (noctx)
 	// resp, err := http.Get(apiURL)
-	resp, err := Request(ctx, apiURL)
+	resp, err := observedRequest(ctx, e.metrics, metrics.FetchTargetEntityJSON, apiURL)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +68,7 @@ func (e *entityJSONFetcher) Fetch(ctx context.Context, url string) (*entities.Bo
 			Name:        bookmark.User,
 			IsDeleted:   false,
 			IsCommented: bookmark.Comment != "",
+			Comment:     bookmark.Comment,
 		}
 	}
 
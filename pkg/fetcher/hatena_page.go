@@ -10,15 +10,18 @@ import (
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 )
 
 type hatenaPageURLFetcher struct {
-	logger logger.Logger
+	logger  logger.Logger
+	metrics metrics.Metrics
 }
 
-func NewHatenaPageURLFetcher(logger logger.Logger) *hatenaPageURLFetcher {
+func NewHatenaPageURLFetcher(logger logger.Logger, metrics metrics.Metrics) *hatenaPageURLFetcher {
 	return &hatenaPageURLFetcher{
-		logger: logger,
+		logger:  logger,
+		metrics: metrics,
 	}
 }
 
@@ -32,7 +35,7 @@ func (h *hatenaPageURLFetcher) Fetch(
 	// h.logger.Debug("hatenaPageURLFetcher.Fetch() fetching urls of page: ", "url", url)
 
 	// Request
-	resp, err := Request(ctx, url)
+	resp, err := observedRequest(ctx, h.metrics, metrics.FetchTargetHatenaPage, url)
 	if err != nil {
 		return nil, err
 	}
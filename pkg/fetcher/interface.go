@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 )
@@ -14,6 +15,9 @@ type EntityJSONFetcher interface {
 	Fetch(ctx context.Context, url string) (*entities.Bookmark, error)
 }
 
+// ErrUserNotFound is returned when user's page no longer exists
+var ErrUserNotFound = errors.New("user is not found")
+
 type UserBookmarkCountFetcher interface {
 	Fetch(ctx context.Context, userName string) (int, error)
 }
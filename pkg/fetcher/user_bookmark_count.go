@@ -10,16 +10,19 @@ import (
 	"golang.org/x/net/html"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 )
 
 type userBookmarkCountFetcher struct {
 	logger  logger.Logger
+	metrics metrics.Metrics
 	userURL string
 }
 
-func NewUserBookmarkCountFetcher(logger logger.Logger) *userBookmarkCountFetcher {
+func NewUserBookmarkCountFetcher(logger logger.Logger, metrics metrics.Metrics) *userBookmarkCountFetcher {
 	return &userBookmarkCountFetcher{
 		logger:  logger,
+		metrics: metrics,
 		userURL: "https://b.hatena.ne.jp/%s/",
 	}
 }
@@ -29,12 +32,15 @@ func NewUserBookmarkCountFetcher(logger logger.Logger) *userBookmarkCountFetcher
 func (u *userBookmarkCountFetcher) Fetch(ctx context.Context, userName string) (int, error) {
 	// Request
 	userURL := fmt.Sprintf(u.userURL, userName)
-	resp, err := Request(ctx, userURL)
+	resp, err := observedRequest(ctx, u.metrics, metrics.FetchTargetUserBookmarkCount, userURL)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, fmt.Errorf("%w: %s", ErrUserNotFound, userName)
+	}
 	if resp.StatusCode != http.StatusOK {
 		u.logger.Error("failed to get user", "status_code", resp.StatusCode)
 		return 0, fmt.Errorf("failed to get user: status: %d", resp.StatusCode)
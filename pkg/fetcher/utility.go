@@ -3,6 +3,12 @@ package fetcher
 import (
 	"context"
 	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 )
 
 func Request(ctx context.Context, targetURL string) (*http.Response, error) {
@@ -16,7 +22,12 @@ func Request(ctx context.Context, targetURL string) (*http.Response, error) {
 		return nil, err
 	}
 
-	client := &http.Client{}
+	// child span of ctx is created per request
+	// trace context is not propagated by headers because target is external host
+	client := &http.Client{Transport: otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithPropagators(propagation.NewCompositeTextMapPropagator()),
+	)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -24,3 +35,19 @@ func Request(ctx context.Context, targetURL string) (*http.Response, error) {
 
 	return resp, nil
 }
+
+// observedRequest sends request and observes latency and status code as metrics of target
+func observedRequest(
+	ctx context.Context,
+	m metrics.Metrics,
+	target, targetURL string,
+) (*http.Response, error) {
+	start := time.Now()
+	resp, err := Request(ctx, targetURL)
+	var statusCode int
+	if err == nil {
+		statusCode = resp.StatusCode
+	}
+	m.ObserveFetch(target, statusCode, time.Since(start))
+	return resp, err
+}
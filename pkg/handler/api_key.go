@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+// API keys are managed only by CLI
+
+//
+// issueAPIKeyCLIHandler
+//
+
+type issueAPIKeyCLIHandler struct {
+	logger     logger.Logger
+	usecase    usecase.APIKeyUsecaser
+	name       string
+	scope      entities.APIKeyScope
+	rateLimit  int
+	dailyQuota int
+}
+
+func NewIssueAPIKeyCLIHandler(
+	logger logger.Logger,
+	usecase usecase.APIKeyUsecaser,
+	name string,
+	scope entities.APIKeyScope,
+	rateLimit int,
+	dailyQuota int,
+) *issueAPIKeyCLIHandler {
+	return &issueAPIKeyCLIHandler{
+		logger:     logger,
+		usecase:    usecase,
+		name:       name,
+		scope:      scope,
+		rateLimit:  rateLimit,
+		dailyQuota: dailyQuota,
+	}
+}
+
+func (i *issueAPIKeyCLIHandler) Handler(ctx context.Context) error {
+	i.logger.Info("issueAPIKeyCLIHandler Handler")
+
+	apiKeyID, key, err := i.usecase.Issue(ctx, i.name, i.scope, i.rateLimit, i.dailyQuota)
+	if err != nil {
+		i.logger.Error("failed to issue API key", "error", err)
+		return err
+	}
+
+	fmt.Println("[API key issued]")
+	fmt.Printf(" - api_key_id: %d, name: %s, scope: %s\n", apiKeyID, i.name, i.scope)
+	fmt.Printf(" - rate_limit: %d/min, daily_quota: %d/day (0 is unlimited)\n", i.rateLimit, i.dailyQuota)
+	fmt.Printf(" - key: %s\n", key)
+	fmt.Println(" key can't be shown again. keep it safe")
+	return nil
+}
+
+// dummy
+func (i *issueAPIKeyCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// revokeAPIKeyCLIHandler
+//
+
+type revokeAPIKeyCLIHandler struct {
+	logger   logger.Logger
+	usecase  usecase.APIKeyUsecaser
+	apiKeyID int32
+}
+
+func NewRevokeAPIKeyCLIHandler(
+	logger logger.Logger,
+	usecase usecase.APIKeyUsecaser,
+	apiKeyID int32,
+) *revokeAPIKeyCLIHandler {
+	return &revokeAPIKeyCLIHandler{
+		logger:   logger,
+		usecase:  usecase,
+		apiKeyID: apiKeyID,
+	}
+}
+
+func (r *revokeAPIKeyCLIHandler) Handler(ctx context.Context) error {
+	r.logger.Info("revokeAPIKeyCLIHandler Handler")
+
+	if err := r.usecase.Revoke(ctx, r.apiKeyID); err != nil {
+		r.logger.Error("failed to revoke API key", "api_key_id", r.apiKeyID, "error", err)
+		return err
+	}
+	fmt.Printf("API key %d is revoked\n", r.apiKeyID)
+	return nil
+}
+
+// dummy
+func (r *revokeAPIKeyCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// listAPIKeysCLIHandler
+//
+
+type listAPIKeysCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.APIKeyUsecaser
+}
+
+func NewListAPIKeysCLIHandler(
+	logger logger.Logger,
+	usecase usecase.APIKeyUsecaser,
+) *listAPIKeysCLIHandler {
+	return &listAPIKeysCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (l *listAPIKeysCLIHandler) Handler(ctx context.Context) error {
+	l.logger.Info("listAPIKeysCLIHandler Handler")
+
+	apiKeys, err := l.usecase.List(ctx)
+	if err != nil {
+		l.logger.Error("failed to list API keys", "error", err)
+		return err
+	}
+
+	fmt.Println("[API keys]")
+	for _, apiKey := range apiKeys {
+		l.print(&apiKey)
+	}
+	return nil
+}
+
+func (l *listAPIKeysCLIHandler) print(apiKey *entities.APIKey) {
+	status := "active"
+	if apiKey.IsRevoked {
+		status = "revoked"
+	}
+	lastUsed := "-"
+	if apiKey.LastUsedAt != nil {
+		lastUsed = times.FormatToString(times.ToJPTime(*apiKey.LastUsedAt))
+	}
+	fmt.Printf(
+		" - api_key_id: %d, name: %s, key: %s..., scope: %s, status: %s\n",
+		apiKey.ID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.Scope,
+		status,
+	)
+	fmt.Printf(
+		"   rate_limit: %d/min, daily_quota: %d/day, today: %d, created: %s, last used: %s\n",
+		apiKey.RateLimit,
+		apiKey.DailyQuota,
+		apiKey.TodayCount,
+		times.FormatToString(times.ToJPTime(apiKey.CreatedAt)),
+		lastUsed,
+	)
+}
+
+// dummy
+func (l *listAPIKeysCLIHandler) WebHandler(_ *gin.Context) {
+}
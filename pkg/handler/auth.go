@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// APIKeyAuth authenticates request by API key with required scope
+// key is given by `Authorization: Bearer <key>` or `X-API-Key: <key>` header
+func APIKeyAuth(authUsecase usecase.AuthenticateAPIKeyUsecaser, required entities.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		apiKey, err := authUsecase.Execute(ctx, requestAPIKey(c), required)
+		if err != nil {
+			status, retryAfter := authErrorStatus(err)
+			if retryAfter != 0 {
+				c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			}
+			if status == http.StatusInternalServerError {
+				respondError(c, status, "failed to authenticate API key")
+				return
+			}
+			logger.FromContext(ctx).Warn("request is rejected", "error", err)
+			respondError(c, status, err.Error())
+			return
+		}
+
+		// api_key_id is added to logs of request
+		ctx = logger.WithFields(ctx, "api_key_id", apiKey.ID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		return key
+	}
+	scheme, key, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(key)
+	}
+	return ""
+}
+
+// authErrorStatus returns status code and duration to retry for error
+func authErrorStatus(err error) (int, time.Duration) {
+	switch {
+	case errors.Is(err, usecase.ErrAPIKeyInvalid), errors.Is(err, usecase.ErrAPIKeyRevoked):
+		return http.StatusUnauthorized, 0
+	case errors.Is(err, usecase.ErrAPIKeyScope):
+		return http.StatusForbidden, 0
+	case errors.Is(err, usecase.ErrAPIKeyRateLimited):
+		return http.StatusTooManyRequests, time.Minute
+	case errors.Is(err, usecase.ErrAPIKeyQuotaExceeded):
+		// quota is reset at 00:00 UTC
+		now := time.Now()
+		return http.StatusTooManyRequests, entities.APIKeyUsageDate(now).AddDate(0, 0, 1).Sub(now).Round(time.Second)
+	default:
+		return http.StatusInternalServerError, 0
+	}
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+func TestAuthErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantRetryAfter bool
+	}{
+		{name: "invalid", err: usecase.ErrAPIKeyInvalid, wantStatus: http.StatusUnauthorized},
+		{name: "revoked", err: usecase.ErrAPIKeyRevoked, wantStatus: http.StatusUnauthorized},
+		{name: "scope", err: usecase.ErrAPIKeyScope, wantStatus: http.StatusForbidden},
+		{name: "rate limited", err: usecase.ErrAPIKeyRateLimited, wantStatus: http.StatusTooManyRequests, wantRetryAfter: true},
+		{name: "quota exceeded", err: usecase.ErrAPIKeyQuotaExceeded, wantStatus: http.StatusTooManyRequests, wantRetryAfter: true},
+		{name: "wrapped", err: fmt.Errorf("wrapped: %w", usecase.ErrAPIKeyRevoked), wantStatus: http.StatusUnauthorized},
+		{name: "unknown error", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, retryAfter := authErrorStatus(tt.err)
+			if status != tt.wantStatus {
+				t.Errorf("authErrorStatus() status = %d, want %d", status, tt.wantStatus)
+			}
+			if tt.wantRetryAfter != (retryAfter > 0) {
+				t.Errorf("authErrorStatus() retryAfter = %v, want positive %v", retryAfter, tt.wantRetryAfter)
+			}
+			// quota is reset at 00:00 UTC
+			if retryAfter > 24*time.Hour {
+				t.Errorf("authErrorStatus() retryAfter = %v, want less than or equal to a day", retryAfter)
+			}
+		})
+	}
+}
+
+type fakeAuthUsecase struct {
+	apiKey *entities.APIKey
+	err    error
+	// given arguments
+	key      string
+	required entities.APIKeyScope
+}
+
+func (f *fakeAuthUsecase) Execute(
+	_ context.Context,
+	key string,
+	required entities.APIKeyScope,
+) (*entities.APIKey, error) {
+	f.key = key
+	f.required = required
+	return f.apiKey, f.err
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		header         map[string]string
+		err            error
+		wantKey        string
+		wantStatus     int
+		wantRetryAfter bool
+	}{
+		{
+			name:       "x-api-key header",
+			header:     map[string]string{"X-API-Key": "hta_key"},
+			wantKey:    "hta_key",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "bearer token",
+			header:     map[string]string{"Authorization": "bearer  hta_key "},
+			wantKey:    "hta_key",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other authorization scheme is ignored",
+			header:     map[string]string{"Authorization": "Basic hta_key"},
+			err:        usecase.ErrAPIKeyInvalid,
+			wantKey:    "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no key",
+			err:        usecase.ErrAPIKeyInvalid,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "scope",
+			header:     map[string]string{"X-API-Key": "hta_key"},
+			err:        usecase.ErrAPIKeyScope,
+			wantKey:    "hta_key",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:           "rate limited",
+			header:         map[string]string{"X-API-Key": "hta_key"},
+			err:            usecase.ErrAPIKeyRateLimited,
+			wantKey:        "hta_key",
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: true,
+		},
+		{
+			name:       "internal error",
+			header:     map[string]string{"X-API-Key": "hta_key"},
+			err:        errors.New("connection refused"),
+			wantKey:    "hta_key",
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUsecase := &fakeAuthUsecase{apiKey: &entities.APIKey{ID: 1}, err: tt.err}
+			router := gin.New()
+			router.GET("/", APIKeyAuth(authUsecase, entities.APIKeyScopeWrite), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if authUsecase.key != tt.wantKey {
+				t.Errorf("given key = %q, want %q", authUsecase.key, tt.wantKey)
+			}
+			if authUsecase.required != entities.APIKeyScopeWrite {
+				t.Errorf("given scope = %q, want %q", authUsecase.required, entities.APIKeyScopeWrite)
+			}
+			retryAfter, _ := strconv.Atoi(rec.Header().Get("Retry-After"))
+			if tt.wantRetryAfter != (retryAfter > 0) {
+				t.Errorf("Retry-After = %q, want positive %v", rec.Header().Get("Retry-After"), tt.wantRetryAfter)
+			}
+		})
+	}
+}
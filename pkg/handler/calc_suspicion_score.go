@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+//
+// calcSuspicionScoreCLIHandler
+//
+
+type calcSuspicionScoreCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.CalcSuspicionScoreUsecaser
+	urls    []string
+}
+
+func NewCalcSuspicionScoreCLIHandler(
+	logger logger.Logger,
+	usecase usecase.CalcSuspicionScoreUsecaser,
+	urls []string,
+) *calcSuspicionScoreCLIHandler {
+	return &calcSuspicionScoreCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		urls:    urls,
+	}
+}
+
+func (cs *calcSuspicionScoreCLIHandler) Handler(ctx context.Context) error {
+	cs.logger.Info("calcSuspicionScoreCLIHandler Handler")
+
+	err := cs.usecase.Execute(ctx, cs.urls)
+	if err != nil {
+		cs.logger.Error("failed to calculate suspicion score", "error", err)
+	}
+	return err
+}
+
+// dummy
+func (cs *calcSuspicionScoreCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// calcSuspicionScoreWebHandler
+//
+
+type calcSuspicionScoreWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.CalcSuspicionScoreUsecaser
+}
+
+func NewCalcSuspicionScoreWebHandler(
+	logger logger.Logger,
+	usecase usecase.CalcSuspicionScoreUsecaser,
+) *calcSuspicionScoreWebHandler {
+	return &calcSuspicionScoreWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (cs *calcSuspicionScoreWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (cs *calcSuspicionScoreWebHandler) WebHandler(c *gin.Context) {
+	cs.logger.Info("calcSuspicionScoreWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req calcSuspicionScoreRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		cs.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
+	}
+
+	err := cs.usecase.Execute(ctx, req.URLs)
+	if err != nil {
+		cs.logger.Error("failed to calculate suspicion score", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to calculate suspicion score")
+		return
+	}
+
+	cs.logger.Info("successfully calculated suspicion score")
+	c.JSON(http.StatusOK, gin.H{"message": "successfully calculated suspicion score"})
+}
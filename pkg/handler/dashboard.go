@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// static files of dashboard. data is read from /api/v1 by browser with API key
+//
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// DashboardFS returns files of dashboard to be served by gin engine
+func DashboardFS() http.FileSystem {
+	sub, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		// never happens because directory is embedded
+		panic(err)
+	}
+	return http.FS(sub)
+}
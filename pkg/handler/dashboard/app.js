@@ -0,0 +1,482 @@
+// Dashboard of hatena-analyzer. data is read from /api/v1 with API key saved in localStorage
+"use strict";
+
+const API_BASE = "../api/v1";
+const API_KEY_STORAGE = "hatena-analyzer-api-key";
+const CATEGORIES = [
+  "all", "general", "social", "economics", "life", "knowledge", "it", "fun", "entertainment", "game",
+];
+const HIGH_SCORE = 50;
+const COLORS = ["#0969da", "#1a7f37", "#cf222e", "#8250df"];
+
+const $ = (id) => document.getElementById(id);
+
+let summary = null;
+
+//
+// API
+//
+
+async function request(path, body) {
+  const headers = { "X-API-Key": localStorage.getItem(API_KEY_STORAGE) || "" };
+  const options = { headers };
+  if (body !== undefined) {
+    options.method = "POST";
+    options.body = JSON.stringify(body);
+    headers["Content-Type"] = "application/json";
+  }
+  const res = await fetch(`${API_BASE}${path}`, options);
+  const data = await res.json().catch(() => ({}));
+  if (!res.ok) {
+    const details = (data.details || []).map((d) => `${d.field} ${d.message}`).join(", ");
+    throw new Error(`${res.status}: ${data.error || res.statusText}${details ? ` (${details})` : ""}`);
+  }
+  return data;
+}
+
+function setStatus(message, isError = false) {
+  $("status").textContent = message;
+  $("status").classList.toggle("error", isError);
+}
+
+//
+// DOM helpers
+//
+
+function el(tag, attrs = {}, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    if (key === "class") {
+      node.className = value;
+    } else if (key.startsWith("on")) {
+      node.addEventListener(key.slice(2), value);
+    } else {
+      node.setAttribute(key, value);
+    }
+  }
+  for (const child of children) {
+    node.append(child instanceof Node ? child : document.createTextNode(String(child)));
+  }
+  return node;
+}
+
+function fmt(value, digits = 1) {
+  return Number(value || 0).toFixed(digits);
+}
+
+function formatDate(value) {
+  return value ? new Date(value).toLocaleString() : "-";
+}
+
+//
+// SVG charts
+//
+
+const SVG_NS = "http://www.w3.org/2000/svg";
+
+function svg(tag, attrs = {}, text) {
+  const node = document.createElementNS(SVG_NS, tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    node.setAttribute(key, value);
+  }
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  return node;
+}
+
+// lineChart draws series of {label, values: [{x: Date, y: number}]}
+function lineChart(container, series, options = {}) {
+  container.replaceChildren();
+  const points = series.flatMap((s) => s.values);
+  if (points.length === 0) {
+    container.append(el("p", { class: "muted" }, "no data"));
+    return;
+  }
+  const width = 720;
+  const height = 240;
+  const pad = { top: 12, right: 16, bottom: 28, left: 56 };
+  const xs = points.map((p) => p.x.getTime());
+  const ys = points.map((p) => p.y);
+  const minX = Math.min(...xs);
+  const maxX = Math.max(...xs);
+  const minY = options.zero ? 0 : Math.min(...ys);
+  const maxY = Math.max(...ys, minY + 1);
+  const scaleX = (x) => pad.left + ((x - minX) / (maxX - minX || 1)) * (width - pad.left - pad.right);
+  const scaleY = (y) => height - pad.bottom - ((y - minY) / (maxY - minY)) * (height - pad.top - pad.bottom);
+
+  const root = svg("svg", { viewBox: `0 0 ${width} ${height}`, role: "img" });
+  for (let i = 0; i <= 4; i++) {
+    const y = minY + ((maxY - minY) * i) / 4;
+    root.append(
+      svg("line", { x1: pad.left, x2: width - pad.right, y1: scaleY(y), y2: scaleY(y), stroke: "#eaeef2" }),
+      svg("text", { x: pad.left - 6, y: scaleY(y) + 4, "text-anchor": "end" }, fmt(y, 0)),
+    );
+  }
+  root.append(
+    svg("text", { x: pad.left, y: height - 8 }, new Date(minX).toLocaleDateString()),
+    svg("text", { x: width - pad.right, y: height - 8, "text-anchor": "end" }, new Date(maxX).toLocaleDateString()),
+  );
+  series.forEach((s, i) => {
+    const d = s.values.map((p, j) => `${j === 0 ? "M" : "L"}${scaleX(p.x.getTime())},${scaleY(p.y)}`).join(" ");
+    root.append(svg("path", { d, fill: "none", stroke: COLORS[i % COLORS.length], "stroke-width": 2 }));
+  });
+  container.append(root, legend(series.map((s) => s.label)));
+}
+
+// barChart draws groups of bars. labels are x axis, series are {label, values: number[]}
+function barChart(container, labels, series, options = {}) {
+  container.replaceChildren();
+  if (labels.length === 0) {
+    container.append(el("p", { class: "muted" }, "no data"));
+    return;
+  }
+  const width = 480;
+  const height = 240;
+  const pad = { top: 12, right: 8, bottom: 40, left: 40 };
+  const maxY = Math.max(...series.flatMap((s) => s.values), 1);
+  const groupWidth = (width - pad.left - pad.right) / labels.length;
+  const barWidth = (groupWidth * 0.8) / series.length;
+  const scaleY = (y) => ((height - pad.top - pad.bottom) * y) / maxY;
+
+  const root = svg("svg", { viewBox: `0 0 ${width} ${height}`, role: "img" });
+  labels.forEach((label, i) => {
+    const x = pad.left + groupWidth * i + groupWidth * 0.1;
+    series.forEach((s, j) => {
+      const h = scaleY(s.values[i] || 0);
+      const bar = svg("rect", {
+        x: x + barWidth * j,
+        y: height - pad.bottom - h,
+        width: Math.max(barWidth - 1, 1),
+        height: h,
+        fill: s.color || COLORS[j % COLORS.length],
+      });
+      bar.append(svg("title", {}, `${label} ${s.label}: ${fmt(s.values[i], options.digits ?? 1)}`));
+      root.append(bar);
+    });
+    root.append(svg("text", {
+      x: x + (groupWidth * 0.8) / 2,
+      y: height - pad.bottom + 14,
+      "text-anchor": "middle",
+    }, label));
+  });
+  root.append(svg("text", { x: pad.left - 6, y: pad.top + 8, "text-anchor": "end" }, fmt(maxY, 0)));
+  container.append(root);
+  if (series.length > 1) {
+    container.append(legend(series.map((s) => s.label), series.map((s) => s.color)));
+  }
+}
+
+function legend(labels, colors = []) {
+  return el("div", { class: "legend" }, ...labels.map((label, i) => {
+    const item = el("span", {}, label);
+    item.style.color = colors[i] || COLORS[i % COLORS.length];
+    return item;
+  }));
+}
+
+//
+// URLs
+//
+
+async function loadSummary() {
+  setStatus("loading...");
+  try {
+    const data = await request(
+      `/view-summary?threshold=${encodeURIComponent($("threshold").value || 0)}&sort=${$("sort").value}`,
+    );
+    summary = data.summary;
+    renderSummary();
+    setStatus(`loaded at ${new Date().toLocaleTimeString()}`);
+  } catch (err) {
+    setStatus(err.message, true);
+  }
+}
+
+function renderSummary() {
+  const category = $("category").value;
+  const minScore = Number($("min-score").value || 0);
+  const search = $("search").value.trim().toLowerCase();
+  const urls = summary.urls.filter((u) =>
+    (category === "" || u.category_code === category) &&
+    u.suspicion_score >= minScore &&
+    (search === "" || u.title.toLowerCase().includes(search) || u.url.toLowerCase().includes(search)));
+
+  $("url-count").textContent = `${urls.length} / ${summary.urls.length}`;
+  $("urls").tBodies[0].replaceChildren(...urls.map((u) => {
+    // watched URL and URL newly bookmarked by watched users are highlighted
+    const watchedUsers = u.watched_users || [];
+    const title = el("td", {}, u.title || u.url);
+    if (u.is_watched) {
+      title.append(" ", el("span", { class: "badge" }, "watched"));
+    }
+    if (watchedUsers.length !== 0) {
+      title.append(" ", el("span", { class: "badge user" }, watchedUsers.join(", ")));
+    }
+    const row = el(
+      "tr",
+      { class: watchedUsers.length !== 0 ? "clickable watched-user" : "clickable", onclick: () => selectURL(u, row) },
+      title,
+      el("td", {}, u.category_code),
+      el("td", { class: "num" }, u.bookmark_count),
+      el("td", { class: "num" }, u.user_count),
+      el("td", { class: "num" }, fmt(u.private_user_rate)),
+      el("td", { class: u.suspicion_score >= HIGH_SCORE ? "num high" : "num" }, fmt(u.suspicion_score)),
+    );
+    return row;
+  }));
+
+  const bookmarks = summary.watched_user_bookmarks || [];
+  $("watched-panel").hidden = bookmarks.length === 0;
+  $("watched-bookmark-count").textContent = bookmarks.length;
+  $("watched-bookmarks").tBodies[0].replaceChildren(...bookmarks.map((b) => el(
+    "tr",
+    {},
+    el("td", {}, el("a", { href: "#", onclick: (e) => { e.preventDefault(); selectUser(b.user_name); } }, b.user_name)),
+    el("td", {}, el("a", { href: b.url, target: "_blank", rel: "noopener noreferrer" }, b.title || b.url)),
+    el("td", {}, b.comment),
+    el("td", {}, formatDate(b.created_at)),
+  )));
+
+  const averages = summary.category_averages || [];
+  barChart(
+    $("category-chart"),
+    averages.map((a) => a.category_code),
+    [{ label: "private user rate", values: averages.map((a) => a.average_private_user_rate) }],
+  );
+}
+
+async function selectURL(u, row) {
+  for (const selected of document.querySelectorAll("#urls tr.selected")) {
+    selected.classList.remove("selected");
+  }
+  row.classList.add("selected");
+  $("user-detail").hidden = true;
+  $("url-detail").hidden = false;
+  $("url-title").textContent = u.title || u.url;
+  $("url-link").textContent = u.url;
+  $("url-link").href = u.url;
+  $("url-signals").textContent = u.signals
+    ? Object.entries(u.signals).map(([key, value]) => `${key}: ${fmt(value, 2)}`).join(", ")
+    : "suspicion score is not calculated";
+  setStatus("loading details...");
+
+  const [timeSeries, details] = await Promise.allSettled([
+    request("/view-time-series", { urls: [u.url] }),
+    request("/view-bookmark-details", { urls: [u.url] }),
+  ]);
+
+  if (timeSeries.status === "fulfilled" && timeSeries.value.time_series.length !== 0) {
+    renderTimeSeries(timeSeries.value.time_series[0]);
+  } else {
+    lineChart($("timeseries-chart"), []);
+  }
+  if (details.status === "fulfilled" && details.value.details.length !== 0) {
+    renderDetails(details.value.details[0]);
+  } else {
+    barChart($("histogram-chart"), [], []);
+    $("users").tBodies[0].replaceChildren();
+  }
+  const failed = [timeSeries, details].filter((r) => r.status === "rejected");
+  setStatus(failed.length === 0 ? "" : failed[0].reason.message, failed.length !== 0);
+  $("url-detail").scrollIntoView({ behavior: "smooth" });
+}
+
+function renderTimeSeries(timeSeries) {
+  const points = timeSeries.points.map((p) => ({ ...p, x: new Date(p.timestamp) }));
+  lineChart($("timeseries-chart"), [
+    { label: "bookmarks", values: points.map((p) => ({ x: p.x, y: p.count })) },
+    { label: "users", values: points.map((p) => ({ x: p.x, y: p.user_count })) },
+    { label: "deleted users", values: points.map((p) => ({ x: p.x, y: p.deleted_user_count })) },
+  ], { zero: true });
+}
+
+function renderDetails(detail) {
+  const bins = detail.distribution.histogram;
+  const labels = bins.map((b) => (b.upper_bound === 0 ? `${b.lower_bound}+` : `<${b.upper_bound}`));
+  const series = [{ label: "this URL", values: bins.map((b) => b.rate), color: COLORS[0] }];
+  if (detail.category_baseline) {
+    series.push({
+      label: `${detail.category_code} baseline`,
+      values: detail.category_baseline.histogram.map((b) => b.rate),
+      color: "#8c959f",
+    });
+  }
+  barChart($("histogram-chart"), labels, series);
+  const p = detail.distribution.percentiles;
+  $("histogram-note").textContent =
+    `% of users per bookmark count. new user rate: ${fmt(detail.new_user_rate)}%, ` +
+    `p10/p50/p90: ${fmt(p.p10, 0)}/${fmt(p.p50, 0)}/${fmt(p.p90, 0)}, ` +
+    `distance from baseline: ${fmt(detail.distance_from_baseline, 3)}`;
+
+  const users = detail.users || [];
+  $("user-count").textContent = users.length;
+  $("users").tBodies[0].replaceChildren(...users.map((user) => el(
+    "tr",
+    { class: "clickable", onclick: () => selectUser(user.name) },
+    el("td", {}, user.name),
+    el("td", { class: "num" }, user.bookmark_count),
+  )));
+}
+
+//
+// users
+//
+
+async function selectUser(name) {
+  setStatus(`loading ${name}...`);
+  let data;
+  try {
+    data = await request(`/users/${encodeURIComponent(name)}`);
+  } catch (err) {
+    setStatus(err.message, true);
+    return;
+  }
+  setStatus("");
+  const user = data.user;
+  $("user-detail").hidden = false;
+  $("user-name").textContent = user.name;
+  $("user-summary").textContent =
+    `bookmarks: ${user.bookmark_count}, suspicious URLs: ${user.suspicious_url_count}, ` +
+    `removed: ${user.removed_count}${user.is_deleted ? ", deleted" : ""}`;
+
+  lineChart($("user-history-chart"), [{
+    label: "bookmark count",
+    values: (user.bookmark_count_history || []).map((p) => ({ x: new Date(p.observed_at), y: p.bookmark_count })),
+  }]);
+  const categories = user.categories || [];
+  barChart(
+    $("user-category-chart"),
+    categories.map((c) => c.category_code),
+    [{ label: "bookmarks", values: categories.map((c) => c.count) }],
+    { digits: 0 },
+  );
+  $("shared-users").replaceChildren(...(user.shared_users || []).map((shared) => el(
+    "li",
+    {},
+    el("a", { href: "#", onclick: (e) => { e.preventDefault(); selectUser(shared.name); } }, shared.name),
+    ` (${shared.shared_url_count} URLs)`,
+  )));
+  $("user-bookmarks").tBodies[0].replaceChildren(...(user.bookmarks || []).map((b) => el(
+    "tr",
+    {},
+    el("td", {}, el("a", { href: b.url, target: "_blank", rel: "noopener noreferrer" }, b.title || b.url)),
+    el("td", {}, b.comment),
+    el("td", {}, formatDate(b.bookmarked_at)),
+    el("td", { class: b.score >= HIGH_SCORE ? "num high" : "num" }, fmt(b.score)),
+  )));
+  $("user-detail").scrollIntoView({ behavior: "smooth" });
+}
+
+//
+// Runs
+// progress events are read from SSE stream by fetch() instead of EventSource to send API key in header
+//
+
+const RUNS_RETRY_MS = 5000;
+const runs = new Map();
+let runsAbort = null;
+
+async function streamRuns() {
+  if (runsAbort) {
+    runsAbort.abort();
+  }
+  runsAbort = new AbortController();
+  const { signal } = runsAbort;
+  try {
+    const res = await fetch(`${API_BASE}/runs/events`, {
+      headers: { "X-API-Key": localStorage.getItem(API_KEY_STORAGE) || "" },
+      signal,
+    });
+    if (!res.ok) {
+      $("runs-status").textContent = `(${res.status}: stream is not available)`;
+      return;
+    }
+    $("runs-status").textContent = "(live)";
+    const reader = res.body.pipeThrough(new TextDecoderStream()).getReader();
+    let buffer = "";
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) {
+        break;
+      }
+      buffer += value;
+      // events are separated by blank line. lines starting with ":" are keep-alive comments
+      let end;
+      while ((end = buffer.indexOf("\n\n")) >= 0) {
+        const data = buffer.slice(0, end).split("\n")
+          .filter((line) => line.startsWith("data:"))
+          .map((line) => line.slice(5))
+          .join("\n");
+        buffer = buffer.slice(end + 2);
+        if (data) {
+          renderRunEvent(JSON.parse(data));
+        }
+      }
+    }
+  } catch (e) {
+    if (signal.aborted) {
+      return;
+    }
+  }
+  // reconnect when server is restarted
+  $("runs-status").textContent = "(reconnecting)";
+  setTimeout(() => !signal.aborted && streamRuns(), RUNS_RETRY_MS);
+}
+
+function renderRunEvent(event) {
+  const run = runs.get(event.run_id) || { status: "running" };
+  run.event = event;
+  if (event.item) {
+    run.item = event.item;
+    run.itemFailed = event.type === "failed";
+  }
+  if (event.type === "finished") {
+    run.status = event.status;
+  }
+  runs.set(event.run_id, run);
+
+  $("runs-panel").hidden = false;
+  const rows = [...runs.entries()].sort(([a], [b]) => b - a).map(([id, r]) => {
+    const counts = r.event.counts;
+    return el(
+      "tr",
+      {},
+      el("td", {}, id),
+      el("td", {}, r.event.command),
+      el("td", {}, el("progress", { max: counts.total || 1, value: counts.done + counts.failed })),
+      el("td", { class: "num" }, `${counts.done} / ${counts.total}`),
+      el("td", { class: counts.failed ? "num high" : "num" }, counts.failed),
+      el("td", { class: r.itemFailed ? "item failed" : "item", title: r.item || "" }, r.item || ""),
+      el("td", {}, r.status),
+    );
+  });
+  $("runs").tBodies[0].replaceChildren(...rows);
+}
+
+//
+// init
+//
+
+function init() {
+  $("category").append(...CATEGORIES.map((c) => el("option", { value: c }, c)));
+  $("api-key").value = localStorage.getItem(API_KEY_STORAGE) || "";
+  $("auth").addEventListener("submit", (e) => {
+    e.preventDefault();
+    localStorage.setItem(API_KEY_STORAGE, $("api-key").value.trim());
+    loadSummary();
+    streamRuns();
+  });
+  $("filters").addEventListener("submit", (e) => {
+    e.preventDefault();
+    loadSummary();
+  });
+  // filters except threshold and sort are applied without request
+  for (const id of ["category", "min-score", "search"]) {
+    $(id).addEventListener("input", () => summary && renderSummary());
+  }
+  loadSummary();
+  streamRuns();
+}
+
+init();
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
 
@@ -16,30 +18,45 @@ import (
 //
 
 type fetchBookmarkCLIHandler struct {
-	logger    logger.Logger
-	usecase   usecase.FetchBookmarkUsecaser
-	urls      []string
-	isVerbose bool
+	logger      logger.Logger
+	usecase     usecase.FetchBookmarkUsecaser
+	urls        []string
+	filter      *entities.URLFilter
+	watchedOnly bool
+	isVerbose   bool
+	resumeRunID int32
+	reporter    progress.Reporter
 }
 
 func NewFetchBookmarkCLIHandler(
 	logger logger.Logger,
 	usecase usecase.FetchBookmarkUsecaser,
 	urls []string,
+	filter *entities.URLFilter,
+	watchedOnly bool,
 	isVerbose bool,
+	resumeRunID int32,
+	reporter progress.Reporter,
 ) *fetchBookmarkCLIHandler {
 	return &fetchBookmarkCLIHandler{
-		logger:    logger,
-		usecase:   usecase,
-		urls:      urls,
-		isVerbose: isVerbose,
+		logger:      logger,
+		usecase:     usecase,
+		urls:        urls,
+		filter:      filter,
+		watchedOnly: watchedOnly,
+		isVerbose:   isVerbose,
+		resumeRunID: resumeRunID,
+		reporter:    reporter,
 	}
 }
 
 func (f *fetchBookmarkCLIHandler) Handler(ctx context.Context) error {
 	f.logger.Info("fetchBookmarkCLIHandler Handler")
 
-	err := f.usecase.Execute(ctx, f.urls, f.isVerbose)
+	// progress is shown while workers are running
+	stop := f.reporter.Start(ctx)
+	err := f.usecase.Execute(ctx, f.urls, f.filter, f.watchedOnly, f.isVerbose, f.resumeRunID)
+	stop()
 	if err != nil {
 		f.logger.Error("failed to fetch bookmark data", "error", err)
 	}
@@ -80,17 +97,22 @@ func (f *fetchBookmarkWebHandler) WebHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	// request
-	urlString := c.DefaultQuery("urls", "")
-	var urls []string
-	if urlString != "" {
-		urls = strings.Split(urlString, ",")
-		f.logger.Info("given URLs", "urls", urls, "len", len(urls))
+	var req fetchBookmarkRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		f.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
 	}
 
-	err := f.usecase.Execute(ctx, urls, false)
+	err := f.usecase.Execute(ctx, req.URLs, req.filter, req.Watched, false, req.Resume)
+	if errors.Is(err, usecase.ErrRunNotFound) {
+		respondError(c, http.StatusNotFound, err.Error())
+		return
+	}
 	if err != nil {
 		f.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
@@ -75,7 +75,7 @@ func (f *fetchHatenaPageURLsWebHandler) WebHandler(c *gin.Context) {
 	err := f.usecase.Execute(ctx)
 	if err != nil {
 		f.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
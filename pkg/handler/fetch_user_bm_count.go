@@ -2,12 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
 
@@ -16,27 +18,39 @@ import (
 //
 
 type fetchUserBookmarkCountCLIHandler struct {
-	logger  logger.Logger
-	usecase usecase.FetchUserBookmarkCountUsecaser
-	urls    []string
+	logger      logger.Logger
+	usecase     usecase.FetchUserBookmarkCountUsecaser
+	urls        []string
+	policy      *entities.UserRefreshPolicy
+	resumeRunID int32
+	reporter    progress.Reporter
 }
 
 func NewFetchUserBookmarkCountCLIHandler(
 	logger logger.Logger,
 	usecase usecase.FetchUserBookmarkCountUsecaser,
 	urls []string,
+	policy *entities.UserRefreshPolicy,
+	resumeRunID int32,
+	reporter progress.Reporter,
 ) *fetchUserBookmarkCountCLIHandler {
 	return &fetchUserBookmarkCountCLIHandler{
-		logger:  logger,
-		usecase: usecase,
-		urls:    urls,
+		logger:      logger,
+		usecase:     usecase,
+		urls:        urls,
+		policy:      policy,
+		resumeRunID: resumeRunID,
+		reporter:    reporter,
 	}
 }
 
 func (f *fetchUserBookmarkCountCLIHandler) Handler(ctx context.Context) error {
 	f.logger.Info("fetchUserBookmarkCountCLIHandler Handler")
 
-	err := f.usecase.Execute(ctx, f.urls)
+	// progress is shown while workers are running
+	stop := f.reporter.Start(ctx)
+	err := f.usecase.Execute(ctx, f.urls, f.policy, f.resumeRunID)
+	stop()
 	if err != nil {
 		f.logger.Error("failed to update user info", "error", err)
 	}
@@ -80,17 +94,22 @@ func (f *fetchUserBookmarkCountWebHandler) WebHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	// request
-	urlString := c.DefaultQuery("urls", "")
-	var urls []string
-	if urlString != "" {
-		urls = strings.Split(urlString, ",")
-		f.logger.Info("given URLs", "urls", urls, "len", len(urls))
+	var req fetchUserBookmarkCountRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		f.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
 	}
 
-	err := f.usecase.Execute(ctx, urls)
+	err := f.usecase.Execute(ctx, req.URLs, req.policy(), req.Resume)
+	if errors.Is(err, usecase.ErrRunNotFound) {
+		respondError(c, http.StatusNotFound, err.Error())
+		return
+	}
 	if err != nil {
 		f.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+const defaultRunsLimit = 20
+
+//
+// listRunsCLIHandler
+//
+
+type listRunsCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.ListRunsUsecaser
+	limit   int
+}
+
+func NewListRunsCLIHandler(
+	logger logger.Logger,
+	usecase usecase.ListRunsUsecaser,
+	limit int,
+) *listRunsCLIHandler {
+	// default
+	if limit == 0 {
+		limit = defaultRunsLimit
+	}
+
+	return &listRunsCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		limit:   limit,
+	}
+}
+
+func (l *listRunsCLIHandler) Handler(ctx context.Context) error {
+	l.logger.Info("listRunsCLIHandler Handler")
+
+	runs, err := l.usecase.Execute(ctx, l.limit)
+	if err != nil {
+		l.logger.Error("failed to list runs", "error", err)
+		return err
+	}
+
+	fmt.Println("[Runs]")
+	for _, run := range runs {
+		l.print(&run)
+	}
+	return nil
+}
+
+func (l *listRunsCLIHandler) print(run *entities.Run) {
+	fmt.Printf(
+		" - run_id: %d, command: %s, status: %s, started: %s, duration: %s\n",
+		run.ID,
+		run.Command,
+		run.Status,
+		times.FormatToString(times.ToJPTime(run.CreatedAt)),
+		run.Duration().Round(time.Second),
+	)
+	fmt.Printf(
+		"   total: %d, done: %d, failed: %d, pending: %d\n",
+		run.TotalCount,
+		run.DoneCount,
+		run.FailedCount,
+		run.PendingCount(),
+	)
+}
+
+// dummy
+func (l *listRunsCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// listRunsWebHandler
+//
+
+type listRunsWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.ListRunsUsecaser
+}
+
+func NewListRunsWebHandler(
+	logger logger.Logger,
+	usecase usecase.ListRunsUsecaser,
+) *listRunsWebHandler {
+	return &listRunsWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (l *listRunsWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (l *listRunsWebHandler) WebHandler(c *gin.Context) {
+	l.logger.Info("listRunsWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req listRunsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	runs, err := l.usecase.Execute(ctx, req.Limit)
+	if err != nil {
+		l.logger.Error("failed to list runs", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to list runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+//
+// mergeDuplicateURLsCLIHandler
+//
+
+type mergeDuplicateURLsCLIHandler struct {
+	logger   logger.Logger
+	usecase  usecase.MergeDuplicateURLsUsecaser
+	isDryRun bool
+}
+
+func NewMergeDuplicateURLsCLIHandler(
+	logger logger.Logger,
+	usecase usecase.MergeDuplicateURLsUsecaser,
+	isDryRun bool,
+) *mergeDuplicateURLsCLIHandler {
+	return &mergeDuplicateURLsCLIHandler{
+		logger:   logger,
+		usecase:  usecase,
+		isDryRun: isDryRun,
+	}
+}
+
+func (m *mergeDuplicateURLsCLIHandler) Handler(ctx context.Context) error {
+	m.logger.Info("mergeDuplicateURLsCLIHandler Handler")
+
+	err := m.usecase.Execute(ctx, m.isDryRun)
+	if err != nil {
+		m.logger.Error("failed to merge duplicate urls", "error", err)
+	}
+	return err
+}
+
+// dummy
+func (m *mergeDuplicateURLsCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// mergeDuplicateURLsWebHandler
+//
+
+type mergeDuplicateURLsWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.MergeDuplicateURLsUsecaser
+}
+
+func NewMergeDuplicateURLsWebHandler(
+	logger logger.Logger,
+	usecase usecase.MergeDuplicateURLsUsecaser,
+) *mergeDuplicateURLsWebHandler {
+	return &mergeDuplicateURLsWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (m *mergeDuplicateURLsWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (m *mergeDuplicateURLsWebHandler) WebHandler(c *gin.Context) {
+	m.logger.Info("mergeDuplicateURLsWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req mergeDuplicateURLsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	err := m.usecase.Execute(ctx, req.DryRun)
+	if err != nil {
+		m.logger.Error("failed to merge duplicate urls", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to merge duplicate urls")
+		return
+	}
+
+	m.logger.Info("successfully merged duplicate urls")
+	c.JSON(http.StatusOK, gin.H{"message": "successfully merged duplicate urls"})
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestLogger carries logger with request_id by request context
+// request_id is taken from X-Request-ID header or generated, and returned by response header
+func RequestLogger(l logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		ctx := logger.NewContext(c.Request.Context(), l.With("request_id", requestID))
+		c.Request = c.Request.WithContext(ctx)
+
+		start := time.Now()
+		c.Next()
+
+		logger.FromContext(ctx).Info("request finished",
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"status", c.Writer.Status(),
+			"latency", time.Since(start).String(),
+		)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
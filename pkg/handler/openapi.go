@@ -0,0 +1,18 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI 3 specification of /api/v1. keep it in sync with requests in request.go
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPI serves specification. it doesn't require API key
+func OpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPISpec)
+}
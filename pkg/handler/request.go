@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/urlnorm"
+)
+
+//
+// Requests of web handlers
+// GET binds query parameters, POST and DELETE bind JSON body with the same keys.
+// rules are checked by `binding` tags, then normalize() checks values depending on other fields.
+// keep openapi.json in sync with these requests
+//
+
+// maximum number of URLs in a request. use POST for large lists
+const maxRequestURLs = 1000
+
+type normalizer interface {
+	normalize() error
+}
+
+// bindRequest binds and validates request. it responds 400 and returns false if request is invalid
+func bindRequest(c *gin.Context, req any) bool {
+	bind := c.ShouldBindQuery
+	method := c.Request.Method
+	if (method == http.MethodPost || method == http.MethodDelete) && c.Request.ContentLength != 0 {
+		bind = c.ShouldBindJSON
+	}
+	if err := bind(req); err != nil {
+		respondInvalidRequest(c, err)
+		return false
+	}
+	if n, ok := req.(normalizer); ok {
+		if err := n.normalize(); err != nil {
+			respondInvalidRequest(c, err)
+			return false
+		}
+	}
+	return true
+}
+
+// urlsRequest has target URLs
+// - query: comma separated `?urls=a,b` or repeated `?urls=a&urls=b`
+// - body: `{"urls": ["a", "b"]}`
+type urlsRequest struct {
+	URLs []string `form:"urls" json:"urls"`
+}
+
+// split comma separated urls and remove urls which have same normalized URL
+func (u *urlsRequest) normalize() error {
+	if len(u.URLs) == 0 {
+		return nil
+	}
+	urls := splitValues(u.URLs)
+	if len(urls) > maxRequestURLs {
+		return &fieldError{
+			Field:   "urls",
+			Message: fmt.Sprintf("must have less than or equal to %d items", maxRequestURLs),
+		}
+	}
+	uniqueURLs, err := urlnorm.UniqueURLs(urls)
+	if err != nil {
+		return &fieldError{Field: "urls", Message: err.Error()}
+	}
+	u.URLs = uniqueURLs
+	return nil
+}
+
+// split comma separated values of query
+func splitValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				split = append(split, v)
+			}
+		}
+	}
+	return split
+}
+
+// urlFilterRequest selects URLs stored in DB
+// e.g. ?category=it&date=2025-02-10&order=bookmark_count&limit=20&page=2
+type urlFilterRequest struct {
+	Category string `form:"category" json:"category"` // category code e.g. it
+	Date     string `form:"date" json:"date" binding:"omitempty,datetime=2006-01-02"`
+	From     string `form:"from" json:"from" binding:"omitempty,datetime=2006-01-02"`
+	To       string `form:"to" json:"to" binding:"omitempty,datetime=2006-01-02"`
+	Order    string `form:"order" json:"order" binding:"omitempty,oneof=bookmark_count private_user_rate"`
+	Limit    int    `form:"limit" json:"limit" binding:"min=0,max=1000"`
+	Page     int    `form:"page" json:"page" binding:"min=0"`
+
+	filter *entities.URLFilter
+}
+
+func (u *urlFilterRequest) normalize() error {
+	filter, err := entities.NewURLFilter(u.Category, u.Date, u.From, u.To, u.Order, u.Limit, u.Page)
+	if err != nil {
+		return err
+	}
+	u.filter = filter
+	return nil
+}
+
+// fetch-bookmark
+type fetchBookmarkRequest struct {
+	urlsRequest
+	urlFilterRequest
+	Watched bool  `form:"watched" json:"watched"`               // fetch only watched URLs
+	Resume  int32 `form:"resume" json:"resume" binding:"min=0"` // run ID to resume
+}
+
+func (f *fetchBookmarkRequest) normalize() error {
+	if err := f.urlsRequest.normalize(); err != nil {
+		return err
+	}
+	return f.urlFilterRequest.normalize()
+}
+
+// fetch-user-bookmark-count
+type fetchUserBookmarkCountRequest struct {
+	urlsRequest
+	StaleDays  int   `form:"stale_days" json:"stale_days" binding:"min=0,max=365"`
+	RecentDays int   `form:"recent_days" json:"recent_days" binding:"min=0,max=365"`
+	MaxUsers   int   `form:"max_users" json:"max_users" binding:"min=0,max=100000"`
+	Resume     int32 `form:"resume" json:"resume" binding:"min=0"` // run ID to resume
+}
+
+func (f *fetchUserBookmarkCountRequest) policy() *entities.UserRefreshPolicy {
+	return entities.NewUserRefreshPolicy(f.StaleDays, f.RecentDays, f.MaxUsers)
+}
+
+// calc-suspicion-score
+type calcSuspicionScoreRequest struct {
+	urlsRequest
+}
+
+// view-time-series
+type viewTimeSeriesRequest struct {
+	urlsRequest
+	urlFilterRequest
+}
+
+func (v *viewTimeSeriesRequest) normalize() error {
+	if err := v.urlsRequest.normalize(); err != nil {
+		return err
+	}
+	return v.urlFilterRequest.normalize()
+}
+
+// view-bookmark-details
+type viewBookmarkDetailsRequest struct {
+	urlsRequest
+	urlFilterRequest
+	Buckets string `form:"buckets" json:"buckets"` // comma separated upper bounds e.g. 10,100,1000
+
+	buckets []int
+}
+
+func (v *viewBookmarkDetailsRequest) normalize() error {
+	if err := v.urlsRequest.normalize(); err != nil {
+		return err
+	}
+	if err := v.urlFilterRequest.normalize(); err != nil {
+		return err
+	}
+	buckets, err := entities.ParseHistogramBuckets(v.Buckets)
+	if err != nil {
+		return &fieldError{Field: "buckets", Message: err.Error()}
+	}
+	v.buckets = buckets
+	return nil
+}
+
+// view-summary
+type viewSummaryRequest struct {
+	urlsRequest
+	Threshold *uint  `form:"threshold" json:"threshold" binding:"omitempty,max=100"` // private user rate. default: 50
+	Sort      string `form:"sort" json:"sort" binding:"omitempty,oneof=private_user_rate score"`
+}
+
+func (v *viewSummaryRequest) normalize() error {
+	// 0 shows all urls
+	if v.Threshold == nil {
+		threshold := uint(defaultSummaryThreshold)
+		v.Threshold = &threshold
+	}
+	return v.urlsRequest.normalize()
+}
+
+// view-comment-similarity
+type viewCommentSimilarityRequest struct {
+	urlsRequest
+	Threshold float64 `form:"threshold" json:"threshold" binding:"omitempty,gt=0,lte=1"` // default: 0.8
+}
+
+func (v *viewCommentSimilarityRequest) normalize() error {
+	if v.Threshold == 0 {
+		v.Threshold = defaultCommentSimilarityThreshold
+	}
+	return v.urlsRequest.normalize()
+}
+
+// users/:name
+type viewUserRequest struct {
+	MinScore *float64 `form:"min_score" json:"min_score" binding:"omitempty,min=0,max=100"` // default: 50
+}
+
+func (v *viewUserRequest) normalize() error {
+	if v.MinScore == nil {
+		minScore := float64(entities.DefaultSuspiciousScore)
+		v.MinScore = &minScore
+	}
+	return nil
+}
+
+// view-user-growth
+type viewUserGrowthRequest struct {
+	Days  int `form:"days" json:"days" binding:"min=0,max=365"`    // default: 7
+	Limit int `form:"limit" json:"limit" binding:"min=0,max=1000"` // default: 20
+}
+
+func (v *viewUserGrowthRequest) normalize() error {
+	if v.Days == 0 {
+		v.Days = defaultUserGrowthDays
+	}
+	if v.Limit == 0 {
+		v.Limit = defaultUserGrowthLimit
+	}
+	return nil
+}
+
+// merge-duplicate-urls
+type mergeDuplicateURLsRequest struct {
+	DryRun bool `form:"dry_run" json:"dry_run"`
+}
+
+// runs
+type listRunsRequest struct {
+	Limit int `form:"limit" json:"limit" binding:"min=0,max=1000"` // default: 20
+}
+
+func (l *listRunsRequest) normalize() error {
+	if l.Limit == 0 {
+		l.Limit = defaultRunsLimit
+	}
+	return nil
+}
+
+// runs/events
+type runEventsRequest struct {
+	RunID int32 `form:"run_id" json:"run_id" binding:"min=0"` // events of all runs if 0
+}
+
+// watch
+// e.g. {"urls": ["a"], "users": ["user1"], "note": "reason"}
+type watchRequest struct {
+	urlsRequest
+	Users []string `form:"users" json:"users"`                 // comma separated in query
+	Note  string   `form:"note" json:"note" binding:"max=200"` // only for POST
+}
+
+func (w *watchRequest) normalize() error {
+	if err := w.urlsRequest.normalize(); err != nil {
+		return err
+	}
+	w.Users = splitValues(w.Users)
+	if len(w.URLs) == 0 && len(w.Users) == 0 {
+		return &fieldError{Field: "urls", Message: "urls or users are required"}
+	}
+	if len(w.Users) > maxRequestURLs {
+		return &fieldError{
+			Field:   "users",
+			Message: fmt.Sprintf("must have less than or equal to %d items", maxRequestURLs),
+		}
+	}
+	for _, userName := range w.Users {
+		if err := entities.ValidateUserName(userName); err != nil {
+			return &fieldError{Field: "users", Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+type listWatchRequest struct {
+	Days int `form:"days" json:"days" binding:"min=0,max=365"` // default: 7
+}
+
+func (l *listWatchRequest) normalize() error {
+	if l.Days == 0 {
+		l.Days = entities.DefaultWatchDays
+	}
+	return nil
+}
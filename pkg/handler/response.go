@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// errorResponse is body of all error responses of web server
+// e.g. {"error": "request is invalid", "code": "invalid_request", "details": [{"field": "limit", "message": "..."}]}
+type errorResponse struct {
+	Error   string        `json:"error"`
+	Code    string        `json:"code"`
+	Details []*fieldError `json:"details,omitempty"`
+}
+
+// fieldError is validation error of request field
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (f *fieldError) Error() string {
+	return fmt.Sprintf("%s %s", f.Field, f.Message)
+}
+
+// code of errorResponse by status
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	default:
+		return "internal_error"
+	}
+}
+
+// respondError aborts request with error body
+func respondError(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, &errorResponse{
+		Error: message,
+		Code:  errorCode(status),
+	})
+}
+
+// respondInvalidRequest aborts request with details of invalid fields
+func respondInvalidRequest(c *gin.Context, err error) {
+	details := fieldErrors(err)
+	message := err.Error()
+	// name of query parameter is unknown by error of parsing number
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		message = fmt.Sprintf("invalid number: %q", numErr.Num)
+	}
+	if len(details) != 0 {
+		message = "request is invalid"
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, &errorResponse{
+		Error:   message,
+		Code:    errorCode(http.StatusBadRequest),
+		Details: details,
+	})
+}
+
+// NotFound responds for unknown route
+func NotFound(c *gin.Context) {
+	respondError(c, http.StatusNotFound, fmt.Sprintf("%s %s is not found", c.Request.Method, c.Request.URL.Path))
+}
+
+func fieldErrors(err error) []*fieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]*fieldError, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			details = append(details, &fieldError{Field: e.Field(), Message: validationMessage(e)})
+		}
+		return details
+	}
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		return []*fieldError{fieldErr}
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []*fieldError{{Field: typeErr.Field, Message: "must be " + typeErr.Type.String()}}
+	}
+	return nil
+}
+
+func validationMessage(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be greater than or equal to " + e.Param()
+	case "max":
+		if e.Kind() == reflect.Slice {
+			return "must have less than or equal to " + e.Param() + " items"
+		}
+		return "must be less than or equal to " + e.Param()
+	case "gt":
+		return "must be greater than " + e.Param()
+	case "lte":
+		return "must be less than or equal to " + e.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(e.Param(), " ", ", ")
+	case "datetime":
+		return "must be date of " + e.Param()
+	default:
+		return "is invalid"
+	}
+}
+
+// field of validation error is name of query parameter or JSON key
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			if name, _, _ := strings.Cut(field.Tag.Get("form"), ","); name != "" && name != "-" {
+				return name
+			}
+			return field.Name
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
+)
+
+// comment is sent periodically so that proxies don't close idle stream
+const runEventsKeepAlive = 15 * time.Second
+
+//
+// runEventsWebHandler
+// streams progress events of fetch-bookmark and fetch-user-bookmark-count as Server-Sent Events
+// e.g. event: saved
+//      data: {"type":"saved","run_id":12,"command":"fetch-bookmark","item":"https://...","counts":{...}}
+//
+
+type runEventsWebHandler struct {
+	logger   logger.Logger
+	notifier progress.Notifier
+}
+
+func NewRunEventsWebHandler(
+	logger logger.Logger,
+	notifier progress.Notifier,
+) *runEventsWebHandler {
+	return &runEventsWebHandler{
+		logger:   logger,
+		notifier: notifier,
+	}
+}
+
+// dummy
+func (r *runEventsWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (r *runEventsWebHandler) WebHandler(c *gin.Context) {
+	r.logger.Info("runEventsWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req runEventsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	events, unsubscribe := r.notifier.Subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// disable buffering of nginx
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(runEventsKeepAlive)
+	defer keepAlive.Stop()
+
+	logger.FromContext(ctx).Info("event stream started", "run_id", req.RunID)
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-keepAlive.C:
+			_, err := w.Write([]byte(": keep-alive\n\n"))
+			return err == nil
+		case event, ok := <-events:
+			// notifier is closed when server is shutting down
+			if !ok {
+				return false
+			}
+			if req.RunID != 0 && event.RunID != req.RunID {
+				return true
+			}
+			c.SSEvent(string(event.Type), event)
+			return true
+		}
+	})
+	logger.FromContext(ctx).Info("event stream ended", "run_id", req.RunID)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/envs"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+)
+
+//
+// showConfigCLIHandler
+// config is not exposed by web server because it includes connection settings
+//
+
+type showConfigCLIHandler struct {
+	logger  logger.Logger
+	envConf *envs.Config
+}
+
+func NewShowConfigCLIHandler(
+	logger logger.Logger,
+	envConf *envs.Config,
+) *showConfigCLIHandler {
+	return &showConfigCLIHandler{
+		logger:  logger,
+		envConf: envConf,
+	}
+}
+
+func (s *showConfigCLIHandler) Handler(_ context.Context) error {
+	s.logger.Info("showConfigCLIHandler Handler")
+
+	fmt.Println("[Config]")
+	for _, entry := range s.envConf.Entries() {
+		fmt.Printf(" - %s: %s (%s)\n", entry.Key, entry.Value, entry.Source)
+	}
+	return nil
+}
+
+// dummy
+func (s *showConfigCLIHandler) WebHandler(_ *gin.Context) {
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
@@ -19,28 +21,62 @@ type viewBookmarkDetailsCLIHandler struct {
 	logger  logger.Logger
 	usecase usecase.ViewBookmarkDetailsUsecaser
 	urls    []string
+	filter  *entities.URLFilter
+	buckets []int
 }
 
 func NewViewBookmarkDetailsCLIHandler(
 	logger logger.Logger,
 	usecase usecase.ViewBookmarkDetailsUsecaser,
 	urls []string,
+	filter *entities.URLFilter,
+	buckets []int,
 ) *viewBookmarkDetailsCLIHandler {
 	return &viewBookmarkDetailsCLIHandler{
 		logger:  logger,
 		usecase: usecase,
 		urls:    urls,
+		filter:  filter,
+		buckets: buckets,
 	}
 }
 
 func (v *viewBookmarkDetailsCLIHandler) Handler(ctx context.Context) error {
 	v.logger.Info("viewBookmarkDetailsCLIHandler Handler")
 
-	err := v.usecase.Execute(ctx, v.urls)
+	details, err := v.usecase.Execute(ctx, v.urls, v.filter, v.buckets)
 	if err != nil {
 		v.logger.Error("failed to view bookmark details", "error", err)
+		return err
 	}
-	return err
+	for _, detail := range details {
+		v.print(detail)
+	}
+	return nil
+}
+
+func (*viewBookmarkDetailsCLIHandler) print(detail *entities.BookmarkDetails) {
+	fmt.Println("----------------------------------------------------------------------")
+	fmt.Printf(" Title: %s,\n URL: %s\n Category: %s\n", detail.Title, detail.URL, detail.CategoryCode)
+	fmt.Printf(" User's bookmark count / number of users whose bookmark count (category baseline)\n")
+	for i, bin := range detail.Distribution.Histogram {
+		if detail.Baseline != nil {
+			fmt.Printf(
+				" - %-12s %5d (%5.1f%%)  baseline: %5.1f%%\n",
+				bin.Label()+":", bin.Count, bin.Rate, detail.Baseline.Histogram[i].Rate,
+			)
+			continue
+		}
+		fmt.Printf(" - %-12s %5d (%5.1f%%)\n", bin.Label()+":", bin.Count, bin.Rate)
+	}
+	p := detail.Distribution.Percentiles
+	fmt.Printf(" Percentiles:    p10: %.0f, p50: %.0f, p90: %.0f\n", p.P10, p.P50, p.P90)
+	if detail.Baseline != nil {
+		bp := detail.Baseline.Percentiles
+		fmt.Printf(" Baseline:       p10: %.0f, p50: %.0f, p90: %.0f\n", bp.P10, bp.P50, bp.P90)
+		fmt.Printf(" Distance from baseline: %.3f\n", detail.Distance)
+	}
+	fmt.Printf(" New user rate:  %.1f\n", detail.NewUserRate)
 }
 
 // dummy
@@ -76,20 +112,25 @@ func (v *viewBookmarkDetailsWebHandler) WebHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	// request
-	urlString := c.DefaultQuery("urls", "")
-	var urls []string
-	if urlString != "" {
-		urls = strings.Split(urlString, ",")
-		v.logger.Info("given URLs", "urls", urls, "len", len(urls))
+	var req viewBookmarkDetailsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		v.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
 	}
 
-	err := v.usecase.Execute(ctx, urls)
+	details, err := v.usecase.Execute(ctx, req.URLs, req.filter, req.buckets)
 	if err != nil {
+		if errors.Is(err, usecase.ErrURLsNotFound) {
+			respondError(c, http.StatusNotFound, err.Error())
+			return
+		}
 		v.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
 	v.logger.Info("successfully fetched bookmark data")
-	c.JSON(http.StatusOK, gin.H{"message": "successfully fetched bookmark data"})
+	c.JSON(http.StatusOK, gin.H{"details": details})
 }
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+// default similarity threshold of comments
+const defaultCommentSimilarityThreshold = 0.8
+
+//
+// viewCommentSimilarityCLIHandler
+//
+
+type viewCommentSimilarityCLIHandler struct {
+	logger    logger.Logger
+	usecase   usecase.ViewCommentSimilarityUsecaser
+	urls      []string
+	threshold float64
+}
+
+func NewViewCommentSimilarityCLIHandler(
+	logger logger.Logger,
+	usecase usecase.ViewCommentSimilarityUsecaser,
+	urls []string,
+	threshold float64,
+) *viewCommentSimilarityCLIHandler {
+	if threshold == 0 {
+		// default
+		threshold = defaultCommentSimilarityThreshold
+	}
+
+	return &viewCommentSimilarityCLIHandler{
+		logger:    logger,
+		usecase:   usecase,
+		urls:      urls,
+		threshold: threshold,
+	}
+}
+
+func (v *viewCommentSimilarityCLIHandler) Handler(ctx context.Context) error {
+	v.logger.Info("viewCommentSimilarityCLIHandler Handler")
+
+	err := v.usecase.Execute(ctx, v.urls, v.threshold)
+	if err != nil {
+		v.logger.Error("failed to view comment similarity", "error", err)
+	}
+	return err
+}
+
+// dummy
+func (v *viewCommentSimilarityCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// viewCommentSimilarityWebHandler
+//
+
+type viewCommentSimilarityWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.ViewCommentSimilarityUsecaser
+}
+
+func NewViewCommentSimilarityWebHandler(
+	logger logger.Logger,
+	usecase usecase.ViewCommentSimilarityUsecaser,
+) *viewCommentSimilarityWebHandler {
+	return &viewCommentSimilarityWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (v *viewCommentSimilarityWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (v *viewCommentSimilarityWebHandler) WebHandler(c *gin.Context) {
+	v.logger.Info("viewCommentSimilarityWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req viewCommentSimilarityRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		v.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
+	}
+
+	err := v.usecase.Execute(ctx, req.URLs, req.Threshold)
+	if err != nil {
+		v.logger.Error("failed to view comment similarity", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to view comment similarity")
+		return
+	}
+
+	v.logger.Info("successfully viewed comment similarity")
+	c.JSON(http.StatusOK, gin.H{"message": "successfully viewed comment similarity"})
+}
@@ -2,15 +2,21 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
 
+// private user rate to show urls
+const defaultSummaryThreshold = 50
+
 //
 // viewSummaryCLIHandler
 //
@@ -20,6 +26,7 @@ type viewSummaryCLIHandler struct {
 	usecase   usecase.ViewSummaryUsecaser
 	urls      []string
 	threshold uint
+	sortKey   usecase.SummarySortKey
 }
 
 func NewViewSummaryCLIHandler(
@@ -27,10 +34,10 @@ func NewViewSummaryCLIHandler(
 	usecase usecase.ViewSummaryUsecaser,
 	urls []string,
 	threshold uint,
+	sortKey usecase.SummarySortKey,
 ) *viewSummaryCLIHandler {
 	if threshold == 0 {
-		// default
-		threshold = 50
+		threshold = defaultSummaryThreshold
 	}
 
 	return &viewSummaryCLIHandler{
@@ -38,17 +45,66 @@ func NewViewSummaryCLIHandler(
 		usecase:   usecase,
 		urls:      urls,
 		threshold: threshold,
+		sortKey:   sortKey,
 	}
 }
 
 func (v *viewSummaryCLIHandler) Handler(ctx context.Context) error {
 	v.logger.Info("viewSummaryCLIHandler Handler")
 
-	err := v.usecase.Execute(ctx, v.urls, v.threshold)
+	summary, err := v.usecase.Execute(ctx, v.urls, v.threshold, v.sortKey)
 	if err != nil {
 		v.logger.Error("failed to view bookmark summary data", "error", err)
+		return err
+	}
+	v.print(summary)
+	return nil
+}
+
+func (v *viewSummaryCLIHandler) print(summary *entities.Summary) {
+	fmt.Printf("[Private user rate over threshold: %d, sorted by %s]\n", v.threshold, v.sortKey)
+	for _, urlSummary := range summary.URLs {
+		args := []any{
+			"url", urlSummary.URL,
+			"title", urlSummary.Title,
+			"bm_count", urlSummary.BookmarkCount,
+			"user_count", urlSummary.UserCount,
+			"private_user_rate", urlSummary.PrivateUserRate,
+			"suspicion_score", urlSummary.SuspicionScore,
+		}
+		if urlSummary.IsWatched {
+			args = append(args, "watched", true)
+		}
+		if len(urlSummary.WatchedUsers) != 0 {
+			args = append(args, "watched_users", strings.Join(urlSummary.WatchedUsers, ","))
+		}
+		v.logger.Info("url info", args...)
+	}
+	fmt.Println("")
+
+	// highlighted regardless of threshold
+	if len(summary.WatchedUserBookmarks) != 0 {
+		fmt.Printf("[New bookmarks of watched users in last %d days]\n", entities.DefaultWatchDays)
+		for _, bookmark := range summary.WatchedUserBookmarks {
+			fmt.Printf(
+				" * %s %s: %s (%s)\n",
+				times.FormatToString(times.ToJPTime(bookmark.CreatedAt)),
+				bookmark.UserName,
+				bookmark.Title,
+				bookmark.URL,
+			)
+		}
+		fmt.Println("")
+	}
+
+	fmt.Println("[Average private user rate per category]")
+	for _, ave := range summary.CategoryAverages {
+		v.logger.Info(
+			"average private user rate",
+			"ave", ave.CategoryCode.String(),
+			"average_private_user_rate", ave.AveragePrivateUserRate,
+		)
 	}
-	return err
 }
 
 // dummy
@@ -84,21 +140,25 @@ func (v *viewSummaryWebHandler) WebHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	// request
-	urlString := c.DefaultQuery("urls", "")
-	var urls []string
-	if urlString != "" {
-		urls = strings.Split(urlString, ",")
-		v.logger.Info("given URLs", "urls", urls, "len", len(urls))
+	var req viewSummaryRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		v.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
+	}
+	sortKey, err := usecase.ToSummarySortKey(req.Sort)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	// threshold := c.Query("threshold")
-	err := v.usecase.Execute(ctx, urls, 50)
+	summary, err := v.usecase.Execute(ctx, req.URLs, *req.Threshold, sortKey)
 	if err != nil {
 		v.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
-	v.logger.Info("successfully fetched bookmark data")
-	c.JSON(http.StatusOK, gin.H{"message": "successfully fetched bookmark data"})
+	c.JSON(http.StatusOK, gin.H{"summary": summary})
 }
@@ -2,12 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
 
@@ -19,28 +23,51 @@ type viewTimeSeriesCLIHandler struct {
 	logger  logger.Logger
 	usecase usecase.ViewTimeSeriesUsecaser
 	urls    []string
+	filter  *entities.URLFilter
 }
 
 func NewViewTimeSeriesCLIHandler(
 	logger logger.Logger,
 	usecase usecase.ViewTimeSeriesUsecaser,
 	urls []string,
+	filter *entities.URLFilter,
 ) *viewTimeSeriesCLIHandler {
 	return &viewTimeSeriesCLIHandler{
 		logger:  logger,
 		usecase: usecase,
 		urls:    urls,
+		filter:  filter,
 	}
 }
 
 func (v *viewTimeSeriesCLIHandler) Handler(ctx context.Context) error {
 	v.logger.Info("viewTimeSeriesCLIHandler Handler")
 
-	err := v.usecase.Execute(ctx, v.urls)
+	timeSeriesList, err := v.usecase.Execute(ctx, v.urls, v.filter)
 	if err != nil {
 		v.logger.Error("failed to view bookmark time series", "error", err)
+		return err
+	}
+	for _, timeSeries := range timeSeriesList {
+		v.print(timeSeries)
+	}
+	return nil
+}
+
+func (*viewTimeSeriesCLIHandler) print(timeSeries *entities.TimeSeries) {
+	fmt.Println("----------------------------------------------------------------------")
+	fmt.Printf(" Title: %s,\n URL: %s\n", timeSeries.Title, timeSeries.URL)
+	fmt.Printf(" Time series\n")
+	for _, point := range timeSeries.Points {
+		fmt.Printf(
+			"  - %s: total_bookmark: %d, user_count: %d, deleted_user_count: %d, private user rate: %.1f\n",
+			times.ToJPTime(point.Timestamp).Format(time.RFC3339),
+			point.Count,
+			point.UserCount,
+			point.DeletedUserCount,
+			point.PrivateUserRate,
+		)
 	}
-	return err
 }
 
 // dummy
@@ -76,20 +103,24 @@ func (v *viewTimeSeriesWebHandler) WebHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	// request
-	urlString := c.DefaultQuery("urls", "")
-	var urls []string
-	if urlString != "" {
-		urls = strings.Split(urlString, ",")
-		v.logger.Info("given URLs", "urls", urls, "len", len(urls))
+	var req viewTimeSeriesRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	if len(req.URLs) != 0 {
+		v.logger.Info("given URLs", "urls", req.URLs, "len", len(req.URLs))
 	}
 
-	err := v.usecase.Execute(ctx, urls)
+	timeSeriesList, err := v.usecase.Execute(ctx, req.URLs, req.filter)
 	if err != nil {
+		if errors.Is(err, usecase.ErrURLsNotFound) {
+			respondError(c, http.StatusNotFound, err.Error())
+			return
+		}
 		v.logger.Error("failed to fetch bookmark data", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookmark data"})
+		respondError(c, http.StatusInternalServerError, "failed to fetch bookmark data")
 		return
 	}
 
-	v.logger.Info("successfully fetched bookmark data")
-	c.JSON(http.StatusOK, gin.H{"message": "successfully fetched bookmark data"})
+	c.JSON(http.StatusOK, gin.H{"time_series": timeSeriesList})
 }
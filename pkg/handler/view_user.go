@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+//
+// viewUserCLIHandler
+//
+
+type viewUserCLIHandler struct {
+	logger   logger.Logger
+	usecase  usecase.ViewUserUsecaser
+	userName string
+	minScore float64
+}
+
+func NewViewUserCLIHandler(
+	logger logger.Logger,
+	usecase usecase.ViewUserUsecaser,
+	userName string,
+	minScore float64,
+) *viewUserCLIHandler {
+	if minScore == 0 {
+		minScore = entities.DefaultSuspiciousScore
+	}
+
+	return &viewUserCLIHandler{
+		logger:   logger,
+		usecase:  usecase,
+		userName: userName,
+		minScore: minScore,
+	}
+}
+
+func (v *viewUserCLIHandler) Handler(ctx context.Context) error {
+	v.logger.Info("viewUserCLIHandler Handler")
+
+	profile, err := v.usecase.Execute(ctx, v.userName, v.minScore)
+	if err != nil {
+		v.logger.Error("failed to view user", "error", err)
+		return err
+	}
+	v.print(profile)
+	return nil
+}
+
+func (v *viewUserCLIHandler) print(profile *entities.UserProfile) {
+	fmt.Println("----------------------------------------------------------------------")
+	fmt.Printf(" User: %s\n", profile.Name)
+	fmt.Printf(
+		" Bookmark count: %d (updated at: %s)\n",
+		profile.BookmarkCount,
+		times.FormatToString(times.ToJPTime(profile.UpdatedAt)),
+	)
+	fmt.Println(" Bookmark count history:")
+	for _, point := range profile.History {
+		fmt.Printf(" - %s: %d\n", times.FormatToString(times.ToJPTime(point.ObservedAt)), point.BookmarkCount)
+	}
+	fmt.Printf(" Tracked bookmarks: %d, removed: %d\n", len(profile.Bookmarks), profile.RemovedCount)
+	fmt.Printf(" Suspicious urls (score >= %.1f): %d\n", v.minScore, profile.SuspiciousURLCount)
+
+	fmt.Println(" Categories:")
+	for _, category := range profile.Categories {
+		fmt.Printf(" - %-16s %d\n", category.CategoryCode.String()+":", category.Count)
+	}
+
+	fmt.Println(" Bookmarks:")
+	for _, bookmark := range profile.Bookmarks {
+		fmt.Printf(
+			" - [%s] %s\n   %s (category: %s, score: %.1f)\n",
+			times.FormatToString(times.ToJPTime(bookmark.BookmarkedAt)),
+			bookmark.Title,
+			bookmark.URL,
+			bookmark.CategoryCode,
+			bookmark.Score,
+		)
+		if bookmark.Comment != "" {
+			fmt.Printf("   comment: %s\n", bookmark.Comment)
+		}
+		if bookmark.IsDeleted {
+			if bookmark.RemovedAt != nil {
+				fmt.Printf("   removed at: %s\n", times.FormatToString(times.ToJPTime(*bookmark.RemovedAt)))
+			} else {
+				fmt.Println("   removed")
+			}
+		}
+	}
+
+	fmt.Println(" Users sharing suspicious urls:")
+	for _, user := range profile.SharedUsers {
+		fmt.Printf(" - %s: %d\n", user.Name, user.SharedURLCount)
+	}
+}
+
+// dummy
+func (v *viewUserCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// viewUserWebHandler
+//
+
+type viewUserWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.ViewUserUsecaser
+}
+
+func NewViewUserWebHandler(
+	logger logger.Logger,
+	usecase usecase.ViewUserUsecaser,
+) *viewUserWebHandler {
+	return &viewUserWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (v *viewUserWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (v *viewUserWebHandler) WebHandler(c *gin.Context) {
+	v.logger.Info("viewUserWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	userName := c.Param("name")
+	var req viewUserRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	profile, err := v.usecase.Execute(ctx, userName, *req.MinScore)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			respondError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		v.logger.Error("failed to view user", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to view user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": profile})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+const (
+	defaultUserGrowthDays  = 7
+	defaultUserGrowthLimit = 20
+	defaultUserGrowthJump  = 1000
+)
+
+//
+// viewUserGrowthCLIHandler
+//
+
+type viewUserGrowthCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.ViewUserGrowthUsecaser
+	days    int
+	limit   int
+	jump    int
+}
+
+func NewViewUserGrowthCLIHandler(
+	logger logger.Logger,
+	usecase usecase.ViewUserGrowthUsecaser,
+	days, limit, jump int,
+) *viewUserGrowthCLIHandler {
+	// default
+	if days == 0 {
+		days = defaultUserGrowthDays
+	}
+	if limit == 0 {
+		limit = defaultUserGrowthLimit
+	}
+	if jump == 0 {
+		jump = defaultUserGrowthJump
+	}
+
+	return &viewUserGrowthCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		days:    days,
+		limit:   limit,
+		jump:    jump,
+	}
+}
+
+func (v *viewUserGrowthCLIHandler) Handler(ctx context.Context) error {
+	v.logger.Info("viewUserGrowthCLIHandler Handler")
+
+	growths, err := v.usecase.Execute(ctx, v.days, v.limit)
+	if err != nil {
+		v.logger.Error("failed to view user growth", "error", err)
+		return err
+	}
+
+	fmt.Printf("[Fastest-growing users in last %d days: sudden jump >= %d]\n", v.days, v.jump)
+	for _, growth := range growths {
+		v.print(&growth)
+	}
+	return nil
+}
+
+func (v *viewUserGrowthCLIHandler) print(growth *entities.UserGrowth) {
+	var mark string
+	if growth.IsJumped(v.jump) {
+		mark = " [sudden jump]"
+	}
+	fmt.Printf(
+		" - %s: %d -> %d (+%d, %.1f/day), max jump: %d, observations: %d%s\n",
+		growth.Name,
+		growth.FirstCount,
+		growth.LastCount,
+		growth.Growth,
+		growth.GrowthPerDay(),
+		growth.MaxJump,
+		growth.ObservationCount,
+		mark,
+	)
+}
+
+// dummy
+func (v *viewUserGrowthCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// viewUserGrowthWebHandler
+//
+
+type viewUserGrowthWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.ViewUserGrowthUsecaser
+}
+
+func NewViewUserGrowthWebHandler(
+	logger logger.Logger,
+	usecase usecase.ViewUserGrowthUsecaser,
+) *viewUserGrowthWebHandler {
+	return &viewUserGrowthWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+func (v *viewUserGrowthWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (v *viewUserGrowthWebHandler) WebHandler(c *gin.Context) {
+	v.logger.Info("viewUserGrowthWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req viewUserGrowthRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	growths, err := v.usecase.Execute(ctx, req.Days, req.Limit)
+	if err != nil {
+		v.logger.Error("failed to view user growth", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to view user growth")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": growths})
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/times"
+	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
+)
+
+//
+// addWatchCLIHandler
+//
+
+type addWatchCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+	urls    []string
+	users   []string
+	note    string
+}
+
+func NewAddWatchCLIHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+	urls []string,
+	users []string,
+	note string,
+) *addWatchCLIHandler {
+	return &addWatchCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		urls:    urls,
+		users:   users,
+		note:    note,
+	}
+}
+
+func (a *addWatchCLIHandler) Handler(ctx context.Context) error {
+	a.logger.Info("addWatchCLIHandler Handler")
+
+	change, err := a.usecase.Add(ctx, a.urls, a.users, a.note)
+	if err != nil {
+		a.logger.Error("failed to add watch", "error", err)
+		return err
+	}
+	fmt.Println("[Watched]")
+	printWatchChange(change)
+	return nil
+}
+
+// dummy
+func (a *addWatchCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// removeWatchCLIHandler
+//
+
+type removeWatchCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+	urls    []string
+	users   []string
+}
+
+func NewRemoveWatchCLIHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+	urls []string,
+	users []string,
+) *removeWatchCLIHandler {
+	return &removeWatchCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		urls:    urls,
+		users:   users,
+	}
+}
+
+func (r *removeWatchCLIHandler) Handler(ctx context.Context) error {
+	r.logger.Info("removeWatchCLIHandler Handler")
+
+	change, err := r.usecase.Remove(ctx, r.urls, r.users)
+	if err != nil {
+		r.logger.Error("failed to remove watch", "error", err)
+		return err
+	}
+	fmt.Println("[Unwatched]")
+	printWatchChange(change)
+	return nil
+}
+
+// dummy
+func (r *removeWatchCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+// already watched or not watched ones are not printed
+func printWatchChange(change *entities.WatchChange) {
+	for _, url := range change.URLs {
+		fmt.Printf(" - url: %s\n", url)
+	}
+	for _, userName := range change.Users {
+		fmt.Printf(" - user: %s\n", userName)
+	}
+}
+
+//
+// listWatchCLIHandler
+//
+
+type listWatchCLIHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+	days    int
+}
+
+func NewListWatchCLIHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+	days int,
+) *listWatchCLIHandler {
+	// default
+	if days == 0 {
+		days = entities.DefaultWatchDays
+	}
+
+	return &listWatchCLIHandler{
+		logger:  logger,
+		usecase: usecase,
+		days:    days,
+	}
+}
+
+func (l *listWatchCLIHandler) Handler(ctx context.Context) error {
+	l.logger.Info("listWatchCLIHandler Handler")
+
+	watchlist, err := l.usecase.List(ctx, l.days)
+	if err != nil {
+		l.logger.Error("failed to list watch", "error", err)
+		return err
+	}
+	l.print(watchlist)
+	return nil
+}
+
+func (l *listWatchCLIHandler) print(watchlist *entities.Watchlist) {
+	fmt.Println("[Watched URLs]")
+	for _, url := range watchlist.URLs {
+		fetched := "not fetched"
+		if url.FetchedAt != nil {
+			fetched = times.FormatToString(times.ToJPTime(*url.FetchedAt))
+		}
+		fmt.Printf(" - %s, bookmark_count: %d, fetched: %s\n", url.Address, url.BookmarkCount, fetched)
+		if url.Title != "" {
+			fmt.Printf("   title: %s\n", url.Title)
+		}
+		if url.Note != "" {
+			fmt.Printf("   note: %s\n", url.Note)
+		}
+	}
+
+	fmt.Println("[Watched users]")
+	for _, user := range watchlist.Users {
+		fmt.Printf(
+			" - %s, bookmark_count: %d, new bookmarks: %d, watched: %s\n",
+			user.Name,
+			user.BookmarkCount,
+			user.NewBookmarkCount,
+			times.FormatToString(times.ToJPTime(user.CreatedAt)),
+		)
+		if user.Note != "" {
+			fmt.Printf("   note: %s\n", user.Note)
+		}
+	}
+
+	fmt.Printf("[New bookmarks of watched users in last %d days]\n", l.days)
+	for _, bookmark := range watchlist.NewBookmarks {
+		fmt.Printf(
+			" * %s %s: %s (%s)\n",
+			times.FormatToString(times.ToJPTime(bookmark.CreatedAt)),
+			bookmark.UserName,
+			bookmark.Title,
+			bookmark.URL,
+		)
+		if comment := strings.TrimSpace(bookmark.Comment); comment != "" {
+			fmt.Printf("   comment: %s\n", comment)
+		}
+	}
+}
+
+// dummy
+func (l *listWatchCLIHandler) WebHandler(_ *gin.Context) {
+}
+
+//
+// addWatchWebHandler
+//
+
+type addWatchWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+}
+
+func NewAddWatchWebHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+) *addWatchWebHandler {
+	return &addWatchWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+// dummy
+func (a *addWatchWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (a *addWatchWebHandler) WebHandler(c *gin.Context) {
+	a.logger.Info("addWatchWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req watchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	change, err := a.usecase.Add(ctx, req.URLs, req.Users, req.Note)
+	if err != nil {
+		a.logger.Error("failed to add watch", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to add watch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"added": change})
+}
+
+//
+// removeWatchWebHandler
+//
+
+type removeWatchWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+}
+
+func NewRemoveWatchWebHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+) *removeWatchWebHandler {
+	return &removeWatchWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+// dummy
+func (r *removeWatchWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (r *removeWatchWebHandler) WebHandler(c *gin.Context) {
+	r.logger.Info("removeWatchWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req watchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	change, err := r.usecase.Remove(ctx, req.URLs, req.Users)
+	if errors.Is(err, usecase.ErrWatchNotFound) {
+		respondError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		r.logger.Error("failed to remove watch", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to remove watch")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": change})
+}
+
+//
+// listWatchWebHandler
+//
+
+type listWatchWebHandler struct {
+	logger  logger.Logger
+	usecase usecase.WatchUsecaser
+}
+
+func NewListWatchWebHandler(
+	logger logger.Logger,
+	usecase usecase.WatchUsecaser,
+) *listWatchWebHandler {
+	return &listWatchWebHandler{
+		logger:  logger,
+		usecase: usecase,
+	}
+}
+
+// dummy
+func (l *listWatchWebHandler) Handler(_ context.Context) error {
+	return nil
+}
+
+func (l *listWatchWebHandler) WebHandler(c *gin.Context) {
+	l.logger.Info("listWatchWebHandler WebHandler")
+
+	ctx := c.Request.Context()
+
+	// request
+	var req listWatchRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	watchlist, err := l.usecase.List(ctx, req.Days)
+	if err != nil {
+		l.logger.Error("failed to list watch", "error", err)
+		respondError(c, http.StatusInternalServerError, "failed to list watch")
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+//
+// Context-aware logging
+// logger with fields such as run_id, request_id and url is carried by context
+//
+
+type contextKey struct{}
+
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(loggerHolder{NewNoopLogger()})
+}
+
+// atomic.Value requires same concrete type
+type loggerHolder struct {
+	Logger
+}
+
+// SetDefault sets logger used when context has no logger
+func SetDefault(l Logger) {
+	defaultLogger.Store(loggerHolder{l})
+}
+
+// NewContext returns context carrying logger
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// WithFields returns context carrying logger with given fields added
+func WithFields(ctx context.Context, args ...any) context.Context {
+	return NewContext(ctx, fromContext(ctx).With(args...))
+}
+
+// FromContext returns logger carried by ctx, or default logger
+// trace_id and span_id are added when ctx has span
+func FromContext(ctx context.Context) Logger {
+	l := fromContext(ctx)
+	spanCtx := oteltrace.SpanContextFromContext(ctx)
+	if spanCtx.IsValid() {
+		return l.With("trace_id", spanCtx.TraceID().String(), "span_id", spanCtx.SpanID().String())
+	}
+	return l
+}
+
+func fromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return l
+	}
+	holder, _ := defaultLogger.Load().(loggerHolder)
+	return holder.Logger
+}
@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"io"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileConfig is setting of log file rotation
+type FileConfig struct {
+	Path       string // log file path
+	MaxSizeMB  int    // rotate when file exceeds size
+	MaxBackups int    // number of rotated files to keep. 0 keeps all
+	MaxAgeDays int    // days to keep rotated files. 0 keeps all
+	Compress   bool   // gzip rotated files
+}
+
+// NewRotateWriter returns writer of log file rotated by size
+func NewRotateWriter(conf *FileConfig) io.WriteCloser {
+	return &lumberjack.Logger{
+		Filename:   conf.Path,
+		MaxSize:    conf.MaxSizeMB,
+		MaxBackups: conf.MaxBackups,
+		MaxAge:     conf.MaxAgeDays,
+		Compress:   conf.Compress,
+		LocalTime:  true,
+	}
+}
@@ -5,4 +5,6 @@ type Logger interface {
 	Info(msg string, args ...any)
 	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
+	// With returns logger which always outputs given args e.g. run_id, url
+	With(args ...any) Logger
 }
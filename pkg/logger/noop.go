@@ -37,3 +37,10 @@ func (*NoopLogger) Warn(msg string, args ...any) {
 func (*NoopLogger) Error(msg string, args ...any) {
 	// DummyLogger disables logging
 }
+
+// With
+//
+//nolint:revive
+func (n *NoopLogger) With(args ...any) Logger {
+	return n
+}
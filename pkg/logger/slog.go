@@ -1,8 +1,8 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
-	"os"
 
 	"github.com/phsym/console-slog"
 )
@@ -16,7 +16,9 @@ type SlogJSONLogger struct {
 	args []any
 }
 
+// secrets are redacted from output
 func NewSlogJSONLogger(
+	w io.Writer,
 	level slog.Level,
 	appCode string,
 	commitID string,
@@ -32,7 +34,7 @@ func NewSlogJSONLogger(
 	options := &slog.HandlerOptions{Level: level}
 
 	return &SlogJSONLogger{
-		log:  slog.New(slog.NewJSONHandler(os.Stdout, options)),
+		log:  slog.New(newRedactHandler(slog.NewJSONHandler(w, options))),
 		args: args,
 	}
 }
@@ -57,9 +59,17 @@ func (s *SlogJSONLogger) Error(msg string, args ...any) {
 	s.log.Error(msg, s.appendArgs(args...)...)
 }
 
+// With
+func (s *SlogJSONLogger) With(args ...any) Logger {
+	return &SlogJSONLogger{
+		log:  s.log.With(args...),
+		args: s.args,
+	}
+}
+
 // appends the args to the default args
 func (s *SlogJSONLogger) appendArgs(args ...any) []any {
-	return append(s.args, args...)
+	return append(s.args[:len(s.args):len(s.args)], args...)
 }
 
 //
@@ -72,12 +82,15 @@ type SlogConsoleLogger struct {
 	args []any
 }
 
+// secrets are redacted from output. color is disabled when output is file
 func NewSlogConsoleLogger(
+	w io.Writer,
 	level slog.Level,
+	noColor bool,
 ) *SlogConsoleLogger {
-	options := &console.HandlerOptions{Level: level}
+	options := &console.HandlerOptions{Level: level, NoColor: noColor}
 	return &SlogConsoleLogger{
-		log:  slog.New(console.NewHandler(os.Stderr, options)),
+		log:  slog.New(newRedactHandler(console.NewHandler(w, options))),
 		args: []any{},
 	}
 }
@@ -101,3 +114,11 @@ func (s *SlogConsoleLogger) Warn(msg string, args ...any) {
 func (s *SlogConsoleLogger) Error(msg string, args ...any) {
 	s.log.Error(msg, args...)
 }
+
+// With
+func (s *SlogConsoleLogger) With(args ...any) Logger {
+	return &SlogConsoleLogger{
+		log:  s.log.With(args...),
+		args: s.args,
+	}
+}
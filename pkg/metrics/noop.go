@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// disable metrics
+
+type NoopProvider struct{}
+
+func NewNoopProvider() *NoopProvider {
+	return &NoopProvider{}
+}
+
+func (n *NoopProvider) ObserveFetch(_ string, _ int, _ time.Duration) {}
+
+func (n *NoopProvider) ObserveDBWrite(_, _ string, _ time.Duration, _ error) {}
+
+func (n *NoopProvider) IncItem(_, _ string) {}
+
+func (n *NoopProvider) SetMaxWorkers(_ string, _ int64) {}
+
+func (n *NoopProvider) IncWorker(_ string) {}
+
+func (n *NoopProvider) DecWorker(_ string) {}
+
+func (n *NoopProvider) AddScrapedURLs(_ string, _ int) {}
+
+// Handler returns nil, then `/metrics` is not registered
+func (n *NoopProvider) Handler() http.Handler {
+	return nil
+}
+
+func (n *NoopProvider) Push(_ context.Context) error {
+	return nil
+}
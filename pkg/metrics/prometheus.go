@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const namespace = "hatena_analyzer"
+
+type PrometheusProvider struct {
+	registry *prometheus.Registry
+	pushURL  string // Pushgateway URL for CLI. empty disables push
+	job      string
+
+	fetchDuration   *prometheus.HistogramVec
+	fetchStatus     *prometheus.CounterVec
+	dbWriteDuration *prometheus.HistogramVec
+	dbWriteErrors   *prometheus.CounterVec
+	items           *prometheus.CounterVec
+	maxWorkers      *prometheus.GaugeVec
+	busyWorkers     *prometheus.GaugeVec
+	scrapedURLs     *prometheus.CounterVec
+}
+
+// NewPrometheusProvider creates collectors on own registry
+// - pushURL: Pushgateway URL e.g. http://localhost:9091
+// - job: job name when pushing
+func NewPrometheusProvider(pushURL, job string) *PrometheusProvider {
+	p := &PrometheusProvider{
+		registry: prometheus.NewRegistry(),
+		pushURL:  pushURL,
+		job:      job,
+		fetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "fetcher",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to Hatena.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"target"}),
+		fetchStatus: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "fetcher",
+			Name:      "responses_total",
+			Help:      "HTTP status codes from Hatena. status is 0 when request failed.",
+		}, []string{"target", "status"}),
+		dbWriteDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "write_duration_seconds",
+			Help:      "Latency of writes per store.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"store", "operation"}),
+		dbWriteErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "write_errors_total",
+			Help:      "Failed writes per store.",
+		}, []string{"store", "operation"}),
+		items: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "items_total",
+			Help:      "Items processed per usecase by result.",
+		}, []string{"usecase", "result"}),
+		maxWorkers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "workers_max",
+			Help:      "Capacity of semaphore per usecase.",
+		}, []string{"usecase"}),
+		busyWorkers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "workers_busy",
+			Help:      "Acquired semaphore per usecase.",
+		}, []string{"usecase"}),
+		scrapedURLs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usecase",
+			Name:      "scraped_urls_total",
+			Help:      "URLs scraped from Hatena pages per category.",
+		}, []string{"category"}),
+	}
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.fetchDuration,
+		p.fetchStatus,
+		p.dbWriteDuration,
+		p.dbWriteErrors,
+		p.items,
+		p.maxWorkers,
+		p.busyWorkers,
+		p.scrapedURLs,
+	)
+	return p
+}
+
+func (p *PrometheusProvider) ObserveFetch(target string, statusCode int, duration time.Duration) {
+	p.fetchDuration.WithLabelValues(target).Observe(duration.Seconds())
+	p.fetchStatus.WithLabelValues(target, strconv.Itoa(statusCode)).Inc()
+}
+
+func (p *PrometheusProvider) ObserveDBWrite(store, operation string, duration time.Duration, err error) {
+	p.dbWriteDuration.WithLabelValues(store, operation).Observe(duration.Seconds())
+	if err != nil {
+		p.dbWriteErrors.WithLabelValues(store, operation).Inc()
+	}
+}
+
+func (p *PrometheusProvider) IncItem(usecase, result string) {
+	p.items.WithLabelValues(usecase, result).Inc()
+}
+
+func (p *PrometheusProvider) SetMaxWorkers(usecase string, count int64) {
+	p.maxWorkers.WithLabelValues(usecase).Set(float64(count))
+}
+
+func (p *PrometheusProvider) IncWorker(usecase string) {
+	p.busyWorkers.WithLabelValues(usecase).Inc()
+}
+
+func (p *PrometheusProvider) DecWorker(usecase string) {
+	p.busyWorkers.WithLabelValues(usecase).Dec()
+}
+
+func (p *PrometheusProvider) AddScrapedURLs(category string, count int) {
+	p.scrapedURLs.WithLabelValues(category).Add(float64(count))
+}
+
+func (p *PrometheusProvider) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+// Push sends metrics to Pushgateway. it does nothing if pushURL is empty
+func (p *PrometheusProvider) Push(ctx context.Context) error {
+	if p.pushURL == "" {
+		return nil
+	}
+	return push.New(p.pushURL, p.job).Gatherer(p.registry).PushContext(ctx)
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type Metrics interface {
+	// fetcher
+	ObserveFetch(target string, statusCode int, duration time.Duration)
+	// repository
+	ObserveDBWrite(store, operation string, duration time.Duration, err error)
+	// usecase
+	IncItem(usecase, result string)
+	SetMaxWorkers(usecase string, count int64)
+	IncWorker(usecase string)
+	DecWorker(usecase string)
+	AddScrapedURLs(category string, count int)
+	// exposition
+	Handler() http.Handler
+	Push(ctx context.Context) error
+}
+
+//
+// Metrics Utility
+//
+
+type MetricsMode int
+
+const (
+	MetricsModeNOOP       MetricsMode = iota // no metrics
+	MetricsModePrometheus                    // Prometheus
+)
+
+func ValidateMetricsEnv(value string) MetricsMode {
+	switch value {
+	case "prometheus":
+		return MetricsModePrometheus
+	default:
+		return MetricsModeNOOP
+	}
+}
+
+// labels of fetch target
+const (
+	FetchTargetEntityJSON        = "entity_json"
+	FetchTargetHatenaPage        = "hatena_page"
+	FetchTargetUserBookmarkCount = "user_bookmark_count"
+)
+
+// labels of store
+const (
+	StorePostgres = "postgres"
+	StoreInfluxDB = "influxdb"
+	StoreMongoDB  = "mongodb"
+)
+
+// labels of item result
+const (
+	ItemResultDone   = "done"
+	ItemResultFailed = "failed"
+)
@@ -0,0 +1,114 @@
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is type of progress event of run
+type EventType string
+
+const (
+	EventStarted  EventType = "started"  // run started or resumed
+	EventFetched  EventType = "fetched"  // item was fetched from Hatena
+	EventSaved    EventType = "saved"    // item was saved to DB
+	EventFailed   EventType = "failed"   // item failed
+	EventFinished EventType = "finished" // run finished with status
+)
+
+// Counts are numbers of items of run at the time of event
+type Counts struct {
+	Total  int `json:"total"`
+	Done   int `json:"done"`
+	Failed int `json:"failed"`
+}
+
+// Event is progress of run of fetch-bookmark or fetch-user-bm-count
+type Event struct {
+	Type      EventType `json:"type"`
+	RunID     int32     `json:"run_id"`
+	Command   string    `json:"command"`
+	Item      string    `json:"item,omitempty"`   // url or user name
+	Error     string    `json:"error,omitempty"`  // error of failed item
+	Status    string    `json:"status,omitempty"` // status of finished run
+	Counts    Counts    `json:"counts"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier delivers progress events of runs to subscribers in the same process
+type Notifier interface {
+	Publish(event *Event)
+	// Subscribe returns channel of events of all runs. unsubscribe must be called when it's not used
+	Subscribe() (events <-chan *Event, unsubscribe func())
+	// Close closes channels of all subscribers. events published after Close are discarded
+	Close()
+}
+
+// buffer of each subscriber. events are dropped for slow subscriber instead of blocking workers
+const subscriberBuffer = 256
+
+type broker struct {
+	mu          sync.RWMutex
+	subscribers map[chan *Event]struct{}
+	closed      bool
+}
+
+func NewNotifier() *broker {
+	return &broker{
+		subscribers: make(map[chan *Event]struct{}),
+	}
+}
+
+func (b *broker) Publish(event *Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func (b *broker) Subscribe() (<-chan *Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *Event, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[ch]; ok {
+				delete(b.subscribers, ch)
+				close(ch)
+			}
+		})
+	}
+	return ch, unsubscribe
+}
+
+func (b *broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,244 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+)
+
+//
+// Reporter Utility
+//
+
+type ReporterMode int
+
+const (
+	ReporterModeAuto ReporterMode = iota // bar if stdout is TTY, otherwise log
+	ReporterModeBar                      // progress bar redrawn in place
+	ReporterModeLog                      // periodic summary log lines
+	ReporterModeNone                     // nothing is reported
+)
+
+func ValidateReporterMode(value string) ReporterMode {
+	switch value {
+	case "bar":
+		return ReporterModeBar
+	case "log":
+		return ReporterModeLog
+	case "none":
+		return ReporterModeNone
+	default:
+		return ReporterModeAuto
+	}
+}
+
+const (
+	barRedrawInterval  = 200 * time.Millisecond
+	logSummaryInterval = 10 * time.Second
+	barWidth           = 30
+)
+
+// Reporter shows progress of runs of CLI command from events of notifier
+type Reporter interface {
+	// Start reports events in background. stop must be called after command finished
+	Start(ctx context.Context) (stop func())
+}
+
+// runStats is progress of run seen by reporter
+type runStats struct {
+	command   string
+	counts    Counts
+	startDone int // items done before resumed run, not counted for rate
+	startedAt time.Time
+	status    string
+}
+
+func (r *runStats) processed() int {
+	return r.counts.Done + r.counts.Failed - r.startDone
+}
+
+// rate is processed items per second
+func (r *runStats) rate(now time.Time) float64 {
+	elapsed := now.Sub(r.startedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(r.processed()) / elapsed
+}
+
+// eta is 0 if it's unknown
+func (r *runStats) eta(now time.Time) time.Duration {
+	rate := r.rate(now)
+	remaining := r.counts.Total - r.counts.Done - r.counts.Failed
+	if rate == 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second))
+}
+
+type reporter struct {
+	notifier Notifier
+	mode     ReporterMode
+	out      io.Writer
+	interval time.Duration
+
+	mu   sync.Mutex
+	runs map[int32]*runStats
+}
+
+// NewReporter returns reporter writing progress bar to out, or logs by logger of context
+// ReporterModeAuto selects bar when out is terminal
+func NewReporter(notifier Notifier, mode ReporterMode, out *os.File) *reporter {
+	if mode == ReporterModeAuto {
+		mode = ReporterModeLog
+		if term.IsTerminal(int(out.Fd())) {
+			mode = ReporterModeBar
+		}
+	}
+	interval := logSummaryInterval
+	if mode == ReporterModeBar {
+		interval = barRedrawInterval
+	}
+	return &reporter{
+		notifier: notifier,
+		mode:     mode,
+		out:      out,
+		interval: interval,
+		runs:     make(map[int32]*runStats),
+	}
+}
+
+func (r *reporter) Start(ctx context.Context) func() {
+	if r.mode == ReporterModeNone {
+		return func() {}
+	}
+	events, unsubscribe := r.notifier.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				r.update(ctx, event)
+			case <-ticker.C:
+				r.report(ctx)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// events published before stop are drained by closed channel
+			unsubscribe()
+			<-done
+		})
+	}
+}
+
+func (r *reporter) update(ctx context.Context, event *Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.runs[event.RunID]
+	if !ok || event.Type == EventStarted {
+		stats = &runStats{
+			command:   event.Command,
+			startDone: event.Counts.Done + event.Counts.Failed,
+			startedAt: event.Timestamp,
+		}
+		r.runs[event.RunID] = stats
+	}
+	stats.counts = event.Counts
+	if event.Type != EventFinished {
+		return
+	}
+	stats.status = event.Status
+
+	// last state of run is reported once
+	now := event.Timestamp
+	switch r.mode {
+	case ReporterModeBar:
+		fmt.Fprintf(r.out, "\r\033[K%s\n", r.bar(event.RunID, stats, now))
+	case ReporterModeLog:
+		r.log(ctx, "run progress finished", event.RunID, stats, now)
+	}
+	delete(r.runs, event.RunID)
+}
+
+// report progress of running runs
+func (r *reporter) report(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for runID, stats := range r.runs {
+		switch r.mode {
+		case ReporterModeBar:
+			fmt.Fprintf(r.out, "\r\033[K%s", r.bar(runID, stats, now))
+		case ReporterModeLog:
+			r.log(ctx, "run progress", runID, stats, now)
+		}
+	}
+}
+
+// e.g. fetch-bookmark #12 [#########.....................] 30/100 30% 2.5/s ETA 28s errors 1
+func (r *reporter) bar(runID int32, stats *runStats, now time.Time) string {
+	processed := stats.counts.Done + stats.counts.Failed
+	ratio := 1.0
+	if stats.counts.Total > 0 {
+		ratio = min(float64(processed)/float64(stats.counts.Total), 1)
+	}
+	filled := int(ratio * barWidth)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s #%d [%s%s] %d/%d %3.0f%% %.1f/s",
+		stats.command,
+		runID,
+		strings.Repeat("#", filled),
+		strings.Repeat(".", barWidth-filled),
+		processed,
+		stats.counts.Total,
+		ratio*100,
+		stats.rate(now),
+	)
+	if stats.status != "" {
+		fmt.Fprintf(&sb, " %s in %s", stats.status, now.Sub(stats.startedAt).Round(time.Second))
+	} else if eta := stats.eta(now); eta != 0 {
+		fmt.Fprintf(&sb, " ETA %s", eta.Round(time.Second))
+	}
+	if stats.counts.Failed != 0 {
+		fmt.Fprintf(&sb, " errors %d", stats.counts.Failed)
+	}
+	return sb.String()
+}
+
+func (r *reporter) log(ctx context.Context, msg string, runID int32, stats *runStats, now time.Time) {
+	args := []any{
+		"run_id", runID,
+		"command", stats.command,
+		"done_count", stats.counts.Done,
+		"failed_count", stats.counts.Failed,
+		"total_count", stats.counts.Total,
+		"rate_per_sec", fmt.Sprintf("%.2f", stats.rate(now)),
+	}
+	if stats.status != "" {
+		args = append(args, "status", stats.status, "elapsed", now.Sub(stats.startedAt).Round(time.Second).String())
+	} else if eta := stats.eta(now); eta != 0 {
+		args = append(args, "eta", eta.Round(time.Second).String())
+	}
+	logger.FromContext(ctx).Info(msg, args...)
+}
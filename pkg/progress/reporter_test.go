@@ -0,0 +1,179 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRunStatsRateAndETA(t *testing.T) {
+	startedAt := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		stats    runStats
+		elapsed  time.Duration
+		wantRate float64
+		wantETA  time.Duration
+	}{
+		{
+			name:     "in progress",
+			stats:    runStats{counts: Counts{Total: 100, Done: 20}},
+			elapsed:  10 * time.Second,
+			wantRate: 2,
+			wantETA:  40 * time.Second,
+		},
+		{
+			name:     "failed items are processed",
+			stats:    runStats{counts: Counts{Total: 100, Done: 15, Failed: 5}},
+			elapsed:  10 * time.Second,
+			wantRate: 2,
+			wantETA:  40 * time.Second,
+		},
+		{
+			// items done before resume are not counted for rate, but they are not remaining
+			name:     "resumed run",
+			stats:    runStats{counts: Counts{Total: 100, Done: 60}, startDone: 50},
+			elapsed:  10 * time.Second,
+			wantRate: 1,
+			wantETA:  40 * time.Second,
+		},
+		{
+			name:     "nothing processed",
+			stats:    runStats{counts: Counts{Total: 100}},
+			elapsed:  10 * time.Second,
+			wantRate: 0,
+			wantETA:  0,
+		},
+		{
+			name:     "no time elapsed",
+			stats:    runStats{counts: Counts{Total: 100, Done: 20}},
+			elapsed:  0,
+			wantRate: 0,
+			wantETA:  0,
+		},
+		{
+			name:     "completed",
+			stats:    runStats{counts: Counts{Total: 100, Done: 100}},
+			elapsed:  50 * time.Second,
+			wantRate: 2,
+			wantETA:  0,
+		},
+		{
+			name:     "unknown total",
+			stats:    runStats{counts: Counts{Done: 20}},
+			elapsed:  10 * time.Second,
+			wantRate: 2,
+			wantETA:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			stats.startedAt = startedAt
+			now := startedAt.Add(tt.elapsed)
+			if got := stats.rate(now); math.Abs(got-tt.wantRate) > 1e-9 {
+				t.Errorf("rate() = %v, want %v", got, tt.wantRate)
+			}
+			if got := stats.eta(now); got != tt.wantETA {
+				t.Errorf("eta() = %v, want %v", got, tt.wantETA)
+			}
+		})
+	}
+}
+
+func TestReporterBar(t *testing.T) {
+	startedAt := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	now := startedAt.Add(12 * time.Second)
+	tests := []struct {
+		name  string
+		stats runStats
+		want  string
+	}{
+		{
+			name:  "in progress",
+			stats: runStats{command: "fetch-bookmark", counts: Counts{Total: 100, Done: 29, Failed: 1}},
+			want:  "fetch-bookmark #12 [#########.....................] 30/100  30% 2.5/s ETA 28s errors 1",
+		},
+		{
+			name:  "finished",
+			stats: runStats{command: "fetch-bookmark", counts: Counts{Total: 100, Done: 100}, status: "completed"},
+			want:  "fetch-bookmark #12 [##############################] 100/100 100% 8.3/s completed in 12s",
+		},
+		{
+			name:  "no total",
+			stats: runStats{command: "fetch-user-bm-count"},
+			want:  "fetch-user-bm-count #12 [##############################] 0/0 100% 0.0/s",
+		},
+	}
+	r := &reporter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			stats.startedAt = startedAt
+			if got := r.bar(12, &stats, now); got != tt.want {
+				t.Errorf("bar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReporterUpdate(t *testing.T) {
+	startedAt := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	r := &reporter{mode: ReporterModeBar, out: &out, runs: make(map[int32]*runStats)}
+	ctx := context.Background()
+
+	// resumed run which has done 50 items before
+	r.update(ctx, &Event{
+		Type: EventStarted, RunID: 1, Command: "fetch-bookmark",
+		Counts: Counts{Total: 100, Done: 50}, Timestamp: startedAt,
+	})
+	r.update(ctx, &Event{
+		Type: EventSaved, RunID: 1, Command: "fetch-bookmark",
+		Counts: Counts{Total: 100, Done: 60}, Timestamp: startedAt.Add(10 * time.Second),
+	})
+	stats, ok := r.runs[1]
+	if !ok {
+		t.Fatal("run is not tracked")
+	}
+	if stats.startDone != 50 || stats.counts.Done != 60 {
+		t.Errorf("stats = %+v, want startDone 50 and done 60", stats)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output before finished = %q, want empty", out.String())
+	}
+
+	r.update(ctx, &Event{
+		Type: EventFinished, RunID: 1, Command: "fetch-bookmark", Status: "completed",
+		Counts: Counts{Total: 100, Done: 100}, Timestamp: startedAt.Add(20 * time.Second),
+	})
+	if _, ok := r.runs[1]; ok {
+		t.Error("finished run is still tracked")
+	}
+	want := "\r\033[Kfetch-bookmark #1 [##############################] 100/100 100% 2.5/s completed in 20s\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestValidateReporterMode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ReporterMode
+	}{
+		{value: "bar", want: ReporterModeBar},
+		{value: "log", want: ReporterModeLog},
+		{value: "none", want: ReporterModeNone},
+		{value: "auto", want: ReporterModeAuto},
+		{value: "", want: ReporterModeAuto},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := ValidateReporterMode(tt.value); got != tt.want {
+				t.Errorf("ValidateReporterMode(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,15 +3,20 @@ package registry
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
+	"net/http"
+	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/alert"
 	"github.com/hiromaily/hatena-analyzer/pkg/app"
 	"github.com/hiromaily/hatena-analyzer/pkg/args"
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
@@ -19,11 +24,14 @@ import (
 	"github.com/hiromaily/hatena-analyzer/pkg/fetcher"
 	"github.com/hiromaily/hatena-analyzer/pkg/handler"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/mongodb"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+	"github.com/hiromaily/hatena-analyzer/pkg/urlnorm"
 	"github.com/hiromaily/hatena-analyzer/pkg/usecase"
 )
 
@@ -36,13 +44,25 @@ type registry struct {
 	isCLI bool
 
 	// repositories
-	closerRepo          repository.CloserRepositorier
-	fetchBookmarkRepo   repository.FetchBookmarkRepositorier
-	fetchURLRepo        repository.FetchURLRepositorier
-	fetchUserRepo       repository.FetchUserRepositorier
-	timeSeriesRepo      repository.TimeSeriesRepositorier
-	bookmarkDetailsRepo repository.BookmarkDetailsRepositorier
-	summaryRepo         repository.SummaryRepositorier
+	closerRepo            repository.CloserRepositorier
+	fetchBookmarkRepo     repository.FetchBookmarkRepositorier
+	fetchURLRepo          repository.FetchURLRepositorier
+	fetchUserRepo         repository.FetchUserRepositorier
+	timeSeriesRepo        repository.TimeSeriesRepositorier
+	bookmarkDetailsRepo   repository.BookmarkDetailsRepositorier
+	summaryRepo           repository.SummaryRepositorier
+	commentSimilarityRepo repository.CommentSimilarityRepositorier
+	suspicionScoreRepo    repository.SuspicionScoreRepositorier
+	userProfileRepo       repository.UserProfileRepositorier
+	userGrowthRepo        repository.UserGrowthRepositorier
+	mergeURLRepo          repository.MergeURLRepositorier
+	fetchRunRepo          repository.FetchRunRepositorier
+	apiKeyRepo            repository.APIKeyRepositorier
+	alertRepo             repository.AlertRepositorier
+	watchRepo             repository.WatchRepositorier
+
+	// usecases shared by handlers
+	authenticateAPIKeyUsecase usecase.AuthenticateAPIKeyUsecaser
 
 	// db clients
 	postgresClient  *rdb.SqlcPostgresClient
@@ -56,8 +76,12 @@ type registry struct {
 	userBookmarkCountFetcher fetcher.UserBookmarkCountFetcher
 	pageURLFetcher           fetcher.HatenaPageURLFetcher
 	// common instance
-	logger logger.Logger
-	tracer tracer.Tracer
+	logger  logger.Logger
+	logFile io.WriteCloser
+	tracer  tracer.Tracer
+	metrics metrics.Metrics
+	// progress events of runs. shared by fetch usecases and event stream
+	progressNotifier progress.Notifier
 }
 
 func NewRegistry(
@@ -77,9 +101,12 @@ func NewRegistry(
 }
 
 func (r *registry) InitializeApp() (app.Application, error) {
-	_, err := r.newCloserRepository()
-	if err != nil {
-		return nil, err
+	// databases are not used to show config
+	if r.appCode != app.AppCodeShowConfig {
+		_, err := r.newCloserRepository()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if r.isCLI {
@@ -88,15 +115,35 @@ func (r *registry) InitializeApp() (app.Application, error) {
 		if err != nil {
 			return nil, err
 		}
-		app := app.NewCLIApp(handler)
+		tracer, err := r.newTracer(r.appCode.String())
+		if err != nil {
+			return nil, err
+		}
+		app := app.NewCLIApp(r.newLogger(), handler, tracer, r.newMetrics())
 		return app, nil
 	}
 	// Web Application
 	ginEngine := gin.Default()
+	// span is created per incoming request
+	ginEngine.Use(otelgin.Middleware(r.envConf.TracerServiceName))
+	// request_id and trace_id are added to logs of request
+	ginEngine.Use(handler.RequestLogger(r.newLogger()))
 	if err := r.createWebHandler(ginEngine); err != nil {
 		return nil, err
 	}
-	app := app.NewWebApp(ginEngine, r.args.WebCommand.Port)
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	app := app.NewWebApp(
+		r.newLogger(),
+		ginEngine,
+		tracer,
+		r.args.WebCommand.Port,
+		r.envConf.WebShutdownTimeout,
+		// event streams are ended to shutdown gracefully
+		r.newProgressNotifier().Close,
+	)
 	return app, nil
 }
 
@@ -108,6 +155,9 @@ func (r *registry) Close() error {
 	if r.closerRepo != nil {
 		r.closerRepo.Close(context.Background())
 	}
+	if r.logFile != nil {
+		return r.logFile.Close()
+	}
 	return nil
 }
 
@@ -122,12 +172,38 @@ func (r *registry) createCLIHandler() (handler.Handler, error) {
 		handler, err = r.newFetchBookmarkHandler()
 	case r.appCode == app.AppCodeFetchUserBookmarkCount:
 		handler, err = r.newFetchUserBookmarkCountHandler()
+	case r.appCode == app.AppCodeCalcSuspicionScore:
+		handler, err = r.newCalcSuspicionScoreHandler()
 	case r.appCode == app.AppCodeViewTimeSeries:
 		handler, err = r.newViewTimeSeriesHanlder()
 	case r.appCode == app.AppCodeViewBookmarkDetails:
 		handler, err = r.newViewBookmarkDetailsHanlder()
 	case r.appCode == app.AppCodeViewSummary:
 		handler, err = r.newViewSummaryHanlder()
+	case r.appCode == app.AppCodeViewCommentSimilarity:
+		handler, err = r.newViewCommentSimilarityHanlder()
+	case r.appCode == app.AppCodeViewUser:
+		handler, err = r.newViewUserHanlder()
+	case r.appCode == app.AppCodeViewUserGrowth:
+		handler, err = r.newViewUserGrowthHanlder()
+	case r.appCode == app.AppCodeMergeDuplicateURLs:
+		handler, err = r.newMergeDuplicateURLsHandler()
+	case r.appCode == app.AppCodeListRuns:
+		handler, err = r.newListRunsHandler()
+	case r.appCode == app.AppCodeShowConfig:
+		handler = r.newShowConfigHandler()
+	case r.appCode == app.AppCodeIssueAPIKey:
+		handler, err = r.newIssueAPIKeyHandler()
+	case r.appCode == app.AppCodeRevokeAPIKey:
+		handler, err = r.newRevokeAPIKeyHandler()
+	case r.appCode == app.AppCodeListAPIKeys:
+		handler, err = r.newListAPIKeysHandler()
+	case r.appCode == app.AppCodeAddWatch:
+		handler, err = r.newAddWatchHandler()
+	case r.appCode == app.AppCodeRemoveWatch:
+		handler, err = r.newRemoveWatchHandler()
+	case r.appCode == app.AppCodeListWatch:
+		handler, err = r.newListWatchHandler()
 	}
 	if err != nil {
 		return nil, err
@@ -139,43 +215,137 @@ func (r *registry) createCLIHandler() (handler.Handler, error) {
 }
 
 func (r *registry) createWebHandler(ginEngine *gin.Engine) error {
+	ginEngine.NoRoute(handler.NotFound)
+
+	// dashboard is public, but API key is required to read data
+	ginEngine.StaticFS("/dashboard", handler.DashboardFS())
+	ginEngine.GET("/", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, "/dashboard/")
+	})
+
 	v1Router := ginEngine.Group("/api/v1")
+	// specification doesn't require API key
+	v1Router.GET("/openapi.json", handler.OpenAPI)
+	// fetch-* and other commands updating data require write scope, view-* require read scope
+	// endpoints taking URLs accept POST with JSON body as well for large URL lists
+	writeRouter := v1Router.Group("")
+	readRouter := v1Router.Group("")
+	// metrics for Prometheus require read scope unless METRICS_PUBLIC is set
+	metricsRouter := ginEngine.Group("")
+	if r.envConf.WebAPIAuth {
+		authUsecase, err := r.newAuthenticateAPIKeyUsecase()
+		if err != nil {
+			return err
+		}
+		writeRouter.Use(handler.APIKeyAuth(authUsecase, entities.APIKeyScopeWrite))
+		readRouter.Use(handler.APIKeyAuth(authUsecase, entities.APIKeyScopeRead))
+		if !r.envConf.MetricsPublic {
+			metricsRouter.Use(handler.APIKeyAuth(authUsecase, entities.APIKeyScopeRead))
+		}
+	}
+	if metricsHandler := r.newMetrics().Handler(); metricsHandler != nil {
+		metricsRouter.GET("/metrics", gin.WrapH(metricsHandler))
+	}
 
 	handler, err := r.newFetchHatenaPageURLsHandler()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/fetch-page-url", handler.WebHandler)
+	writeRouter.GET("/fetch-page-url", handler.WebHandler)
 
 	handler, err = r.newFetchBookmarkHandler()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/fetch-bookmark", handler.WebHandler)
+	writeRouter.GET("/fetch-bookmark", handler.WebHandler)
+	writeRouter.POST("/fetch-bookmark", handler.WebHandler)
 
 	handler, err = r.newFetchUserBookmarkCountHandler()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/fetch-user-bookmark-count", handler.WebHandler)
+	writeRouter.GET("/fetch-user-bookmark-count", handler.WebHandler)
+	writeRouter.POST("/fetch-user-bookmark-count", handler.WebHandler)
+
+	handler, err = r.newCalcSuspicionScoreHandler()
+	if err != nil {
+		return err
+	}
+	writeRouter.GET("/calc-suspicion-score", handler.WebHandler)
+	writeRouter.POST("/calc-suspicion-score", handler.WebHandler)
 
 	handler, err = r.newViewTimeSeriesHanlder()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/view-time-series", handler.WebHandler)
+	readRouter.GET("/view-time-series", handler.WebHandler)
+	readRouter.POST("/view-time-series", handler.WebHandler)
 
 	handler, err = r.newViewBookmarkDetailsHanlder()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/view-bookmark-details", handler.WebHandler)
+	readRouter.GET("/view-bookmark-details", handler.WebHandler)
+	readRouter.POST("/view-bookmark-details", handler.WebHandler)
 
 	handler, err = r.newViewSummaryHanlder()
 	if err != nil {
 		return err
 	}
-	v1Router.GET("/view-summary", handler.WebHandler)
+	readRouter.GET("/view-summary", handler.WebHandler)
+	readRouter.POST("/view-summary", handler.WebHandler)
+
+	handler, err = r.newViewCommentSimilarityHanlder()
+	if err != nil {
+		return err
+	}
+	readRouter.GET("/view-comment-similarity", handler.WebHandler)
+	readRouter.POST("/view-comment-similarity", handler.WebHandler)
+
+	handler, err = r.newViewUserHanlder()
+	if err != nil {
+		return err
+	}
+	readRouter.GET("/users/:name", handler.WebHandler)
+
+	handler, err = r.newViewUserGrowthHanlder()
+	if err != nil {
+		return err
+	}
+	readRouter.GET("/view-user-growth", handler.WebHandler)
+
+	handler, err = r.newMergeDuplicateURLsHandler()
+	if err != nil {
+		return err
+	}
+	writeRouter.GET("/merge-duplicate-urls", handler.WebHandler)
+
+	handler, err = r.newListRunsHandler()
+	if err != nil {
+		return err
+	}
+	readRouter.GET("/runs", handler.WebHandler)
+
+	handler = r.newRunEventsHandler()
+	readRouter.GET("/runs/events", handler.WebHandler)
+
+	handler, err = r.newListWatchHandler()
+	if err != nil {
+		return err
+	}
+	readRouter.GET("/watch", handler.WebHandler)
+
+	handler, err = r.newAddWatchHandler()
+	if err != nil {
+		return err
+	}
+	writeRouter.POST("/watch", handler.WebHandler)
+
+	handler, err = r.newRemoveWatchHandler()
+	if err != nil {
+		return err
+	}
+	writeRouter.DELETE("/watch", handler.WebHandler)
 
 	return nil
 }
@@ -189,128 +359,621 @@ func (r *registry) newFetchHatenaPageURLsHandler() (handler.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		return handler.NewFetchHatenaPageURLsCLIHandler(r.newLogger(), usecaser), nil
-	}
-	return handler.NewFetchHatenaPageURLsWebHandler(r.newLogger(), usecaser), nil
+	if r.isCLI {
+		return handler.NewFetchHatenaPageURLsCLIHandler(r.newLogger(), usecaser), nil
+	}
+	return handler.NewFetchHatenaPageURLsWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newFetchBookmarkHandler() (handler.Handler, error) {
+	usecaser, err := r.newFetchBookmarkUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.FetchBookmarkEntitiesCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		filter, err := newURLFilter(&r.args.FetchBookmarkEntitiesCommand.URLFilterArgs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewFetchBookmarkCLIHandler(
+			r.newLogger(), usecaser,
+			urls, filter,
+			r.args.FetchBookmarkEntitiesCommand.Watched,
+			r.args.FetchBookmarkEntitiesCommand.Verbose,
+			r.args.FetchBookmarkEntitiesCommand.Resume,
+			r.newProgressReporter(
+				r.args.FetchBookmarkEntitiesCommand.Progress,
+				r.args.FetchBookmarkEntitiesCommand.Verbose,
+			),
+		), nil
+	}
+	return handler.NewFetchBookmarkWebHandler(
+		r.newLogger(), usecaser,
+	), nil
+}
+
+func (r *registry) newFetchUserBookmarkCountHandler() (handler.Handler, error) {
+	usecaser, err := r.newFetchUserBookmarkCountUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.FetchUserBookmarkCountCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		policy := entities.NewUserRefreshPolicy(
+			r.args.FetchUserBookmarkCountCommand.StaleDays,
+			r.args.FetchUserBookmarkCountCommand.RecentDays,
+			r.args.FetchUserBookmarkCountCommand.MaxUsers,
+		)
+		return handler.NewFetchUserBookmarkCountCLIHandler(
+			r.newLogger(), usecaser,
+			urls, policy, r.args.FetchUserBookmarkCountCommand.Resume,
+			r.newProgressReporter(r.args.FetchUserBookmarkCountCommand.Progress, false),
+		), nil
+	}
+	return handler.NewFetchUserBookmarkCountWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newCalcSuspicionScoreHandler() (handler.Handler, error) {
+	usecaser, err := r.newCalcSuspicionScoreUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.CalcSuspicionScoreCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewCalcSuspicionScoreCLIHandler(r.newLogger(), usecaser, urls), nil
+	}
+	return handler.NewCalcSuspicionScoreWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewTimeSeriesHanlder() (handler.Handler, error) {
+	usecaser, err := r.newViewTimeSeriesUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.ViewTimeSeriesCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		filter, err := newURLFilter(&r.args.ViewTimeSeriesCommand.URLFilterArgs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewViewTimeSeriesCLIHandler(r.newLogger(), usecaser, urls, filter), nil
+	}
+	return handler.NewViewTimeSeriesWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewBookmarkDetailsHanlder() (handler.Handler, error) {
+	usecaser, err := r.newViewBookmarkDetailsUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.ViewBookmarkDetailsCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		buckets, err := entities.ParseHistogramBuckets(r.args.ViewBookmarkDetailsCommand.Buckets)
+		if err != nil {
+			return nil, err
+		}
+		filter, err := newURLFilter(&r.args.ViewBookmarkDetailsCommand.URLFilterArgs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewViewBookmarkDetailsCLIHandler(r.newLogger(), usecaser, urls, filter, buckets), nil
+	}
+	return handler.NewViewBookmarkDetailsWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewSummaryHanlder() (handler.Handler, error) {
+	// retrieve args
+	usecaser, err := r.newViewSummaryUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		urls, err := r.splitURLs(r.args.ViewSummaryCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		sortKey, err := usecase.ToSummarySortKey(r.args.ViewSummaryCommand.Sort)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewViewSummaryCLIHandler(
+			r.newLogger(),
+			usecaser,
+			urls,
+			r.args.ViewSummaryCommand.Threshold,
+			sortKey,
+		), nil
+	}
+	return handler.NewViewSummaryWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewCommentSimilarityHanlder() (handler.Handler, error) {
+	usecaser, err := r.newViewCommentSimilarityUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		// retrieve args
+		urls, err := r.splitURLs(r.args.ViewCommentSimilarityCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewViewCommentSimilarityCLIHandler(
+			r.newLogger(),
+			usecaser,
+			urls,
+			r.args.ViewCommentSimilarityCommand.Threshold,
+		), nil
+	}
+	return handler.NewViewCommentSimilarityWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewUserHanlder() (handler.Handler, error) {
+	usecaser, err := r.newViewUserUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		return handler.NewViewUserCLIHandler(
+			r.newLogger(),
+			usecaser,
+			r.args.ViewUserCommand.Name,
+			r.args.ViewUserCommand.MinScore,
+		), nil
+	}
+	return handler.NewViewUserWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newViewUserGrowthHanlder() (handler.Handler, error) {
+	usecaser, err := r.newViewUserGrowthUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		return handler.NewViewUserGrowthCLIHandler(
+			r.newLogger(),
+			usecaser,
+			r.args.ViewUserGrowthCommand.Days,
+			r.args.ViewUserGrowthCommand.Limit,
+			r.args.ViewUserGrowthCommand.Jump,
+		), nil
+	}
+	return handler.NewViewUserGrowthWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newMergeDuplicateURLsHandler() (handler.Handler, error) {
+	usecaser, err := r.newMergeDuplicateURLsUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		return handler.NewMergeDuplicateURLsCLIHandler(
+			r.newLogger(),
+			usecaser,
+			r.args.MergeDuplicateURLsCommand.DryRun,
+		), nil
+	}
+	return handler.NewMergeDuplicateURLsWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newListRunsHandler() (handler.Handler, error) {
+	usecaser, err := r.newListRunsUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		return handler.NewListRunsCLIHandler(r.newLogger(), usecaser, r.args.RunsCommand.ListCommand.Limit), nil
+	}
+	return handler.NewListRunsWebHandler(r.newLogger(), usecaser), nil
+}
+
+// web only
+func (r *registry) newRunEventsHandler() handler.Handler {
+	return handler.NewRunEventsWebHandler(r.newLogger(), r.newProgressNotifier())
+}
+
+func (r *registry) newIssueAPIKeyHandler() (handler.Handler, error) {
+	usecaser, err := r.newAPIKeyUsecase()
+	if err != nil {
+		return nil, err
+	}
+	scope, err := entities.ParseAPIKeyScope(r.args.APIKeyCommand.IssueCommand.Scope)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewIssueAPIKeyCLIHandler(
+		r.newLogger(),
+		usecaser,
+		r.args.APIKeyCommand.IssueCommand.Name,
+		scope,
+		r.args.APIKeyCommand.IssueCommand.RateLimit,
+		r.args.APIKeyCommand.IssueCommand.DailyQuota,
+	), nil
+}
+
+func (r *registry) newRevokeAPIKeyHandler() (handler.Handler, error) {
+	usecaser, err := r.newAPIKeyUsecase()
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewRevokeAPIKeyCLIHandler(r.newLogger(), usecaser, r.args.APIKeyCommand.RevokeCommand.ID), nil
+}
+
+func (r *registry) newListAPIKeysHandler() (handler.Handler, error) {
+	usecaser, err := r.newAPIKeyUsecase()
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewListAPIKeysCLIHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newAddWatchHandler() (handler.Handler, error) {
+	usecaser, err := r.newWatchUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		urls, err := r.splitURLs(r.args.WatchCommand.AddCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewAddWatchCLIHandler(
+			r.newLogger(),
+			usecaser,
+			urls,
+			splitUserNames(r.args.WatchCommand.AddCommand.Users),
+			r.args.WatchCommand.AddCommand.Note,
+		), nil
+	}
+	return handler.NewAddWatchWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newRemoveWatchHandler() (handler.Handler, error) {
+	usecaser, err := r.newWatchUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		urls, err := r.splitURLs(r.args.WatchCommand.RemoveCommand.URLs)
+		if err != nil {
+			return nil, err
+		}
+		return handler.NewRemoveWatchCLIHandler(
+			r.newLogger(),
+			usecaser,
+			urls,
+			splitUserNames(r.args.WatchCommand.RemoveCommand.Users),
+		), nil
+	}
+	return handler.NewRemoveWatchWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newListWatchHandler() (handler.Handler, error) {
+	usecaser, err := r.newWatchUsecase()
+	if err != nil {
+		return nil, err
+	}
+	if r.isCLI {
+		return handler.NewListWatchCLIHandler(r.newLogger(), usecaser, r.args.WatchCommand.ListCommand.Days), nil
+	}
+	return handler.NewListWatchWebHandler(r.newLogger(), usecaser), nil
+}
+
+func (r *registry) newShowConfigHandler() handler.Handler {
+	return handler.NewShowConfigCLIHandler(r.newLogger(), r.envConf)
+}
+
+// split comma separated urls and normalize them
+func (r *registry) splitURLs(urlString string) ([]string, error) {
+	if urlString == "" {
+		return nil, nil
+	}
+	urls, err := urlnorm.UniqueURLs(strings.Split(urlString, ","))
+	if err != nil {
+		return nil, err
+	}
+	r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
+	return urls, nil
+}
+
+// e.g. user1,user2
+func splitUserNames(userString string) []string {
+	var userNames []string
+	for _, userName := range strings.Split(userString, ",") {
+		if userName = strings.TrimSpace(userName); userName != "" {
+			userNames = append(userNames, userName)
+		}
+	}
+	return userNames
+}
+
+func newURLFilter(filterArgs *args.URLFilterArgs) (*entities.URLFilter, error) {
+	return entities.NewURLFilter(
+		filterArgs.Category,
+		filterArgs.Date,
+		filterArgs.From,
+		filterArgs.To,
+		filterArgs.Order,
+		filterArgs.Limit,
+		filterArgs.Page,
+	)
+}
+
+///
+/// usecases
+///
+
+// must be called only once
+
+func (r *registry) newFetchHatenaPageURLsUsecase() (usecase.FetchHatenaPageURLsUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	urlRepo, err := r.newURLRepository()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewFetchHatenaPageURLsUsecase(
+		r.newLogger(),
+		tracer,
+		r.newMetrics(),
+		urlRepo,
+		r.newPageURLFetcher(),
+		entities.Unknown, // TODO: pass from cli args
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+func (r *registry) newFetchBookmarkUsecase() (usecase.FetchBookmarkUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	bookmarkRepo, err := r.newBookmarkRepository()
+	if err != nil {
+		return nil, err
+	}
+	fetchRunRepo, err := r.newFetchRunRepository()
+	if err != nil {
+		return nil, err
+	}
+	alertUsecase, err := r.newEvaluateAlertsUsecase()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewFetchBookmarkUsecase(
+		r.newLogger(),
+		tracer,
+		r.newMetrics(),
+		r.newProgressNotifier(),
+		bookmarkRepo,
+		fetchRunRepo,
+		r.newBookmarkFetcher(),
+		alertUsecase,
+		r.envConf.MaxWorkers, // maxWorker
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+// nil is returned if alerting is disabled
+func (r *registry) newEvaluateAlertsUsecase() (usecase.EvaluateAlertsUsecaser, error) {
+	if len(r.envConf.AlertSinks) == 0 {
+		return nil, nil
+	}
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	alertRepo, err := r.newAlertRepository()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewEvaluateAlertsUsecase(
+		r.newLogger(),
+		tracer,
+		alertRepo,
+		r.newAlertSink(),
+		&entities.AlertRules{
+			PrivateUserRate: r.envConf.AlertPrivateUserRate,
+			NewUserRate:     r.envConf.AlertNewUserRate,
+			DeletionSpike:   r.envConf.AlertDeletionSpike,
+			Burst:           r.envConf.AlertBurst,
+		},
+		r.envConf.AlertDedupWindow,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+func (r *registry) newCalcSuspicionScoreUsecase() (usecase.CalcSuspicionScoreUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	suspicionScoreRepo, err := r.newSuspicionScoreRepository()
+	if err != nil {
+		return nil, err
+	}
+	// cli args takes priority over environment variable
+	weightsFile := r.envConf.ScoreWeightsFile
+	if r.isCLI && r.args.CalcSuspicionScoreCommand.Weights != "" {
+		weightsFile = r.args.CalcSuspicionScoreCommand.Weights
+	}
+	usecase, err := usecase.NewCalcSuspicionScoreUsecase(
+		r.newLogger(),
+		tracer,
+		suspicionScoreRepo,
+		weightsFile,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+func (r *registry) newViewTimeSeriesUsecase() (usecase.ViewTimeSeriesUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	timeSeriesRepo, err := r.newTimeSeriesRepository()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewViewTimeSeriesUsecase(
+		r.newLogger(),
+		tracer,
+		timeSeriesRepo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+func (r *registry) newViewBookmarkDetailsUsecase() (usecase.ViewBookmarkDetailsUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	bookmarkDetailsRepo, err := r.newBookmarkDetailsRepository()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewViewBookmarkDetailsUsecase(
+		r.newLogger(),
+		tracer,
+		bookmarkDetailsRepo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
+}
+
+func (r *registry) newViewSummaryUsecase() (usecase.ViewSummaryUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
+	if err != nil {
+		return nil, err
+	}
+	summaryRepo, err := r.newSummaryRepository()
+	if err != nil {
+		return nil, err
+	}
+	usecase, err := usecase.NewViewSummaryUsecase(
+		r.newLogger(),
+		tracer,
+		summaryRepo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
 }
 
-func (r *registry) newFetchBookmarkHandler() (handler.Handler, error) {
-	usecaser, err := r.newFetchBookmarkUsecase()
+func (r *registry) newViewCommentSimilarityUsecase() (usecase.ViewCommentSimilarityUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		// retrieve args
-		var urls []string
-		if r.args.FetchBookmarkEntitiesCommand.URLs != "" {
-			urls = strings.Split(r.args.FetchBookmarkEntitiesCommand.URLs, ",")
-			r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
-		}
-		return handler.NewFetchBookmarkCLIHandler(
-			r.newLogger(), usecaser,
-			urls, r.args.FetchBookmarkEntitiesCommand.Verbose,
-		), nil
-	}
-	return handler.NewFetchBookmarkWebHandler(
-		r.newLogger(), usecaser,
-	), nil
-}
-
-func (r *registry) newFetchUserBookmarkCountHandler() (handler.Handler, error) {
-	usecaser, err := r.newFetchUserBookmarkCountUsecase()
+	commentSimilarityRepo, err := r.newCommentSimilarityRepository()
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		// retrieve args
-		var urls []string
-		if r.args.FetchUserBookmarkCountCommand.URLs != "" {
-			urls = strings.Split(r.args.FetchUserBookmarkCountCommand.URLs, ",")
-			r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
-		}
-		return handler.NewFetchUserBookmarkCountCLIHandler(r.newLogger(), usecaser, urls), nil
+	usecase, err := usecase.NewViewCommentSimilarityUsecase(
+		r.newLogger(),
+		tracer,
+		commentSimilarityRepo,
+	)
+	if err != nil {
+		return nil, err
 	}
-	return handler.NewFetchUserBookmarkCountWebHandler(r.newLogger(), usecaser), nil
+	return usecase, nil
 }
 
-func (r *registry) newViewTimeSeriesHanlder() (handler.Handler, error) {
-	usecaser, err := r.newViewTimeSeriesUsecase()
+func (r *registry) newViewUserUsecase() (usecase.ViewUserUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		// retrieve args
-		var urls []string
-		if r.args.ViewTimeSeriesCommand.URLs != "" {
-			urls = strings.Split(r.args.ViewTimeSeriesCommand.URLs, ",")
-			r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
-		}
-		return handler.NewViewTimeSeriesCLIHandler(r.newLogger(), usecaser, urls), nil
-	}
-	return handler.NewViewTimeSeriesWebHandler(r.newLogger(), usecaser), nil
-}
-
-func (r *registry) newViewBookmarkDetailsHanlder() (handler.Handler, error) {
-	usecaser, err := r.newViewBookmarkDetailsUsecase()
+	userProfileRepo, err := r.newUserProfileRepository()
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		// retrieve args
-		var urls []string
-		if r.args.ViewBookmarkDetailsCommand.URLs != "" {
-			urls = strings.Split(r.args.ViewBookmarkDetailsCommand.URLs, ",")
-			r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
-		}
-		return handler.NewViewBookmarkDetailsCLIHandler(r.newLogger(), usecaser, urls), nil
+	usecase, err := usecase.NewViewUserUsecase(
+		r.newLogger(),
+		tracer,
+		userProfileRepo,
+	)
+	if err != nil {
+		return nil, err
 	}
-	return handler.NewViewBookmarkDetailsWebHandler(r.newLogger(), usecaser), nil
+	return usecase, nil
 }
 
-func (r *registry) newViewSummaryHanlder() (handler.Handler, error) {
-	// retrieve args
-	usecaser, err := r.newViewSummaryUsecase()
+func (r *registry) newViewUserGrowthUsecase() (usecase.ViewUserGrowthUsecaser, error) {
+	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	if r.isCLI {
-		var urls []string
-		if r.args.ViewSummaryCommand.URLs != "" {
-			urls = strings.Split(r.args.ViewSummaryCommand.URLs, ",")
-			r.newLogger().Info("given URLs", "urls", urls, "len", len(urls))
-		}
-		return handler.NewViewSummaryCLIHandler(
-			r.newLogger(),
-			usecaser,
-			urls,
-			r.args.ViewSummaryCommand.Threshold,
-		), nil
+	userGrowthRepo, err := r.newUserGrowthRepository()
+	if err != nil {
+		return nil, err
 	}
-	return handler.NewViewSummaryWebHandler(r.newLogger(), usecaser), nil
+	usecase, err := usecase.NewViewUserGrowthUsecase(
+		r.newLogger(),
+		tracer,
+		userGrowthRepo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return usecase, nil
 }
 
-///
-/// usecases
-///
-
-// must be called only once
-
-func (r *registry) newFetchHatenaPageURLsUsecase() (usecase.FetchHatenaPageURLsUsecaser, error) {
+func (r *registry) newMergeDuplicateURLsUsecase() (usecase.MergeDuplicateURLsUsecaser, error) {
 	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	urlRepo, err := r.newURLRepository()
+	mergeURLRepo, err := r.newMergeURLRepository()
 	if err != nil {
 		return nil, err
 	}
-	usecase, err := usecase.NewFetchHatenaPageURLsUsecase(
+	usecase, err := usecase.NewMergeDuplicateURLsUsecase(
 		r.newLogger(),
 		tracer,
-		urlRepo,
-		r.newPageURLFetcher(),
-		entities.Unknown, // TODO: pass from cli args
+		mergeURLRepo,
 	)
 	if err != nil {
 		return nil, err
@@ -318,21 +981,19 @@ func (r *registry) newFetchHatenaPageURLsUsecase() (usecase.FetchHatenaPageURLsU
 	return usecase, nil
 }
 
-func (r *registry) newFetchBookmarkUsecase() (usecase.FetchBookmarkUsecaser, error) {
+func (r *registry) newListRunsUsecase() (usecase.ListRunsUsecaser, error) {
 	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	bookmarkRepo, err := r.newBookmarkRepository()
+	fetchRunRepo, err := r.newFetchRunRepository()
 	if err != nil {
 		return nil, err
 	}
-	usecase, err := usecase.NewFetchBookmarkUsecase(
+	usecase, err := usecase.NewListRunsUsecase(
 		r.newLogger(),
 		tracer,
-		bookmarkRepo,
-		r.newBookmarkFetcher(),
-		r.envConf.MaxWorkers, // maxWorker
+		fetchRunRepo,
 	)
 	if err != nil {
 		return nil, err
@@ -340,19 +1001,19 @@ func (r *registry) newFetchBookmarkUsecase() (usecase.FetchBookmarkUsecaser, err
 	return usecase, nil
 }
 
-func (r *registry) newViewTimeSeriesUsecase() (usecase.ViewTimeSeriesUsecaser, error) {
+func (r *registry) newAPIKeyUsecase() (usecase.APIKeyUsecaser, error) {
 	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	timeSeriesRepo, err := r.newTimeSeriesRepository()
+	apiKeyRepo, err := r.newAPIKeyRepository()
 	if err != nil {
 		return nil, err
 	}
-	usecase, err := usecase.NewViewTimeSeriesUsecase(
+	usecase, err := usecase.NewAPIKeyUsecase(
 		r.newLogger(),
 		tracer,
-		timeSeriesRepo,
+		apiKeyRepo,
 	)
 	if err != nil {
 		return nil, err
@@ -360,19 +1021,19 @@ func (r *registry) newViewTimeSeriesUsecase() (usecase.ViewTimeSeriesUsecaser, e
 	return usecase, nil
 }
 
-func (r *registry) newViewBookmarkDetailsUsecase() (usecase.ViewBookmarkDetailsUsecaser, error) {
+func (r *registry) newWatchUsecase() (usecase.WatchUsecaser, error) {
 	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	bookmarkDetailsRepo, err := r.newBookmarkDetailsRepository()
+	watchRepo, err := r.newWatchRepository()
 	if err != nil {
 		return nil, err
 	}
-	usecase, err := usecase.NewViewBookmarkDetailsUsecase(
+	usecase, err := usecase.NewWatchUsecase(
 		r.newLogger(),
 		tracer,
-		bookmarkDetailsRepo,
+		watchRepo,
 	)
 	if err != nil {
 		return nil, err
@@ -380,23 +1041,28 @@ func (r *registry) newViewBookmarkDetailsUsecase() (usecase.ViewBookmarkDetailsU
 	return usecase, nil
 }
 
-func (r *registry) newViewSummaryUsecase() (usecase.ViewSummaryUsecaser, error) {
+// shared by middlewares to keep rate limiters of API keys
+func (r *registry) newAuthenticateAPIKeyUsecase() (usecase.AuthenticateAPIKeyUsecaser, error) {
+	if r.authenticateAPIKeyUsecase != nil {
+		return r.authenticateAPIKeyUsecase, nil
+	}
 	tracer, err := r.newTracer(r.appCode.String())
 	if err != nil {
 		return nil, err
 	}
-	summaryRepo, err := r.newSummaryRepository()
+	apiKeyRepo, err := r.newAPIKeyRepository()
 	if err != nil {
 		return nil, err
 	}
-	usecase, err := usecase.NewViewSummaryUsecase(
+	usecase, err := usecase.NewAuthenticateAPIKeyUsecase(
 		r.newLogger(),
 		tracer,
-		summaryRepo,
+		apiKeyRepo,
 	)
 	if err != nil {
 		return nil, err
 	}
+	r.authenticateAPIKeyUsecase = usecase
 	return usecase, nil
 }
 
@@ -409,10 +1075,17 @@ func (r *registry) newFetchUserBookmarkCountUsecase() (usecase.FetchUserBookmark
 	if err != nil {
 		return nil, err
 	}
+	fetchRunRepo, err := r.newFetchRunRepository()
+	if err != nil {
+		return nil, err
+	}
 	usecase, err := usecase.NewFetchUserBookmarkCountUsecase(
 		r.newLogger(),
 		tracer,
+		r.newMetrics(),
+		r.newProgressNotifier(),
 		userRepo,
+		fetchRunRepo,
 		r.newUserBookmarkCountFetcher(),
 		r.envConf.MaxWorkers, // maxWorker
 	)
@@ -466,6 +1139,7 @@ func (r *registry) newBookmarkRepository() (repository.FetchBookmarkRepositorier
 	if r.fetchBookmarkRepo == nil {
 		r.fetchBookmarkRepo = repository.NewFetchBookmarkRepository(
 			r.newLogger(),
+			r.newMetrics(),
 			pgQuery,
 			influxdbQuery,
 			mongodbQuery,
@@ -474,7 +1148,31 @@ func (r *registry) newBookmarkRepository() (repository.FetchBookmarkRepositorier
 	return r.fetchBookmarkRepo, nil
 }
 
+func (r *registry) newSuspicionScoreRepository() (repository.SuspicionScoreRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	influxdbQuery, err := r.newInfluxDBQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.suspicionScoreRepo == nil {
+		r.suspicionScoreRepo = repository.NewSuspicionScoreRepository(
+			r.newLogger(),
+			r.newMetrics(),
+			pgQuery,
+			influxdbQuery,
+		)
+	}
+	return r.suspicionScoreRepo, nil
+}
+
 func (r *registry) newTimeSeriesRepository() (repository.TimeSeriesRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
 	influxdbQuery, err := r.newInfluxDBQueries()
 	if err != nil {
 		return nil, err
@@ -482,6 +1180,7 @@ func (r *registry) newTimeSeriesRepository() (repository.TimeSeriesRepositorier,
 	if r.timeSeriesRepo == nil {
 		r.timeSeriesRepo = repository.NewTimeSeriesRepository(
 			r.newLogger(),
+			pgQuery,
 			influxdbQuery,
 		)
 	}
@@ -516,6 +1215,135 @@ func (r *registry) newSummaryRepository() (repository.SummaryRepositorier, error
 	return r.summaryRepo, nil
 }
 
+func (r *registry) newCommentSimilarityRepository() (repository.CommentSimilarityRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.commentSimilarityRepo == nil {
+		r.commentSimilarityRepo = repository.NewCommentSimilarityRepository(
+			r.newLogger(),
+			pgQuery,
+		)
+	}
+	return r.commentSimilarityRepo, nil
+}
+
+func (r *registry) newUserProfileRepository() (repository.UserProfileRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.userProfileRepo == nil {
+		r.userProfileRepo = repository.NewUserProfileRepository(
+			r.newLogger(),
+			pgQuery,
+		)
+	}
+	return r.userProfileRepo, nil
+}
+
+func (r *registry) newUserGrowthRepository() (repository.UserGrowthRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.userGrowthRepo == nil {
+		r.userGrowthRepo = repository.NewUserGrowthRepository(
+			r.newLogger(),
+			pgQuery,
+		)
+	}
+	return r.userGrowthRepo, nil
+}
+
+func (r *registry) newMergeURLRepository() (repository.MergeURLRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	influxdbQuery, err := r.newInfluxDBQueries()
+	if err != nil {
+		return nil, err
+	}
+	mongodbQuery, err := r.newMongoDBQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.mergeURLRepo == nil {
+		r.mergeURLRepo = repository.NewMergeURLRepository(
+			r.newLogger(),
+			pgQuery,
+			influxdbQuery,
+			mongodbQuery,
+		)
+	}
+	return r.mergeURLRepo, nil
+}
+
+func (r *registry) newFetchRunRepository() (repository.FetchRunRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.fetchRunRepo == nil {
+		r.fetchRunRepo = repository.NewFetchRunRepository(
+			r.newLogger(),
+			pgQuery,
+		)
+	}
+	return r.fetchRunRepo, nil
+}
+
+func (r *registry) newAPIKeyRepository() (repository.APIKeyRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.apiKeyRepo == nil {
+		r.apiKeyRepo = repository.NewAPIKeyRepository(
+			r.newLogger(),
+			pgQuery,
+		)
+	}
+	return r.apiKeyRepo, nil
+}
+
+func (r *registry) newAlertRepository() (repository.AlertRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	influxdbQuery, err := r.newInfluxDBQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.alertRepo == nil {
+		r.alertRepo = repository.NewAlertRepository(
+			r.newLogger(),
+			r.newMetrics(),
+			pgQuery,
+			influxdbQuery,
+		)
+	}
+	return r.alertRepo, nil
+}
+
+func (r *registry) newWatchRepository() (repository.WatchRepositorier, error) {
+	pgQuery, err := r.newPostgresQueries()
+	if err != nil {
+		return nil, err
+	}
+	if r.watchRepo == nil {
+		r.watchRepo = repository.NewWatchRepository(
+			r.newLogger(),
+			r.newMetrics(),
+			pgQuery,
+		)
+	}
+	return r.watchRepo, nil
+}
+
 func (r *registry) newUserRepository() (repository.FetchUserRepositorier, error) {
 	pgQuery, err := r.newPostgresQueries()
 	if err != nil {
@@ -524,6 +1352,7 @@ func (r *registry) newUserRepository() (repository.FetchUserRepositorier, error)
 	if r.fetchUserRepo == nil {
 		r.fetchUserRepo = repository.NewFetchUserRepository(
 			r.newLogger(),
+			r.newMetrics(),
 			pgQuery,
 		)
 	}
@@ -538,6 +1367,7 @@ func (r *registry) newURLRepository() (repository.FetchURLRepositorier, error) {
 	if r.fetchURLRepo == nil {
 		r.fetchURLRepo = repository.NewFetchURLRepository(
 			r.newLogger(),
+			r.newMetrics(),
 			pgQuery,
 		)
 	}
@@ -604,15 +1434,38 @@ func (r *registry) newLogger() logger.Logger {
 		if r.envConf.IsDebug {
 			logLevel = slog.LevelDebug
 		}
+		// log file is used only in CLI mode. web server logs to stdout/stderr
+		var logFile io.WriteCloser
+		if r.isCLI && r.envConf.LogFile != "" && r.envConf.Logger != "none" {
+			logFile = logger.NewRotateWriter(&logger.FileConfig{
+				Path:       r.envConf.LogFile,
+				MaxSizeMB:  r.envConf.LogFileMaxSizeMB,
+				MaxBackups: r.envConf.LogFileMaxBackups,
+				MaxAgeDays: r.envConf.LogFileMaxAgeDays,
+				Compress:   r.envConf.LogFileCompress,
+			})
+			r.logFile = logFile
+		}
 		switch r.envConf.Logger {
 		case "console":
 			// console logger
+			var w io.Writer = os.Stderr
+			if logFile != nil {
+				w = logFile
+			}
 			r.logger = logger.NewSlogConsoleLogger(
+				w,
 				logLevel,
+				logFile != nil,
 			)
 		case "json":
 			// JSON logger
+			var w io.Writer = os.Stdout
+			if logFile != nil {
+				w = logFile
+			}
 			r.logger = logger.NewSlogJSONLogger(
+				w,
 				logLevel,
 				r.appCode.String(),
 				r.commitID,
@@ -621,6 +1474,8 @@ func (r *registry) newLogger() logger.Logger {
 			// default
 			r.logger = logger.NewNoopLogger()
 		}
+		// used by logger.FromContext() when context has no logger
+		logger.SetDefault(r.logger)
 		// r.logger.Info("Logger initialized", "logLevel", logLevel.String())
 	}
 	return r.logger
@@ -628,25 +1483,47 @@ func (r *registry) newLogger() logger.Logger {
 
 func (r *registry) newTracer(tracerName string) (tracer.Tracer, error) {
 	if r.tracer == nil {
-		var err error
-		serviceName := r.envConf.TracerServiceName
-		version := r.envConf.TracerVersion
-		sampler := sdktrace.AlwaysSample()
+		if r.envConf.Tracer == tracer.TracerJaegerDeprecated {
+			r.newLogger().Warn("TRACER=jaeger is deprecated, use jaeger_http instead")
+		}
 		tracerMode := tracer.ValidateTracerEnv(r.envConf.Tracer)
+		if tracerMode == tracer.TracerModeNOOP {
+			r.tracer = tracer.NewNoopProvider()
+			return r.tracer, nil
+		}
+
+		sampler, err := tracer.NewSampler(r.envConf.TracerSampler, r.envConf.TracerSampleRatio)
+		if err != nil {
+			return nil, err
+		}
+		res := &tracer.Resource{
+			ServiceName: r.envConf.TracerServiceName,
+			Version:     r.envConf.TracerVersion,
+			Attributes:  r.envConf.TracerResourceAttributes,
+		}
+		endpoint := r.envConf.TracerEndpoint
+		if endpoint == "" {
+			endpoint = tracerMode.DefaultEndpoint()
+		}
+		otlpConf := &tracer.OTLPConfig{
+			Endpoint: endpoint,
+			Headers:  r.envConf.TracerHeaders,
+			Insecure: r.envConf.TracerInsecure,
+			CAFile:   r.envConf.TracerCAFile,
+		}
 
 		switch tracerMode {
-		case tracer.TracerModeNOOP:
-			r.tracer = tracer.NewNoopProvider()
 		case tracer.TracerModeJaegerHTTP:
-			host := "localhost:4318"
-			r.tracer, err = tracer.NewJaegerHTTPProvider(host, serviceName, tracerName, version, sampler)
+			r.tracer, err = tracer.NewJaegerHTTPProvider(otlpConf, res, tracerName, sampler)
 		case tracer.TracerModeJaegerGRPC:
-			host := "localhost:4317"
-			r.tracer, err = tracer.NewJaegerGRPCProvider(host, serviceName, tracerName, version, sampler)
-		// case tracer.TracerModeDataDog:
-		// 	// datadog
-		// 	r.tracer, err = tracer.NewDatadogOtelProvider(tracerName, version, isDebug)
-		// 	r.tracer = tracer.NewDatadogTracer()
+			r.tracer, err = tracer.NewJaegerGRPCProvider(otlpConf, res, tracerName, sampler)
+		case tracer.TracerModeOTLPHTTP, tracer.TracerModeDataDog:
+			// Datadog Agent accepts spans by OTLP ingestion
+			r.tracer, err = tracer.NewOTLPHTTPProvider(otlpConf, res, tracerName, sampler)
+		case tracer.TracerModeOTLPGRPC:
+			r.tracer, err = tracer.NewOTLPGRPCProvider(otlpConf, res, tracerName, sampler)
+		case tracer.TracerModeStdout:
+			r.tracer, err = tracer.NewStdoutProvider(r.envConf.TracerFile, res, tracerName, sampler)
 		default:
 			err = errors.New("environment variable: Tracer is invalid")
 		}
@@ -657,6 +1534,59 @@ func (r *registry) newTracer(tracerName string) (tracer.Tracer, error) {
 	return r.tracer, nil
 }
 
+func (r *registry) newMetrics() metrics.Metrics {
+	if r.metrics == nil {
+		switch metrics.ValidateMetricsEnv(r.envConf.Metrics) {
+		case metrics.MetricsModePrometheus:
+			r.metrics = metrics.NewPrometheusProvider(r.envConf.MetricsPushURL, r.appCode.String())
+		default:
+			r.metrics = metrics.NewNoopProvider()
+		}
+	}
+	return r.metrics
+}
+
+func (r *registry) newProgressNotifier() progress.Notifier {
+	if r.progressNotifier == nil {
+		r.progressNotifier = progress.NewNotifier()
+	}
+	return r.progressNotifier
+}
+
+// progress bar is replaced with log lines when bookmarks are printed by --verbose
+func (r *registry) newProgressReporter(mode string, isVerbose bool) progress.Reporter {
+	reporterMode := progress.ValidateReporterMode(mode)
+	if reporterMode == progress.ReporterModeAuto && isVerbose {
+		reporterMode = progress.ReporterModeLog
+	}
+	return progress.NewReporter(r.newProgressNotifier(), reporterMode, os.Stdout)
+}
+
+// sinks are validated by config
+func (r *registry) newAlertSink() alert.Sink {
+	sinks := make([]alert.Sink, 0, len(r.envConf.AlertSinks))
+	for _, sink := range r.envConf.AlertSinks {
+		switch alert.ValidateSinkEnv(sink) {
+		case alert.SinkModeStdout:
+			sinks = append(sinks, alert.NewStdoutSink())
+		case alert.SinkModeWebhook:
+			sinks = append(sinks, alert.NewWebhookSink(r.envConf.AlertWebhookURL))
+		case alert.SinkModeSlack:
+			sinks = append(sinks, alert.NewSlackSink(r.envConf.AlertSlackWebhookURL))
+		case alert.SinkModeSMTP:
+			sinks = append(sinks, alert.NewSMTPSink(&alert.SMTPConfig{
+				Host:     r.envConf.AlertSMTPHost,
+				Port:     r.envConf.AlertSMTPPort,
+				Username: r.envConf.AlertSMTPUsername,
+				Password: r.envConf.AlertSMTPPassword,
+				From:     r.envConf.AlertSMTPFrom,
+				To:       r.envConf.AlertSMTPTo,
+			}))
+		}
+	}
+	return alert.NewMultiSink(r.newLogger(), sinks...)
+}
+
 func (r *registry) newPostgresClient() (*rdb.SqlcPostgresClient, error) {
 	if r.postgresClient == nil {
 		pgClient, err := rdb.NewSqlcPostgresClient(
@@ -686,7 +1616,10 @@ func (r *registry) newInfluxdbClient() (influxdb2.Client, error) {
 
 func (r *registry) newMongodbClient() (*mongo.Client, error) {
 	if r.mongodbClient == nil {
-		clientOptions := options.Client().ApplyURI(r.envConf.MongodbURL)
+		// span is created per command
+		clientOptions := options.Client().
+			ApplyURI(r.envConf.MongodbURL).
+			SetMonitor(otelmongo.NewMonitor())
 		client, err := mongo.Connect(context.Background(), clientOptions)
 		if err != nil {
 			return nil, err
@@ -698,21 +1631,21 @@ func (r *registry) newMongodbClient() (*mongo.Client, error) {
 
 func (r *registry) newBookmarkFetcher() fetcher.EntityJSONFetcher {
 	if r.entityJSONFetcher == nil {
-		r.entityJSONFetcher = fetcher.NewEntityJSONFetcher(r.newLogger())
+		r.entityJSONFetcher = fetcher.NewEntityJSONFetcher(r.newLogger(), r.newMetrics())
 	}
 	return r.entityJSONFetcher
 }
 
 func (r *registry) newUserBookmarkCountFetcher() fetcher.UserBookmarkCountFetcher {
 	if r.userBookmarkCountFetcher == nil {
-		r.userBookmarkCountFetcher = fetcher.NewUserBookmarkCountFetcher(r.newLogger())
+		r.userBookmarkCountFetcher = fetcher.NewUserBookmarkCountFetcher(r.newLogger(), r.newMetrics())
 	}
 	return r.userBookmarkCountFetcher
 }
 
 func (r *registry) newPageURLFetcher() fetcher.HatenaPageURLFetcher {
 	if r.pageURLFetcher == nil {
-		r.pageURLFetcher = fetcher.NewHatenaPageURLFetcher(r.newLogger())
+		r.pageURLFetcher = fetcher.NewHatenaPageURLFetcher(r.newLogger(), r.newMetrics())
 	}
 	return r.pageURLFetcher
 }
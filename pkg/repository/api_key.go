@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type APIKeyRepositorier interface {
+	Close(ctx context.Context)
+	InsertAPIKey(
+		ctx context.Context,
+		name, key string,
+		scope entities.APIKeyScope,
+		rateLimit, dailyQuota int,
+	) (int32, error)
+	GetAPIKeyByKey(ctx context.Context, key string) (*entities.APIKey, error)
+	GetAPIKeys(ctx context.Context, usageDate time.Time) ([]entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, apiKeyID int32) (bool, error)
+	IncrementAPIKeyUsage(ctx context.Context, apiKeyID int32, usageDate time.Time, dailyQuota int) (bool, error)
+}
+
+//
+// apiKeyRepository Implementation
+//
+
+type apiKeyRepository struct {
+	logger         logger.Logger
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewAPIKeyRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+) *apiKeyRepository {
+	return &apiKeyRepository{
+		logger:         logger,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (a *apiKeyRepository) Close(ctx context.Context) {
+	a.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (a *apiKeyRepository) InsertAPIKey(
+	ctx context.Context,
+	name, key string,
+	scope entities.APIKeyScope,
+	rateLimit, dailyQuota int,
+) (int32, error) {
+	return a.postgreQueries.InsertAPIKey(ctx, name, key, scope, rateLimit, dailyQuota)
+}
+
+func (a *apiKeyRepository) GetAPIKeyByKey(ctx context.Context, key string) (*entities.APIKey, error) {
+	return a.postgreQueries.GetAPIKeyByKey(ctx, key)
+}
+
+func (a *apiKeyRepository) GetAPIKeys(ctx context.Context, usageDate time.Time) ([]entities.APIKey, error) {
+	return a.postgreQueries.GetAPIKeys(ctx, usageDate)
+}
+
+func (a *apiKeyRepository) RevokeAPIKey(ctx context.Context, apiKeyID int32) (bool, error) {
+	return a.postgreQueries.RevokeAPIKey(ctx, apiKeyID)
+}
+
+func (a *apiKeyRepository) IncrementAPIKeyUsage(
+	ctx context.Context,
+	apiKeyID int32,
+	usageDate time.Time,
+	dailyQuota int,
+) (bool, error) {
+	return a.postgreQueries.IncrementAPIKeyUsage(ctx, apiKeyID, usageDate, dailyQuota)
+}
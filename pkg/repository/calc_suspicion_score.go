@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type SuspicionScoreRepositorier interface {
+	Close(ctx context.Context)
+	// PostgreSQL
+	GetAllURLs(ctx context.Context) ([]entities.URL, error)
+	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
+	GetUsersByURL(ctx context.Context, url string) ([]entities.RDBUser, error)
+	GetMaxSharedUserCount(ctx context.Context, urlID int32) (int, error)
+	UpsertURLScore(ctx context.Context, score *entities.SuspicionScore) error
+	// InfluxDB
+	ReadEntitySummaries(ctx context.Context, url string) ([]*entities.BookmarkSummary, error)
+}
+
+//
+// suspicionScoreRepository Implementation
+//
+
+type suspicionScoreRepository struct {
+	logger          logger.Logger
+	metrics         metrics.Metrics
+	postgreQueries  *rdb.PostgreQueries
+	influxDBQueries *influxdb.InfluxDBQueries
+}
+
+func NewSuspicionScoreRepository(
+	logger logger.Logger,
+	metrics metrics.Metrics,
+	postgreQueries *rdb.PostgreQueries,
+	influxDBQueries *influxdb.InfluxDBQueries,
+) *suspicionScoreRepository {
+	return &suspicionScoreRepository{
+		logger:          logger,
+		metrics:         metrics,
+		postgreQueries:  postgreQueries,
+		influxDBQueries: influxDBQueries,
+	}
+}
+
+func (s *suspicionScoreRepository) Close(ctx context.Context) {
+	s.postgreQueries.Close(ctx)
+	s.influxDBQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (s *suspicionScoreRepository) GetAllURLs(ctx context.Context) ([]entities.URL, error) {
+	return s.postgreQueries.GetAllURLs(ctx)
+}
+
+func (s *suspicionScoreRepository) GetURLsByURLAddresses(
+	ctx context.Context,
+	urls []string,
+) ([]entities.URL, error) {
+	return s.postgreQueries.GetURLsByURLAddresses(ctx, urls)
+}
+
+func (s *suspicionScoreRepository) GetUsersByURL(
+	ctx context.Context,
+	url string,
+) ([]entities.RDBUser, error) {
+	return s.postgreQueries.GetUsersByURL(ctx, url)
+}
+
+func (s *suspicionScoreRepository) GetMaxSharedUserCount(ctx context.Context, urlID int32) (int, error) {
+	return s.postgreQueries.GetMaxSharedUserCount(ctx, urlID)
+}
+
+func (s *suspicionScoreRepository) UpsertURLScore(
+	ctx context.Context,
+	score *entities.SuspicionScore,
+) error {
+	start := time.Now()
+	err := s.postgreQueries.UpsertURLScore(ctx, score)
+	observeWrite(s.metrics, metrics.StorePostgres, "upsert_url_score", start, err)
+	return err
+}
+
+// InfluxDB
+
+func (s *suspicionScoreRepository) ReadEntitySummaries(
+	ctx context.Context,
+	url string,
+) ([]*entities.BookmarkSummary, error) {
+	return s.influxDBQueries.ReadEntitySummaries(ctx, url)
+}
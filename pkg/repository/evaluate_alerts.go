@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type AlertRepositorier interface {
+	Close(ctx context.Context)
+	// PostgreSQL
+	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
+	GetUsersByURL(ctx context.Context, url string) ([]entities.RDBUser, error)
+	GetAlertedRules(ctx context.Context, urlID int32, since time.Time) ([]entities.AlertRule, error)
+	InsertAlert(ctx context.Context, alert *entities.Alert) error
+	// InfluxDB
+	ReadEntitySummaries(ctx context.Context, url string) ([]*entities.BookmarkSummary, error)
+}
+
+//
+// alertRepository Implementation
+//
+
+type alertRepository struct {
+	logger          logger.Logger
+	metrics         metrics.Metrics
+	postgreQueries  *rdb.PostgreQueries
+	influxDBQueries *influxdb.InfluxDBQueries
+}
+
+func NewAlertRepository(
+	logger logger.Logger,
+	metrics metrics.Metrics,
+	postgreQueries *rdb.PostgreQueries,
+	influxDBQueries *influxdb.InfluxDBQueries,
+) *alertRepository {
+	return &alertRepository{
+		logger:          logger,
+		metrics:         metrics,
+		postgreQueries:  postgreQueries,
+		influxDBQueries: influxDBQueries,
+	}
+}
+
+func (a *alertRepository) Close(ctx context.Context) {
+	a.postgreQueries.Close(ctx)
+	a.influxDBQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (a *alertRepository) GetURLsByURLAddresses(
+	ctx context.Context,
+	urls []string,
+) ([]entities.URL, error) {
+	return a.postgreQueries.GetURLsByURLAddresses(ctx, urls)
+}
+
+func (a *alertRepository) GetUsersByURL(
+	ctx context.Context,
+	url string,
+) ([]entities.RDBUser, error) {
+	return a.postgreQueries.GetUsersByURL(ctx, url)
+}
+
+func (a *alertRepository) GetAlertedRules(
+	ctx context.Context,
+	urlID int32,
+	since time.Time,
+) ([]entities.AlertRule, error) {
+	return a.postgreQueries.GetAlertedRules(ctx, urlID, since)
+}
+
+func (a *alertRepository) InsertAlert(ctx context.Context, alert *entities.Alert) error {
+	start := time.Now()
+	err := a.postgreQueries.InsertAlert(ctx, alert)
+	observeWrite(a.metrics, metrics.StorePostgres, "insert_alert", start, err)
+	return err
+}
+
+// InfluxDB
+
+func (a *alertRepository) ReadEntitySummaries(
+	ctx context.Context,
+	url string,
+) ([]*entities.BookmarkSummary, error) {
+	return a.influxDBQueries.ReadEntitySummaries(ctx, url)
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/mongodb"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
@@ -14,6 +16,9 @@ type FetchBookmarkRepositorier interface {
 	Close(ctx context.Context)
 	// PostgreSQL
 	GetAllURLs(ctx context.Context) ([]entities.URL, error)
+	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
+	GetURLsByFilter(ctx context.Context, filter *entities.URLFilter) ([]entities.URL, error)
+	GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error)
 	// GetURLID(ctx context.Context, url string) (int32, error)
 	// InsertURL(
 	// 	ctx context.Context,
@@ -36,8 +41,7 @@ type FetchBookmarkRepositorier interface {
 		privateUserRate float64,
 	) (int64, error)
 	// InsertUser(ctx context.Context, userName string) error
-	UpsertUser(ctx context.Context, userName string) (int32, error)
-	UpsertUserURLs(ctx context.Context, userID, urlID int32) error
+	BulkUpsertUserURLs(ctx context.Context, urlID int32, users []entities.BookmarkUser) error
 	// InfluxDB
 	ReadEntitySummary(ctx context.Context, url string) (*entities.BookmarkSummary, error)
 	WriteEntitySummary(ctx context.Context, url string, bookmark *entities.Bookmark) error
@@ -52,6 +56,7 @@ type FetchBookmarkRepositorier interface {
 
 type fetchBookmarkRepository struct {
 	logger          logger.Logger
+	metrics         metrics.Metrics
 	postgreQueries  *rdb.PostgreQueries
 	influxDBQueries *influxdb.InfluxDBQueries
 	mongoDBQueries  *mongodb.MongoDBQueries
@@ -59,12 +64,14 @@ type fetchBookmarkRepository struct {
 
 func NewFetchBookmarkRepository(
 	logger logger.Logger,
+	metrics metrics.Metrics,
 	postgreQueries *rdb.PostgreQueries,
 	influxDBQueries *influxdb.InfluxDBQueries,
 	mongoDBQueries *mongodb.MongoDBQueries,
 ) *fetchBookmarkRepository {
 	return &fetchBookmarkRepository{
 		logger:          logger,
+		metrics:         metrics,
 		postgreQueries:  postgreQueries,
 		influxDBQueries: influxDBQueries,
 		mongoDBQueries:  mongoDBQueries,
@@ -83,6 +90,24 @@ func (f *fetchBookmarkRepository) GetAllURLs(ctx context.Context) ([]entities.UR
 	return f.postgreQueries.GetAllURLs(ctx)
 }
 
+func (f *fetchBookmarkRepository) GetURLsByURLAddresses(
+	ctx context.Context,
+	urls []string,
+) ([]entities.URL, error) {
+	return f.postgreQueries.GetURLsByURLAddresses(ctx, urls)
+}
+
+func (f *fetchBookmarkRepository) GetURLsByFilter(
+	ctx context.Context,
+	filter *entities.URLFilter,
+) ([]entities.URL, error) {
+	return f.postgreQueries.GetURLsByFilter(ctx, filter)
+}
+
+func (f *fetchBookmarkRepository) GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error) {
+	return f.postgreQueries.GetWatchedURLs(ctx)
+}
+
 // func (f *fetchBookmarkRepository) GetURLID(ctx context.Context, url string) (int32, error) {
 // 	return f.postgreQueries.GetURLID(ctx, url)
 // }
@@ -103,7 +128,10 @@ func (f *fetchBookmarkRepository) UpsertURL(
 	bmCount, userCount int,
 	privateUserRate float64,
 ) (int32, error) {
-	return f.postgreQueries.UpsertURL(ctx, url, title, bmCount, userCount, privateUserRate)
+	start := time.Now()
+	urlID, err := f.postgreQueries.UpsertURL(ctx, url, title, bmCount, userCount, privateUserRate)
+	observeWrite(f.metrics, metrics.StorePostgres, "upsert_url", start, err)
+	return urlID, err
 }
 
 func (f *fetchBookmarkRepository) UpdateURL(
@@ -113,19 +141,25 @@ func (f *fetchBookmarkRepository) UpdateURL(
 	bmCount, userCount int,
 	privateUserRate float64,
 ) (int64, error) {
-	return f.postgreQueries.UpdateURL(ctx, urlID, title, bmCount, userCount, privateUserRate)
+	start := time.Now()
+	rows, err := f.postgreQueries.UpdateURL(ctx, urlID, title, bmCount, userCount, privateUserRate)
+	observeWrite(f.metrics, metrics.StorePostgres, "update_url", start, err)
+	return rows, err
 }
 
 // func (b *bookmarkRepository) InsertUser(ctx context.Context, userName string) error {
 // 	return b.postgreQueries.InsertUser(ctx, userName)
 // }
 
-func (f *fetchBookmarkRepository) UpsertUser(ctx context.Context, userName string) (int32, error) {
-	return f.postgreQueries.UpsertUser(ctx, userName)
-}
-
-func (f *fetchBookmarkRepository) UpsertUserURLs(ctx context.Context, userID, urlID int32) error {
-	return f.postgreQueries.UpsertUserURLs(ctx, userID, urlID)
+func (f *fetchBookmarkRepository) BulkUpsertUserURLs(
+	ctx context.Context,
+	urlID int32,
+	users []entities.BookmarkUser,
+) error {
+	start := time.Now()
+	err := f.postgreQueries.BulkUpsertUserURLs(ctx, urlID, users)
+	observeWrite(f.metrics, metrics.StorePostgres, "bulk_upsert_user_urls", start, err)
+	return err
 }
 
 // InfluxDB
@@ -142,7 +176,10 @@ func (f *fetchBookmarkRepository) WriteEntitySummary(
 	url string,
 	bookmark *entities.Bookmark,
 ) error {
-	return f.influxDBQueries.WriteEntitySummary(ctx, url, bookmark)
+	start := time.Now()
+	err := f.influxDBQueries.WriteEntitySummary(ctx, url, bookmark)
+	observeWrite(f.metrics, metrics.StoreInfluxDB, "write_entity_summary", start, err)
+	return err
 }
 
 // MongoDB
@@ -156,5 +193,8 @@ func (f *fetchBookmarkRepository) WriteEntity(
 	url string,
 	bookmark *entities.Bookmark,
 ) error {
-	return f.mongoDBQueries.WriteEntity(ctx, url, bookmark)
+	start := time.Now()
+	err := f.mongoDBQueries.WriteEntity(ctx, url, bookmark)
+	observeWrite(f.metrics, metrics.StoreMongoDB, "write_entity", start, err)
+	return err
 }
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type FetchRunRepositorier interface {
+	Close(ctx context.Context)
+	CreateFetchRun(ctx context.Context, command entities.RunCommand, items []string) (int32, error)
+	GetFetchRun(ctx context.Context, runID int32) (*entities.Run, error)
+	GetFetchRuns(ctx context.Context, limit int) ([]entities.Run, error)
+	GetUnfinishedFetchRunItems(ctx context.Context, runID int32) ([]string, error)
+	UpdateFetchRunItemStatus(ctx context.Context, runID int32, item string, status entities.RunItemStatus) error
+	UpdateFetchRunStatus(ctx context.Context, runID int32, status entities.RunStatus) error
+}
+
+//
+// fetchRunRepository Implementation
+//
+
+type fetchRunRepository struct {
+	logger         logger.Logger
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewFetchRunRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+) *fetchRunRepository {
+	return &fetchRunRepository{
+		logger:         logger,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (f *fetchRunRepository) Close(ctx context.Context) {
+	f.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (f *fetchRunRepository) CreateFetchRun(
+	ctx context.Context,
+	command entities.RunCommand,
+	items []string,
+) (int32, error) {
+	return f.postgreQueries.CreateFetchRun(ctx, command, items)
+}
+
+func (f *fetchRunRepository) GetFetchRun(ctx context.Context, runID int32) (*entities.Run, error) {
+	return f.postgreQueries.GetFetchRun(ctx, runID)
+}
+
+func (f *fetchRunRepository) GetFetchRuns(ctx context.Context, limit int) ([]entities.Run, error) {
+	return f.postgreQueries.GetFetchRuns(ctx, limit)
+}
+
+func (f *fetchRunRepository) GetUnfinishedFetchRunItems(ctx context.Context, runID int32) ([]string, error) {
+	return f.postgreQueries.GetUnfinishedFetchRunItems(ctx, runID)
+}
+
+func (f *fetchRunRepository) UpdateFetchRunItemStatus(
+	ctx context.Context,
+	runID int32,
+	item string,
+	status entities.RunItemStatus,
+) error {
+	return f.postgreQueries.UpdateFetchRunItemStatus(ctx, runID, item, status)
+}
+
+func (f *fetchRunRepository) UpdateFetchRunStatus(
+	ctx context.Context,
+	runID int32,
+	status entities.RunStatus,
+) error {
+	return f.postgreQueries.UpdateFetchRunStatus(ctx, runID, status)
+}
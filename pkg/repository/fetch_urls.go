@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
 )
 
@@ -21,15 +23,18 @@ type FetchURLRepositorier interface {
 
 type fetchURLRepository struct {
 	logger         logger.Logger
+	metrics        metrics.Metrics
 	postgreQueries *rdb.PostgreQueries
 }
 
 func NewFetchURLRepository(
 	logger logger.Logger,
+	metrics metrics.Metrics,
 	postgreQueries *rdb.PostgreQueries,
 ) *fetchURLRepository {
 	return &fetchURLRepository{
 		logger:         logger,
+		metrics:        metrics,
 		postgreQueries: postgreQueries,
 	}
 }
@@ -53,5 +58,8 @@ func (f *fetchURLRepository) CallBulkInsertURLs(
 	categories []entities.CategoryCode,
 	isAlls []bool,
 ) error {
-	return f.postgreQueries.CallBulkInsertURLs(ctx, urls, categories, isAlls)
+	start := time.Now()
+	err := f.postgreQueries.CallBulkInsertURLs(ctx, urls, categories, isAlls)
+	observeWrite(f.metrics, metrics.StorePostgres, "bulk_insert_urls", start, err)
+	return err
 }
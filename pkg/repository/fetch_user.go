@@ -2,29 +2,36 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
 )
 
 type FetchUserRepositorier interface {
 	Close(ctx context.Context) error
 	GetUserNames(ctx context.Context) ([]string, error)
+	GetStaleUserNames(ctx context.Context, staleBefore, recentSince time.Time, maxUsers int) ([]string, error)
 	GetUserNamesByURLS(ctx context.Context, urls []string) ([]string, error)
 	UpdateUserBookmarkCount(ctx context.Context, userName string, count int) error
+	UpdateUserDeleted(ctx context.Context, userName string) error
 }
 
 type fetchUserRepository struct {
 	logger         logger.Logger
+	metrics        metrics.Metrics
 	postgreQueries *rdb.PostgreQueries
 }
 
 func NewFetchUserRepository(
 	logger logger.Logger,
+	metrics metrics.Metrics,
 	postgreQueries *rdb.PostgreQueries,
 ) *fetchUserRepository {
 	return &fetchUserRepository{
 		logger:         logger,
+		metrics:        metrics,
 		postgreQueries: postgreQueries,
 	}
 }
@@ -37,10 +44,28 @@ func (f *fetchUserRepository) GetUserNames(ctx context.Context) ([]string, error
 	return f.postgreQueries.GetUserNames(ctx)
 }
 
+func (f *fetchUserRepository) GetStaleUserNames(
+	ctx context.Context,
+	staleBefore, recentSince time.Time,
+	maxUsers int,
+) ([]string, error) {
+	return f.postgreQueries.GetStaleUserNames(ctx, staleBefore, recentSince, maxUsers)
+}
+
 func (f *fetchUserRepository) GetUserNamesByURLS(ctx context.Context, urls []string) ([]string, error) {
 	return f.postgreQueries.GetUserNamesByURLS(ctx, urls)
 }
 
 func (f *fetchUserRepository) UpdateUserBookmarkCount(ctx context.Context, userName string, count int) error {
-	return f.postgreQueries.UpdateUserBookmarkCount(ctx, userName, count)
+	start := time.Now()
+	err := f.postgreQueries.UpdateUserBookmarkCount(ctx, userName, count)
+	observeWrite(f.metrics, metrics.StorePostgres, "update_user_bookmark_count", start, err)
+	return err
+}
+
+func (f *fetchUserRepository) UpdateUserDeleted(ctx context.Context, userName string) error {
+	start := time.Now()
+	err := f.postgreQueries.UpdateUserDeleted(ctx, userName)
+	observeWrite(f.metrics, metrics.StorePostgres, "update_user_deleted", start, err)
+	return err
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/mongodb"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type MergeURLRepositorier interface {
+	Close(ctx context.Context)
+	// PostgreSQL
+	GetAllURLAddresses(ctx context.Context) ([]entities.URL, error)
+	MergeDuplicateURLs(ctx context.Context, duplicateURLs *entities.DuplicateURLs) error
+	// InfluxDB
+	MoveEntitySummaries(ctx context.Context, from, to string) (int, error)
+	// MongoDB
+	MoveEntity(ctx context.Context, from, to string) error
+}
+
+//
+// mergeURLRepository Implementation
+//
+
+type mergeURLRepository struct {
+	logger          logger.Logger
+	postgreQueries  *rdb.PostgreQueries
+	influxDBQueries *influxdb.InfluxDBQueries
+	mongoDBQueries  *mongodb.MongoDBQueries
+}
+
+func NewMergeURLRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+	influxDBQueries *influxdb.InfluxDBQueries,
+	mongoDBQueries *mongodb.MongoDBQueries,
+) *mergeURLRepository {
+	return &mergeURLRepository{
+		logger:          logger,
+		postgreQueries:  postgreQueries,
+		influxDBQueries: influxDBQueries,
+		mongoDBQueries:  mongoDBQueries,
+	}
+}
+
+func (m *mergeURLRepository) Close(ctx context.Context) {
+	m.postgreQueries.Close(ctx)
+	m.influxDBQueries.Close(ctx)
+	m.mongoDBQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (m *mergeURLRepository) GetAllURLAddresses(ctx context.Context) ([]entities.URL, error) {
+	return m.postgreQueries.GetAllURLAddresses(ctx)
+}
+
+func (m *mergeURLRepository) MergeDuplicateURLs(
+	ctx context.Context,
+	duplicateURLs *entities.DuplicateURLs,
+) error {
+	return m.postgreQueries.MergeDuplicateURLs(ctx, duplicateURLs)
+}
+
+// InfluxDB
+
+func (m *mergeURLRepository) MoveEntitySummaries(ctx context.Context, from, to string) (int, error) {
+	return m.influxDBQueries.MoveEntitySummaries(ctx, from, to)
+}
+
+// MongoDB
+
+func (m *mergeURLRepository) MoveEntity(ctx context.Context, from, to string) error {
+	return m.mongoDBQueries.MoveEntity(ctx, from, to)
+}
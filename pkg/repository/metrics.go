@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+)
+
+// observeWrite observes latency and error of write to store since start
+func observeWrite(m metrics.Metrics, store, operation string, start time.Time, err error) {
+	m.ObserveDBWrite(store, operation, time.Since(start), err)
+}
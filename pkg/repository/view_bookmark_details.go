@@ -12,7 +12,9 @@ type BookmarkDetailsRepositorier interface {
 	Close(ctx context.Context)
 	GetAllURLs(ctx context.Context) ([]entities.URL, error)
 	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
+	GetURLsByFilter(ctx context.Context, filter *entities.URLFilter) ([]entities.URL, error)
 	GetUsersByURL(ctx context.Context, url string) ([]entities.RDBUser, error)
+	GetUserBookmarkCountsByCategory(ctx context.Context, categoryCode entities.CategoryCode) ([]int, error)
 }
 
 //
@@ -44,6 +46,13 @@ func (b *bookmarkDetailsRepository) GetAllURLs(ctx context.Context) ([]entities.
 	return b.postgreQueries.GetAllURLs(ctx)
 }
 
+func (b *bookmarkDetailsRepository) GetURLsByFilter(
+	ctx context.Context,
+	filter *entities.URLFilter,
+) ([]entities.URL, error) {
+	return b.postgreQueries.GetURLsByFilter(ctx, filter)
+}
+
 func (b *bookmarkDetailsRepository) GetUsersByURL(
 	ctx context.Context,
 	url string,
@@ -57,3 +66,10 @@ func (b *bookmarkDetailsRepository) GetURLsByURLAddresses(
 ) ([]entities.URL, error) {
 	return b.postgreQueries.GetURLsByURLAddresses(ctx, urls)
 }
+
+func (b *bookmarkDetailsRepository) GetUserBookmarkCountsByCategory(
+	ctx context.Context,
+	categoryCode entities.CategoryCode,
+) ([]int, error) {
+	return b.postgreQueries.GetUserBookmarkCountsByCategory(ctx, categoryCode)
+}
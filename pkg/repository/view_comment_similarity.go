@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type CommentSimilarityRepositorier interface {
+	Close(ctx context.Context)
+	GetAllComments(ctx context.Context) ([]entities.Comment, error)
+	GetCommentsByURLs(ctx context.Context, urls []string) ([]entities.Comment, error)
+}
+
+//
+// commentSimilarityRepository Implementation
+//
+
+type commentSimilarityRepository struct {
+	logger         logger.Logger
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewCommentSimilarityRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+) *commentSimilarityRepository {
+	return &commentSimilarityRepository{
+		logger:         logger,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (c *commentSimilarityRepository) Close(ctx context.Context) {
+	c.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (c *commentSimilarityRepository) GetAllComments(ctx context.Context) ([]entities.Comment, error) {
+	return c.postgreQueries.GetAllComments(ctx)
+}
+
+func (c *commentSimilarityRepository) GetCommentsByURLs(
+	ctx context.Context,
+	urls []string,
+) ([]entities.Comment, error) {
+	return c.postgreQueries.GetCommentsByURLs(ctx, urls)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
@@ -14,6 +15,9 @@ type SummaryRepositorier interface {
 	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
 	// GetUsersByURL(ctx context.Context, url string) ([]entities.RDBUser, error)
 	GetAveragePrivateUserRates(ctx context.Context) ([]entities.AveragePrivateUserRate, error)
+	GetAllURLScores(ctx context.Context) ([]entities.SuspicionScore, error)
+	GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error)
+	GetWatchedUserNewBookmarks(ctx context.Context, since time.Time) ([]entities.WatchedUserBookmark, error)
 }
 
 //
@@ -61,3 +65,18 @@ func (s *summaryRepository) GetAveragePrivateUserRates(
 ) ([]entities.AveragePrivateUserRate, error) {
 	return s.postgreQueries.GetAveragePrivateUserRates(ctx)
 }
+
+func (s *summaryRepository) GetAllURLScores(ctx context.Context) ([]entities.SuspicionScore, error) {
+	return s.postgreQueries.GetAllURLScores(ctx)
+}
+
+func (s *summaryRepository) GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error) {
+	return s.postgreQueries.GetWatchedURLs(ctx)
+}
+
+func (s *summaryRepository) GetWatchedUserNewBookmarks(
+	ctx context.Context,
+	since time.Time,
+) ([]entities.WatchedUserBookmark, error) {
+	return s.postgreQueries.GetWatchedUserNewBookmarks(ctx, since)
+}
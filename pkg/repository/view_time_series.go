@@ -6,10 +6,15 @@ import (
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
 )
 
 type TimeSeriesRepositorier interface {
 	Close(ctx context.Context)
+	// PostgreSQL
+	GetURLsByURLAddresses(ctx context.Context, urls []string) ([]entities.URL, error)
+	GetURLsByFilter(ctx context.Context, filter *entities.URLFilter) ([]entities.URL, error)
+	// InfluxDB
 	ReadEntitySummaries(ctx context.Context, url string) ([]*entities.BookmarkSummary, error)
 }
 
@@ -19,23 +24,43 @@ type TimeSeriesRepositorier interface {
 
 type timeSeriesRepository struct {
 	logger          logger.Logger
+	postgreQueries  *rdb.PostgreQueries
 	influxDBQueries *influxdb.InfluxDBQueries
 }
 
 func NewTimeSeriesRepository(
 	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
 	influxDBQueries *influxdb.InfluxDBQueries,
 ) *timeSeriesRepository {
 	return &timeSeriesRepository{
 		logger:          logger,
+		postgreQueries:  postgreQueries,
 		influxDBQueries: influxDBQueries,
 	}
 }
 
 func (s *timeSeriesRepository) Close(ctx context.Context) {
+	s.postgreQueries.Close(ctx)
 	s.influxDBQueries.Close(ctx)
 }
 
+// PostgreSQL
+
+func (s *timeSeriesRepository) GetURLsByURLAddresses(
+	ctx context.Context,
+	urls []string,
+) ([]entities.URL, error) {
+	return s.postgreQueries.GetURLsByURLAddresses(ctx, urls)
+}
+
+func (s *timeSeriesRepository) GetURLsByFilter(
+	ctx context.Context,
+	filter *entities.URLFilter,
+) ([]entities.URL, error) {
+	return s.postgreQueries.GetURLsByFilter(ctx, filter)
+}
+
 // InfluxDB
 
 func (s *timeSeriesRepository) ReadEntitySummaries(
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type UserProfileRepositorier interface {
+	Close(ctx context.Context)
+	GetUserByName(ctx context.Context, userName string) (*entities.UserProfile, error)
+	GetUserBookmarks(ctx context.Context, userName string) ([]entities.UserBookmark, error)
+	GetUserBookmarkCountHistory(ctx context.Context, userName string) ([]entities.BookmarkCountPoint, error)
+	GetUsersSharingSuspiciousURLs(
+		ctx context.Context,
+		userName string,
+		minScore float64,
+		maxUsers int,
+	) ([]entities.SharedUser, error)
+}
+
+//
+// userProfileRepository Implementation
+//
+
+type userProfileRepository struct {
+	logger         logger.Logger
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewUserProfileRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+) *userProfileRepository {
+	return &userProfileRepository{
+		logger:         logger,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (u *userProfileRepository) Close(ctx context.Context) {
+	u.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (u *userProfileRepository) GetUserByName(
+	ctx context.Context,
+	userName string,
+) (*entities.UserProfile, error) {
+	return u.postgreQueries.GetUserByName(ctx, userName)
+}
+
+func (u *userProfileRepository) GetUserBookmarks(
+	ctx context.Context,
+	userName string,
+) ([]entities.UserBookmark, error) {
+	return u.postgreQueries.GetUserBookmarks(ctx, userName)
+}
+
+func (u *userProfileRepository) GetUserBookmarkCountHistory(
+	ctx context.Context,
+	userName string,
+) ([]entities.BookmarkCountPoint, error) {
+	return u.postgreQueries.GetUserBookmarkCountHistory(ctx, userName)
+}
+
+func (u *userProfileRepository) GetUsersSharingSuspiciousURLs(
+	ctx context.Context,
+	userName string,
+	minScore float64,
+	maxUsers int,
+) ([]entities.SharedUser, error) {
+	return u.postgreQueries.GetUsersSharingSuspiciousURLs(ctx, userName, minScore, maxUsers)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type UserGrowthRepositorier interface {
+	Close(ctx context.Context)
+	GetUserGrowths(ctx context.Context, since time.Time, maxUsers int) ([]entities.UserGrowth, error)
+}
+
+//
+// userGrowthRepository Implementation
+//
+
+type userGrowthRepository struct {
+	logger         logger.Logger
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewUserGrowthRepository(
+	logger logger.Logger,
+	postgreQueries *rdb.PostgreQueries,
+) *userGrowthRepository {
+	return &userGrowthRepository{
+		logger:         logger,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (u *userGrowthRepository) Close(ctx context.Context) {
+	u.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (u *userGrowthRepository) GetUserGrowths(
+	ctx context.Context,
+	since time.Time,
+	maxUsers int,
+) ([]entities.UserGrowth, error) {
+	return u.postgreQueries.GetUserGrowths(ctx, since, maxUsers)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+type WatchRepositorier interface {
+	Close(ctx context.Context)
+	InsertWatchedURL(ctx context.Context, url, note string) (bool, error)
+	DeleteWatchedURL(ctx context.Context, url string) (bool, error)
+	GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error)
+	InsertWatchedUser(ctx context.Context, userName, note string) (bool, error)
+	DeleteWatchedUser(ctx context.Context, userName string) (bool, error)
+	GetWatchedUsers(ctx context.Context) ([]entities.WatchedUser, error)
+	GetWatchedUserNewBookmarks(ctx context.Context, since time.Time) ([]entities.WatchedUserBookmark, error)
+}
+
+//
+// watchRepository Implementation
+//
+
+type watchRepository struct {
+	logger         logger.Logger
+	metrics        metrics.Metrics
+	postgreQueries *rdb.PostgreQueries
+}
+
+func NewWatchRepository(
+	logger logger.Logger,
+	metrics metrics.Metrics,
+	postgreQueries *rdb.PostgreQueries,
+) *watchRepository {
+	return &watchRepository{
+		logger:         logger,
+		metrics:        metrics,
+		postgreQueries: postgreQueries,
+	}
+}
+
+func (w *watchRepository) Close(ctx context.Context) {
+	w.postgreQueries.Close(ctx)
+}
+
+// PostgreSQL
+
+func (w *watchRepository) InsertWatchedURL(ctx context.Context, url, note string) (bool, error) {
+	start := time.Now()
+	inserted, err := w.postgreQueries.InsertWatchedURL(ctx, url, note)
+	observeWrite(w.metrics, metrics.StorePostgres, "insert_watched_url", start, err)
+	return inserted, err
+}
+
+func (w *watchRepository) DeleteWatchedURL(ctx context.Context, url string) (bool, error) {
+	start := time.Now()
+	deleted, err := w.postgreQueries.DeleteWatchedURL(ctx, url)
+	observeWrite(w.metrics, metrics.StorePostgres, "delete_watched_url", start, err)
+	return deleted, err
+}
+
+func (w *watchRepository) GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error) {
+	return w.postgreQueries.GetWatchedURLs(ctx)
+}
+
+func (w *watchRepository) InsertWatchedUser(ctx context.Context, userName, note string) (bool, error) {
+	start := time.Now()
+	inserted, err := w.postgreQueries.InsertWatchedUser(ctx, userName, note)
+	observeWrite(w.metrics, metrics.StorePostgres, "insert_watched_user", start, err)
+	return inserted, err
+}
+
+func (w *watchRepository) DeleteWatchedUser(ctx context.Context, userName string) (bool, error) {
+	start := time.Now()
+	deleted, err := w.postgreQueries.DeleteWatchedUser(ctx, userName)
+	observeWrite(w.metrics, metrics.StorePostgres, "delete_watched_user", start, err)
+	return deleted, err
+}
+
+func (w *watchRepository) GetWatchedUsers(ctx context.Context) ([]entities.WatchedUser, error) {
+	return w.postgreQueries.GetWatchedUsers(ctx)
+}
+
+func (w *watchRepository) GetWatchedUserNewBookmarks(
+	ctx context.Context,
+	since time.Time,
+) ([]entities.WatchedUserBookmark, error) {
+	return w.postgreQueries.GetWatchedUserNewBookmarks(ctx, since)
+}
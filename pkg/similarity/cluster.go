@@ -0,0 +1,179 @@
+package similarity
+
+import (
+	"errors"
+	"sort"
+)
+
+//
+// Clustering by MinHash LSH
+//
+
+const (
+	defaultShingleSize = 3
+	defaultBands       = 32
+	defaultRows        = 4 // numHash = bands * rows
+)
+
+// Cluster has indexes of given texts
+type Cluster struct {
+	Indexes    []int
+	Similarity float64 // minimum similarity of linked pairs
+}
+
+type Clusterer struct {
+	minHasher   *MinHasher
+	shingleSize int
+	bands       int
+	rows        int
+	threshold   float64
+}
+
+func NewClusterer(threshold float64) (*Clusterer, error) {
+	// validation
+	if threshold <= 0 || threshold > 1 {
+		return nil, errors.New("threshold must be in range (0, 1]")
+	}
+
+	return &Clusterer{
+		minHasher:   NewMinHasher(defaultBands * defaultRows),
+		shingleSize: defaultShingleSize,
+		bands:       defaultBands,
+		rows:        defaultRows,
+		threshold:   threshold,
+	}, nil
+}
+
+// Cluster groups similar texts. clusters with only one text are not returned
+func (c *Clusterer) Cluster(texts []string) []Cluster {
+	signatures := make([][]uint64, len(texts))
+	for i, text := range texts {
+		normalized := Normalize(text)
+		if normalized == "" {
+			continue
+		}
+		signatures[i] = c.minHasher.Signature(Shingles(normalized, c.shingleSize))
+	}
+
+	// LSH: texts sharing same band are candidates
+	buckets := make(map[bandKey][]int)
+	for i, signature := range signatures {
+		if signature == nil {
+			continue
+		}
+		for band := range c.bands {
+			key := bandKey{band: band, hash: hashBand(signature[band*c.rows : (band+1)*c.rows])}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	// verify candidates and link them
+	uf := newUnionFind(len(texts))
+	checked := make(map[[2]int]struct{})
+	for _, indexes := range buckets {
+		for x := range indexes {
+			for y := x + 1; y < len(indexes); y++ {
+				pair := [2]int{indexes[x], indexes[y]}
+				if _, ok := checked[pair]; ok {
+					continue
+				}
+				sim := EstimateJaccard(signatures[pair[0]], signatures[pair[1]])
+				checked[pair] = struct{}{}
+				if sim >= c.threshold {
+					uf.union(pair[0], pair[1], sim)
+				}
+			}
+		}
+	}
+
+	// collect clusters
+	groups := make(map[int]*Cluster)
+	for i, signature := range signatures {
+		if signature == nil {
+			continue
+		}
+		root := uf.find(i)
+		if uf.size[root] < 2 {
+			continue
+		}
+		cluster, ok := groups[root]
+		if !ok {
+			cluster = &Cluster{Similarity: uf.similarity[root]}
+			groups[root] = cluster
+		}
+		cluster.Indexes = append(cluster.Indexes, i)
+	}
+
+	clusters := make([]Cluster, 0, len(groups))
+	for _, cluster := range groups {
+		clusters = append(clusters, *cluster)
+	}
+	// larger cluster first
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Indexes) != len(clusters[j].Indexes) {
+			return len(clusters[i].Indexes) > len(clusters[j].Indexes)
+		}
+		return clusters[i].Indexes[0] < clusters[j].Indexes[0]
+	})
+	return clusters
+}
+
+type bandKey struct {
+	band int
+	hash uint64
+}
+
+func hashBand(values []uint64) uint64 {
+	h := uint64(0xcbf29ce484222325)
+	for _, v := range values {
+		h = splitmix64(h ^ v)
+	}
+	return h
+}
+
+//
+// union find
+//
+
+type unionFind struct {
+	parent     []int
+	size       []int
+	similarity []float64
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{
+		parent:     make([]int, n),
+		size:       make([]int, n),
+		similarity: make([]float64, n),
+	}
+	for i := range n {
+		uf.parent[i] = i
+		uf.size[i] = 1
+		uf.similarity[i] = 1
+	}
+	return uf
+}
+
+func (u *unionFind) find(x int) int {
+	for u.parent[x] != x {
+		u.parent[x] = u.parent[u.parent[x]]
+		x = u.parent[x]
+	}
+	return x
+}
+
+func (u *unionFind) union(x, y int, sim float64) {
+	rootX, rootY := u.find(x), u.find(y)
+	minSim := min(u.similarity[rootX], u.similarity[rootY], sim)
+	if rootX == rootY {
+		u.similarity[rootX] = minSim
+		return
+	}
+	if u.size[rootX] < u.size[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	u.parent[rootY] = rootX
+	u.size[rootX] += u.size[rootY]
+	u.similarity[rootX] = minSim
+}
@@ -0,0 +1,105 @@
+package similarity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewClusterer(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		wantErr   bool
+	}{
+		{name: "zero", threshold: 0, wantErr: true},
+		{name: "negative", threshold: -0.5, wantErr: true},
+		{name: "greater than one", threshold: 1.1, wantErr: true},
+		{name: "small", threshold: 0.01, wantErr: false},
+		{name: "one", threshold: 1, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClusterer(tt.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClusterer(%v) error = %v, wantErr %v", tt.threshold, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClustererCluster(t *testing.T) {
+	templated := "この記事はとても参考になりました。ありがとうございます"
+	tests := []struct {
+		name      string
+		threshold float64
+		texts     []string
+		want      [][]int
+	}{
+		{
+			name:      "no texts",
+			threshold: 0.8,
+			texts:     nil,
+			want:      [][]int{},
+		},
+		{
+			name:      "identical texts ignoring symbols, case and width",
+			threshold: 1,
+			texts:     []string{"Great Article!!", "great article", "ＧＲＥＡＴ　ＡＲＴＩＣＬＥ", "totally different comment"},
+			want:      [][]int{{0, 1, 2}},
+		},
+		{
+			name:      "templated comments with small edits",
+			threshold: 0.7,
+			texts: []string{
+				templated,
+				templated + "！",
+				"この記事はとても参考になりました。ありがとうございます https://example.com",
+				"釣りタイトルすぎて読む気がしない",
+			},
+			want: [][]int{{0, 1, 2}},
+		},
+		{
+			name:      "similar pair below threshold",
+			threshold: 0.9,
+			texts:     []string{templated, "この記事はとても参考になりました"},
+			want:      [][]int{},
+		},
+		{
+			name:      "similar pair above threshold",
+			threshold: 0.3,
+			texts:     []string{templated, "この記事はとても参考になりました"},
+			want:      [][]int{{0, 1}},
+		},
+		{
+			name:      "empty texts after normalization are not clustered",
+			threshold: 0.8,
+			texts:     []string{"", "!!!", "", "https://example.com"},
+			want:      [][]int{},
+		},
+		{
+			name:      "larger cluster first",
+			threshold: 0.8,
+			texts:     []string{"short template", "long template text", "short template", "long template text", "long template text"},
+			want:      [][]int{{1, 3, 4}, {0, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterer, err := NewClusterer(tt.threshold)
+			if err != nil {
+				t.Fatal(err)
+			}
+			clusters := clusterer.Cluster(tt.texts)
+			got := make([][]int, 0, len(clusters))
+			for _, cluster := range clusters {
+				if cluster.Similarity < tt.threshold {
+					t.Errorf("similarity of cluster %v = %v, want >= %v", cluster.Indexes, cluster.Similarity, tt.threshold)
+				}
+				got = append(got, cluster.Indexes)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Text normalization and MinHash signature
+//
+
+var urlPattern = regexp.MustCompile(`https?://\S+`)
+
+// Normalize text to compare templated comments
+// - NFKC normalization (full-width to half-width)
+// - lower case
+// - remove URLs, punctuation, symbols and spaces
+func Normalize(text string) string {
+	text = norm.NFKC.String(text)
+	text = strings.ToLower(text)
+	text = urlPattern.ReplaceAllString(text, "")
+
+	var builder strings.Builder
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// Shingles returns set of character k-grams
+// character based shingle is used because Japanese text has no word boundary
+func Shingles(text string, k int) map[string]struct{} {
+	shingles := make(map[string]struct{})
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return shingles
+	}
+	if len(runes) <= k {
+		shingles[string(runes)] = struct{}{}
+		return shingles
+	}
+	for i := 0; i+k <= len(runes); i++ {
+		shingles[string(runes[i:i+k])] = struct{}{}
+	}
+	return shingles
+}
+
+// Jaccard returns exact jaccard similarity of two shingle sets
+func Jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	var intersection int
+	for s := range a {
+		if _, ok := b[s]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+type MinHasher struct {
+	seeds []uint64
+}
+
+func NewMinHasher(numHash int) *MinHasher {
+	seeds := make([]uint64, numHash)
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		state = splitmix64(state)
+		seeds[i] = state
+	}
+	return &MinHasher{seeds: seeds}
+}
+
+// Signature returns MinHash signature of shingle set
+func (m *MinHasher) Signature(shingles map[string]struct{}) []uint64 {
+	signature := make([]uint64, len(m.seeds))
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for shingle := range shingles {
+		h := hashString(shingle)
+		for i, seed := range m.seeds {
+			if v := splitmix64(h ^ seed); v < signature[i] {
+				signature[i] = v
+			}
+		}
+	}
+	return signature
+}
+
+// EstimateJaccard returns estimated jaccard similarity from two signatures
+func EstimateJaccard(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var same int
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	//nolint:errcheck
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,127 @@
+package similarity
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "empty", text: "", want: ""},
+		{name: "lower case", text: "Hello World", want: "helloworld"},
+		{name: "full-width to half-width", text: "ＡＢＣ１２３", want: "abc123"},
+		{name: "remove punctuation and symbols", text: "これは、すごい！！ (笑)", want: "これはすごい笑"},
+		{name: "remove url", text: "see https://example.com/a?b=c here", want: "seehere"},
+		{name: "only symbols", text: "!!! ??? ...", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.text); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		k    int
+		want []string
+	}{
+		{name: "empty", text: "", k: 3, want: []string{}},
+		{name: "shorter than k", text: "ab", k: 3, want: []string{"ab"}},
+		{name: "same length as k", text: "abc", k: 3, want: []string{"abc"}},
+		{name: "k-grams", text: "abcde", k: 3, want: []string{"abc", "bcd", "cde"}},
+		{name: "duplicated k-grams", text: "aaaa", k: 2, want: []string{"aa"}},
+		{name: "multibyte", text: "すごいね", k: 3, want: []string{"すごい", "ごいね"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Shingles(tt.text, tt.k)
+			if want := toSet(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Shingles(%q, %d) = %v, want %v", tt.text, tt.k, got, want)
+			}
+		})
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want float64
+	}{
+		{name: "both empty", a: nil, b: nil, want: 1},
+		{name: "one empty", a: []string{"abc"}, b: nil, want: 0},
+		{name: "same", a: []string{"abc", "bcd"}, b: []string{"abc", "bcd"}, want: 1},
+		{name: "disjoint", a: []string{"abc"}, b: []string{"xyz"}, want: 0},
+		{name: "half", a: []string{"abc", "bcd", "cde"}, b: []string{"bcd", "cde", "def"}, want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Jaccard(toSet(tt.a), toSet(tt.b)); got != tt.want {
+				t.Errorf("Jaccard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateJaccard(t *testing.T) {
+	minHasher := NewMinHasher(defaultBands * defaultRows)
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "same", a: "とても参考になりました", b: "とても参考になりました"},
+		{name: "similar", a: "とても参考になりました。ありがとうございます", b: "とても参考になりました。ありがとう"},
+		{name: "different", a: "とても参考になりました", b: "これは釣りタイトルでしょう"},
+	}
+	// standard error of estimation with 128 hash functions is about 0.044 at most
+	const tolerance = 0.15
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Shingles(Normalize(tt.a), defaultShingleSize)
+			b := Shingles(Normalize(tt.b), defaultShingleSize)
+			want := Jaccard(a, b)
+			got := EstimateJaccard(minHasher.Signature(a), minHasher.Signature(b))
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("EstimateJaccard() = %v, want %v within %v", got, want, tolerance)
+			}
+		})
+	}
+}
+
+func TestEstimateJaccardInvalidSignatures(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []uint64
+		b    []uint64
+	}{
+		{name: "empty", a: nil, b: nil},
+		{name: "different length", a: []uint64{1, 2}, b: []uint64{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateJaccard(tt.a, tt.b); got != 0 {
+				t.Errorf("EstimateJaccard() = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -41,6 +42,9 @@ func (i *InfluxDBQueries) ReadEntitySummary(
 	ctx context.Context,
 	url string,
 ) (*entities.BookmarkSummary, error) {
+	ctx, span := startSpan(ctx, "ReadEntitySummary", url)
+	defer span.End()
+
 	// query
 	queryAPI := i.dbClient.QueryAPI(i.org)
 	query := fmt.Sprintf(`
@@ -56,6 +60,7 @@ func (i *InfluxDBQueries) ReadEntitySummary(
 	if err != nil {
 		// Debug: what happened when data is not found
 		i.logger.Error("failed to call influxDB queryAPI.Query()", "url", url, "error", err)
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -96,6 +101,7 @@ func (i *InfluxDBQueries) ReadEntitySummary(
 	}
 	if result.Err() != nil {
 		i.logger.Error("failed to retrieve data", "error", result.Err())
+		recordError(span, result.Err())
 		return nil, result.Err()
 	}
 
@@ -120,6 +126,9 @@ func (i *InfluxDBQueries) ReadEntitySummaries(
 	ctx context.Context,
 	url string,
 ) ([]*entities.BookmarkSummary, error) {
+	ctx, span := startSpan(ctx, "ReadEntitySummaries", url)
+	defer span.End()
+
 	// query
 	queryAPI := i.dbClient.QueryAPI(i.org)
 	query := fmt.Sprintf(`
@@ -134,6 +143,7 @@ func (i *InfluxDBQueries) ReadEntitySummaries(
 	if err != nil {
 		// Debug: what happened when data is not found
 		i.logger.Error("failed to call influxDB queryAPI.Query()", "url", url, "error", err)
+		recordError(span, err)
 		return nil, err
 	}
 
@@ -188,6 +198,7 @@ func (i *InfluxDBQueries) ReadEntitySummaries(
 	}
 	if result.Err() != nil {
 		i.logger.Error("failed to retrieve data", "error", result.Err())
+		recordError(span, result.Err())
 		return nil, result.Err()
 	}
 
@@ -213,6 +224,9 @@ func (i *InfluxDBQueries) WriteEntitySummary(
 		return errors.New("bookmark is nil")
 	}
 
+	ctx, span := startSpan(ctx, "WriteEntitySummary", url)
+	defer span.End()
+
 	userNum := len(bookmark.Users)
 	deletedUserNum := bookmark.CountDeletedUser()
 
@@ -233,5 +247,72 @@ func (i *InfluxDBQueries) WriteEntitySummary(
 		AddField("deleted_user_num", deletedUserNum).
 		SetTime(time.Now())
 
-	return writeAPI.WritePoint(ctx, point)
+	err := writeAPI.WritePoint(ctx, point)
+	recordError(span, err)
+	return err
+}
+
+// MoveEntitySummaries copies all points of measurement `from` to measurement `to` and deletes `from`
+// points of `to` at the same time are overwritten. it returns number of moved points
+func (i *InfluxDBQueries) MoveEntitySummaries(ctx context.Context, from, to string) (int, error) {
+	ctx, span := startSpan(ctx, "MoveEntitySummaries", from)
+	defer span.End()
+
+	// query
+	queryAPI := i.dbClient.QueryAPI(i.org)
+	query := fmt.Sprintf(`
+		from(bucket: "%s")
+		|> range(start: 0)
+		|> filter(fn: (r) => r._measurement == "%s")
+		`, i.bucket, from)
+
+	result, err := queryAPI.Query(ctx, query)
+	if err != nil {
+		i.logger.Error("failed to call influxDB queryAPI.Query()", "url", from, "error", err)
+		recordError(span, err)
+		return 0, err
+	}
+
+	// fields of point per time
+	type pointValues struct {
+		title  string
+		fields map[string]interface{}
+	}
+	points := make(map[time.Time]*pointValues)
+	for result.Next() {
+		record := result.Record()
+		values, ok := points[record.Time()]
+		if !ok {
+			title, _ := record.ValueByKey("title").(string)
+			values = &pointValues{title: title, fields: make(map[string]interface{})}
+			points[record.Time()] = values
+		}
+		values.fields[record.Field()] = record.Value()
+	}
+	if result.Err() != nil {
+		i.logger.Error("failed to retrieve data", "error", result.Err())
+		recordError(span, result.Err())
+		return 0, result.Err()
+	}
+	if len(points) == 0 {
+		return 0, nil
+	}
+
+	writeAPI := i.dbClient.WriteAPIBlocking(i.org, i.bucket)
+	for timeStamp, values := range points {
+		point := influxdb2.NewPoint(to, map[string]string{"title": values.title}, values.fields, timeStamp)
+		if err := writeAPI.WritePoint(ctx, point); err != nil {
+			recordError(span, err)
+			return 0, err
+		}
+	}
+
+	// points are deleted after all of them are copied, so that move can be retried
+	predicate := fmt.Sprintf(`_measurement="%s"`, strings.ReplaceAll(from, `"`, `\"`))
+	err = i.dbClient.DeleteAPI().DeleteWithName(ctx, i.org, i.bucket, time.Unix(0, 0), time.Now(), predicate)
+	recordError(span, err)
+	if err != nil {
+		return 0, err
+	}
+	return len(points), nil
 }
@@ -0,0 +1,35 @@
+package influxdb
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+//
+// Tracing of InfluxDB calls
+//
+
+var tracer = otel.Tracer("github.com/hiromaily/hatena-analyzer/pkg/storage/influxdb")
+
+// startSpan creates child span of caller. measurement is url
+func startSpan(ctx context.Context, operation, measurement string) (context.Context, oteltrace.Span) {
+	return tracer.Start(ctx, "influxdb:"+operation,
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("db.system", "influxdb"),
+			attribute.String("db.operation", operation),
+			attribute.String("influxdb.measurement", measurement),
+		),
+	)
+}
+
+func recordError(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -98,3 +98,36 @@ func (m *MongoDBQueries) WriteEntity(
 
 	return nil
 }
+
+// MoveEntity moves entity of url `from` to url `to`
+// if entity of `to` exists, users only in entity of `from` are added to it
+func (m *MongoDBQueries) MoveEntity(ctx context.Context, from, to string) error {
+	fromBookmark, err := m.ReadEntity(ctx, from)
+	if err != nil || fromBookmark == nil {
+		return err
+	}
+	toBookmark, err := m.ReadEntity(ctx, to)
+	if err != nil {
+		return err
+	}
+	if toBookmark == nil {
+		toBookmark = fromBookmark
+	} else {
+		if toBookmark.Users == nil {
+			toBookmark.Users = make(map[string]entities.BookmarkUser, len(fromBookmark.Users))
+		}
+		for userName, user := range fromBookmark.Users {
+			if _, ok := toBookmark.Users[userName]; !ok {
+				toBookmark.Users[userName] = user
+			}
+		}
+	}
+	if err := m.WriteEntity(ctx, to, toBookmark); err != nil {
+		return err
+	}
+
+	// entity is deleted after it's written, so that move can be retried
+	collection := m.mongoDB.Collection(m.collectionName)
+	_, err = collection.DeleteOne(ctx, bson.M{"_id": from})
+	return err
+}
@@ -45,6 +45,8 @@ func NewSqlcPostgresClient(
 	}
 	// set config
 	config.MaxConns = maxConnection
+	// child span per query
+	config.ConnConfig.Tracer = newQueryTracer()
 
 	// create pool
 	pool, err := pgxpool.NewWithConfig(ctx, config)
@@ -84,6 +86,24 @@ func (s *SqlcPostgresClient) Close(_ context.Context) error {
 	return nil
 }
 
+// WithTx calls fn with queries bound to new transaction.
+// transaction is committed if fn returns nil, otherwise rollbacked.
+// Unlike Begin(), it is safe to call concurrently because transaction is not shared
+func (s *SqlcPostgresClient) WithTx(ctx context.Context, fn func(queries *sqlcgen.Queries) error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(sqlcgen.New(tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Begin shares transaction with all queries from GetQueries(). it must not be used concurrently
 func (s *SqlcPostgresClient) Begin(ctx context.Context) error {
 	tx, err := s.pool.Begin(ctx) // FIXME: maybe wrong
 	if err != nil {
@@ -3,6 +3,7 @@ package rdb
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
@@ -41,7 +42,7 @@ func (p *PostgreQueries) GetURLsByURLAddresses(ctx context.Context, urls []strin
 		return nil, err
 	}
 	defer release()
-	urlsRow, err := queries.GetURLsByURLAddresses(ctx, urls)
+	urlsRow, err := queries.GetURLsByURLAddresses(ctx, entities.NewURLHashes(urls))
 	if err != nil {
 		return nil, err
 	}
@@ -67,13 +68,84 @@ func (p *PostgreQueries) GetAllURLs(ctx context.Context) ([]entities.URL, error)
 	return urls, nil
 }
 
+func (p *PostgreQueries) GetURLsByFilter(
+	ctx context.Context,
+	filter *entities.URLFilter,
+) ([]entities.URL, error) {
+	if filter == nil {
+		filter = &entities.URLFilter{}
+	}
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	urlsRow, err := queries.GetURLsByFilter(ctx, adapter.CreateGetURLsByFilterParams(filter))
+	if err != nil {
+		return nil, err
+	}
+	// convert to entity models
+	// []sqlcgen.GetURLsByFilterRow
+	return adapter.URLsByFilterToEntityModel(urlsRow), nil
+}
+
+// GetAllURLAddresses returns all urls including deleted urls
+func (p *PostgreQueries) GetAllURLAddresses(ctx context.Context) ([]entities.URL, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	urlsRow, err := queries.GetAllURLAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.AllURLAddressesToEntityModel(urlsRow), nil
+}
+
+// MergeDuplicateURLs moves UserURLs of duplicated urls to kept url, deletes duplicated urls
+// and updates hash of kept url with hash of normalized address in a transaction
+func (p *PostgreQueries) MergeDuplicateURLs(ctx context.Context, duplicateURLs *entities.DuplicateURLs) error {
+	return p.rdbClient.WithTx(ctx, func(queries *sqlcgen.Queries) error {
+		keptID := duplicateURLs.Kept.ID
+		for _, duplicate := range duplicateURLs.Duplicates {
+			params := sqlcgen.MergeUserURLsParams{
+				ToUrlID:   keptID,
+				FromUrlID: duplicate.ID,
+			}
+			if err := queries.MergeUserURLs(ctx, params); err != nil {
+				return err
+			}
+			if err := queries.DeleteUserURLsByURLID(ctx, duplicate.ID); err != nil {
+				return err
+			}
+			if err := queries.DeleteURLScore(ctx, duplicate.ID); err != nil {
+				return err
+			}
+			if err := queries.MoveAlerts(ctx, sqlcgen.MoveAlertsParams(params)); err != nil {
+				return err
+			}
+			if err := queries.DeleteURL(ctx, duplicate.ID); err != nil {
+				return err
+			}
+		}
+		if duplicateURLs.Kept.Hash == duplicateURLs.Hash {
+			return nil
+		}
+		return queries.UpdateURLHash(ctx, sqlcgen.UpdateURLHashParams{
+			UrlID:   keptID,
+			UrlHash: duplicateURLs.Hash,
+		})
+	})
+}
+
 func (p *PostgreQueries) GetURLID(ctx context.Context, url string) (int32, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
 		return 0, err
 	}
 	defer release()
-	return queries.GetUrlID(ctx, url)
+	return queries.GetUrlID(ctx, entities.NewURLHash(url))
 }
 
 func (p *PostgreQueries) InsertURL(
@@ -94,6 +166,7 @@ func (p *PostgreQueries) InsertURL(
 
 	params := sqlcgen.InsertURLParams{
 		UrlAddress:   url,
+		UrlHash:      entities.NewURLHash(url),
 		CategoryCode: pgtype.Text{String: categoryCode.String(), Valid: categoryCode != ""},
 		// BookmarkCount:  pgtype.Int4{Int32: int32(bmCount), Valid: true},
 		// NamedUserCount: pgtype.Int4{Int32: int32(userCount), Valid: true},
@@ -131,8 +204,9 @@ func (p *PostgreQueries) CallBulkInsertURLs(
 	// sqlcgen.BulkInsertUrlsParams
 	params := sqlcgen.BulkInsertUrlsParams{
 		BulkInsertUrls:   urls,
-		BulkInsertUrls_2: categories,
-		BulkInsertUrls_3: isAlls,
+		BulkInsertUrls_2: entities.NewURLHashes(urls),
+		BulkInsertUrls_3: categories,
+		BulkInsertUrls_4: isAlls,
 	}
 	return queries.BulkInsertUrls(ctx, params)
 }
@@ -156,6 +230,7 @@ func (p *PostgreQueries) UpsertURL(
 
 	params := sqlcgen.UpsertURLParams{
 		UrlAddress:      url,
+		UrlHash:         entities.NewURLHash(url),
 		Title:           pgtype.Text{String: title, Valid: true},
 		BookmarkCount:   pgtype.Int4{Int32: int32(bmCount), Valid: true},
 		NamedUserCount:  pgtype.Int4{Int32: int32(userCount), Valid: true},
@@ -212,17 +287,54 @@ func (p *PostgreQueries) GetAveragePrivateUserRates(
 // users
 //
 
-// func (r *PostgreQueries) InsertUser(ctx context.Context, userName string) error {
-// 	return r.rdbClient.Queries.InsertUser(ctx, userName)
-// }
+// GetUserByName returns pgx.ErrNoRows if user is not found
+func (p *PostgreQueries) GetUserByName(ctx context.Context, userName string) (*entities.UserProfile, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	user, err := queries.GetUserByName(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.UserToEntityModel(&user), nil
+}
 
-func (p *PostgreQueries) UpsertUser(ctx context.Context, userName string) (int32, error) {
+func (p *PostgreQueries) GetUserBookmarks(ctx context.Context, userName string) ([]entities.UserBookmark, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer release()
-	return queries.UpsertUser(ctx, userName)
+	userURLs, err := queries.GetUserURLsByUserName(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.UserURLsToEntityModel(userURLs), nil
+}
+
+func (p *PostgreQueries) GetUsersSharingSuspiciousURLs(
+	ctx context.Context,
+	userName string,
+	minScore float64,
+	maxUsers int,
+) ([]entities.SharedUser, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	params := sqlcgen.GetUsersSharingSuspiciousURLsParams{
+		UserName: userName,
+		MinScore: minScore,
+		MaxUsers: int32(maxUsers),
+	}
+	users, err := queries.GetUsersSharingSuspiciousURLs(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.SharedUsersToEntityModel(users), nil
 }
 
 func (p *PostgreQueries) GetUsersByURL(ctx context.Context, url string) ([]entities.RDBUser, error) {
@@ -231,7 +343,7 @@ func (p *PostgreQueries) GetUsersByURL(ctx context.Context, url string) ([]entit
 		return nil, err
 	}
 	defer release()
-	users, err := queries.GetUsersByURL(ctx, url)
+	users, err := queries.GetUsersByURL(ctx, entities.NewURLHash(url))
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +351,29 @@ func (p *PostgreQueries) GetUsersByURL(ctx context.Context, url string) ([]entit
 	return adapter.DBUsersToEntityModel(users), nil
 }
 
+func (p *PostgreQueries) GetUserBookmarkCountsByCategory(
+	ctx context.Context,
+	categoryCode entities.CategoryCode,
+) ([]int, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	counts, err := queries.GetUserBookmarkCountsByCategory(
+		ctx,
+		pgtype.Text{String: categoryCode.String(), Valid: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	bmCounts := make([]int, 0, len(counts))
+	for _, count := range counts {
+		bmCounts = append(bmCounts, int(count.Int32))
+	}
+	return bmCounts, nil
+}
+
 func (p *PostgreQueries) GetUserNames(ctx context.Context) ([]string, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
@@ -248,42 +383,561 @@ func (p *PostgreQueries) GetUserNames(ctx context.Context) ([]string, error) {
 	return queries.GetUserNames(ctx)
 }
 
+// GetStaleUserNames returns users to be refreshed by given policy
+func (p *PostgreQueries) GetStaleUserNames(
+	ctx context.Context,
+	staleBefore, recentSince time.Time,
+	maxUsers int,
+) ([]string, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	params := sqlcgen.GetStaleUserNamesParams{
+		StaleBefore: pgtype.Timestamp{Time: staleBefore.UTC(), Valid: true},
+		RecentSince: pgtype.Timestamp{Time: recentSince.UTC(), Valid: true},
+		MaxUsers:    int32(maxUsers),
+	}
+	return queries.GetStaleUserNames(ctx, params)
+}
+
 func (p *PostgreQueries) GetUserNamesByURLS(ctx context.Context, urls []string) ([]string, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer release()
-	return queries.GetUserNamesByURLs(ctx, urls)
+	return queries.GetUserNamesByURLs(ctx, entities.NewURLHashes(urls))
+}
+
+func (p *PostgreQueries) UpdateUserDeleted(ctx context.Context, userName string) error {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return queries.UpdateUserDeleted(ctx, userName)
 }
 
+// UpdateUserBookmarkCount updates user's bookmark count and keeps it as history in a transaction
 func (p *PostgreQueries) UpdateUserBookmarkCount(ctx context.Context, userName string, count int) error {
 	param := sqlcgen.UpdateUserBookmarkCountParams{
 		BookmarkCount: pgtype.Int4{Int32: int32(count), Valid: true},
 		UserName:      userName,
 	}
+	return p.rdbClient.WithTx(ctx, func(queries *sqlcgen.Queries) error {
+		userID, err := queries.UpdateUserBookmarkCount(ctx, param)
+		if err != nil {
+			return err
+		}
+		return queries.InsertUserBookmarkCountHistory(ctx, sqlcgen.InsertUserBookmarkCountHistoryParams{
+			UserID:        userID,
+			BookmarkCount: int32(count),
+		})
+	})
+}
+
+func (p *PostgreQueries) GetUserBookmarkCountHistory(
+	ctx context.Context,
+	userName string,
+) ([]entities.BookmarkCountPoint, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer release()
-	_, err = queries.UpdateUserBookmarkCount(ctx, param)
-	return err
+	history, err := queries.GetUserBookmarkCountHistory(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.BookmarkCountHistoryToEntityModel(history), nil
+}
+
+func (p *PostgreQueries) GetUserGrowths(
+	ctx context.Context,
+	since time.Time,
+	maxUsers int,
+) ([]entities.UserGrowth, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	params := sqlcgen.GetUserGrowthsParams{
+		Since:    pgtype.Timestamp{Time: since.UTC(), Valid: true},
+		MaxUsers: int32(maxUsers),
+	}
+	growths, err := queries.GetUserGrowths(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.UserGrowthsToEntityModel(growths), nil
 }
 
 //
 // user_urls
 //
 
-func (p *PostgreQueries) UpsertUserURLs(ctx context.Context, userID, urlID int32) error {
-	param := sqlcgen.UpsertUserURLsParams{
-		UserID: userID,
-		UrlID:  urlID,
+// BulkUpsertUserURLs upserts Users and UserURLs of url in a transaction.
+// users are copied into staging table at once instead of upserting them one by one.
+// users with IsDeleted are marked as removed bookmarks in UserURLs
+func (p *PostgreQueries) BulkUpsertUserURLs(
+	ctx context.Context,
+	urlID int32,
+	users []entities.BookmarkUser,
+) error {
+	if urlID == 0 {
+		return errors.New("urlID is 0")
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	return p.rdbClient.WithTx(ctx, func(queries *sqlcgen.Queries) error {
+		params := adapter.CreateInsertUserURLStagingsParams(urlID, users)
+		if _, err := queries.InsertUserURLStagings(ctx, params); err != nil {
+			return err
+		}
+		if err := queries.UpsertUsersFromStagings(ctx, urlID); err != nil {
+			return err
+		}
+		if err := queries.UpsertUserURLsFromStagings(ctx, urlID); err != nil {
+			return err
+		}
+		return queries.DeleteUserURLStagings(ctx, urlID)
+	})
+}
+
+func (p *PostgreQueries) GetAllComments(ctx context.Context) ([]entities.Comment, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	comments, err := queries.GetAllComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// convert to entity models
+	return adapter.AllCommentsToEntityModel(comments), nil
+}
+
+func (p *PostgreQueries) GetCommentsByURLs(ctx context.Context, urls []string) ([]entities.Comment, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	comments, err := queries.GetCommentsByURLs(ctx, entities.NewURLHashes(urls))
+	if err != nil {
+		return nil, err
+	}
+	// convert to entity models
+	return adapter.CommentsByURLsToEntityModel(comments), nil
+}
+
+//
+// url_scores
+//
+
+func (p *PostgreQueries) GetMaxSharedUserCount(ctx context.Context, urlID int32) (int, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	count, err := queries.GetMaxSharedUserCount(ctx, urlID)
+	return int(count), err
+}
+
+func (p *PostgreQueries) UpsertURLScore(ctx context.Context, score *entities.SuspicionScore) error {
+	if score.URLID == 0 {
+		return errors.New("urlID is 0")
+	}
+
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return err
 	}
+	defer release()
+	return queries.UpsertURLScore(ctx, adapter.CreateUpsertURLScoreParams(score))
+}
+
+func (p *PostgreQueries) GetAllURLScores(ctx context.Context) ([]entities.SuspicionScore, error) {
 	queries, release, err := p.rdbClient.GetQueries(ctx)
 	if err != nil {
+		return nil, err
+	}
+	defer release()
+	scores, err := queries.GetAllURLScores(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// convert to entity models
+	return adapter.URLScoresToEntityModel(scores), nil
+}
+
+//
+// fetch_runs
+//
+
+// CreateFetchRun starts run of command with pending items in a transaction
+func (p *PostgreQueries) CreateFetchRun(
+	ctx context.Context,
+	command entities.RunCommand,
+	items []string,
+) (int32, error) {
+	// items must be unique in run
+	uniqueItems := make([]string, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		uniqueItems = append(uniqueItems, item)
+	}
+
+	var runID int32
+	err := p.rdbClient.WithTx(ctx, func(queries *sqlcgen.Queries) error {
+		var err error
+		runID, err = queries.InsertFetchRun(ctx, command.String())
+		if err != nil {
+			return err
+		}
+		if len(uniqueItems) == 0 {
+			return nil
+		}
+		_, err = queries.InsertFetchRunItems(ctx, adapter.CreateInsertFetchRunItemsParams(runID, uniqueItems))
 		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return runID, nil
+}
+
+func (p *PostgreQueries) GetFetchRun(ctx context.Context, runID int32) (*entities.Run, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
 	}
 	defer release()
-	return queries.UpsertUserURLs(ctx, param)
+	run, err := queries.GetFetchRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.FetchRunToEntityModel(&run), nil
+}
+
+func (p *PostgreQueries) GetFetchRuns(ctx context.Context, limit int) ([]entities.Run, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	runs, err := queries.GetFetchRuns(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+	return adapter.FetchRunsToEntityModel(runs), nil
+}
+
+func (p *PostgreQueries) GetUnfinishedFetchRunItems(ctx context.Context, runID int32) ([]string, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return queries.GetUnfinishedFetchRunItems(ctx, runID)
+}
+
+func (p *PostgreQueries) UpdateFetchRunItemStatus(
+	ctx context.Context,
+	runID int32,
+	item string,
+	status entities.RunItemStatus,
+) error {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	params := sqlcgen.UpdateFetchRunItemStatusParams{
+		RunID:  runID,
+		Item:   item,
+		Status: string(status),
+	}
+	return queries.UpdateFetchRunItemStatus(ctx, params)
+}
+
+func (p *PostgreQueries) UpdateFetchRunStatus(
+	ctx context.Context,
+	runID int32,
+	status entities.RunStatus,
+) error {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	params := sqlcgen.UpdateFetchRunStatusParams{
+		RunID:  runID,
+		Status: string(status),
+	}
+	return queries.UpdateFetchRunStatus(ctx, params)
+}
+
+//
+// API keys
+//
+
+func (p *PostgreQueries) InsertAPIKey(
+	ctx context.Context,
+	name, key string,
+	scope entities.APIKeyScope,
+	rateLimit, dailyQuota int,
+) (int32, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	params := sqlcgen.InsertApiKeyParams{
+		Name:       name,
+		KeyPrefix:  entities.APIKeyDisplayPrefix(key),
+		KeyHash:    entities.NewAPIKeyHash(key),
+		Scope:      scope.String(),
+		RateLimit:  int32(rateLimit),
+		DailyQuota: int32(dailyQuota),
+	}
+	return queries.InsertApiKey(ctx, params)
+}
+
+func (p *PostgreQueries) GetAPIKeyByKey(ctx context.Context, key string) (*entities.APIKey, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	apiKey, err := queries.GetApiKeyByHash(ctx, entities.NewAPIKeyHash(key))
+	if err != nil {
+		return nil, err
+	}
+	return adapter.APIKeyByHashToEntityModel(&apiKey), nil
+}
+
+func (p *PostgreQueries) GetAPIKeys(ctx context.Context, usageDate time.Time) ([]entities.APIKey, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	apiKeys, err := queries.GetApiKeys(ctx, pgtype.Date{Time: usageDate, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return adapter.APIKeysToEntityModel(apiKeys), nil
+}
+
+// RevokeAPIKey returns false if key is not found or already revoked
+func (p *PostgreQueries) RevokeAPIKey(ctx context.Context, apiKeyID int32) (bool, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	rows, err := queries.RevokeApiKey(ctx, apiKeyID)
+	if err != nil {
+		return false, err
+	}
+	return rows != 0, nil
+}
+
+// IncrementAPIKeyUsage counts request and records last used time in a transaction
+// it returns false without counting when daily quota is exceeded
+func (p *PostgreQueries) IncrementAPIKeyUsage(
+	ctx context.Context,
+	apiKeyID int32,
+	usageDate time.Time,
+	dailyQuota int,
+) (bool, error) {
+	params := sqlcgen.IncrementApiKeyUsageParams{
+		ApiKeyID:   apiKeyID,
+		UsageDate:  pgtype.Date{Time: usageDate, Valid: true},
+		DailyQuota: int32(dailyQuota),
+	}
+	var accepted bool
+	err := p.rdbClient.WithTx(ctx, func(queries *sqlcgen.Queries) error {
+		if _, err := queries.IncrementApiKeyUsage(ctx, params); err != nil {
+			if IsNoRows(err) {
+				return nil
+			}
+			return err
+		}
+		accepted = true
+		return queries.UpdateApiKeyLastUsed(ctx, apiKeyID)
+	})
+	if err != nil {
+		return false, err
+	}
+	return accepted, nil
+}
+
+//
+// Alerts
+//
+
+func (p *PostgreQueries) InsertAlert(ctx context.Context, alert *entities.Alert) error {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	params := sqlcgen.InsertAlertParams{
+		UrlID:     alert.URLID,
+		Rule:      alert.Rule.String(),
+		Value:     alert.Value,
+		Threshold: alert.Threshold,
+	}
+	return queries.InsertAlert(ctx, params)
+}
+
+// GetAlertedRules returns rules alerted for url since given time
+func (p *PostgreQueries) GetAlertedRules(
+	ctx context.Context,
+	urlID int32,
+	since time.Time,
+) ([]entities.AlertRule, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	params := sqlcgen.GetAlertedRulesParams{
+		UrlID: urlID,
+		Since: pgtype.Timestamp{Time: since.UTC(), Valid: true},
+	}
+	rules, err := queries.GetAlertedRules(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	alertRules := make([]entities.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		alertRules = append(alertRules, entities.AlertRule(rule))
+	}
+	return alertRules, nil
+}
+
+//
+// watchlist
+//
+
+// InsertWatchedURL returns false if url is already watched
+func (p *PostgreQueries) InsertWatchedURL(ctx context.Context, url, note string) (bool, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	params := sqlcgen.InsertWatchedURLParams{
+		UrlAddress: url,
+		UrlHash:    entities.NewURLHash(url),
+		Note:       pgtype.Text{String: note, Valid: true},
+	}
+	rows, err := queries.InsertWatchedURL(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	return rows != 0, nil
+}
+
+// DeleteWatchedURL returns false if url is not watched
+func (p *PostgreQueries) DeleteWatchedURL(ctx context.Context, url string) (bool, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	rows, err := queries.DeleteWatchedURL(ctx, entities.NewURLHash(url))
+	if err != nil {
+		return false, err
+	}
+	return rows != 0, nil
+}
+
+func (p *PostgreQueries) GetWatchedURLs(ctx context.Context) ([]entities.WatchedURL, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	urls, err := queries.GetWatchedURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.WatchedURLsToEntityModel(urls), nil
+}
+
+// InsertWatchedUser returns false if user is already watched
+func (p *PostgreQueries) InsertWatchedUser(ctx context.Context, userName, note string) (bool, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	params := sqlcgen.InsertWatchedUserParams{
+		UserName: userName,
+		Note:     pgtype.Text{String: note, Valid: true},
+	}
+	rows, err := queries.InsertWatchedUser(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	return rows != 0, nil
+}
+
+// DeleteWatchedUser returns false if user is not watched
+func (p *PostgreQueries) DeleteWatchedUser(ctx context.Context, userName string) (bool, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	rows, err := queries.DeleteWatchedUser(ctx, userName)
+	if err != nil {
+		return false, err
+	}
+	return rows != 0, nil
+}
+
+func (p *PostgreQueries) GetWatchedUsers(ctx context.Context) ([]entities.WatchedUser, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	users, err := queries.GetWatchedUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.WatchedUsersToEntityModel(users), nil
+}
+
+// GetWatchedUserNewBookmarks returns bookmarks of watched users stored after they are watched and since given time
+func (p *PostgreQueries) GetWatchedUserNewBookmarks(
+	ctx context.Context,
+	since time.Time,
+) ([]entities.WatchedUserBookmark, error) {
+	queries, release, err := p.rdbClient.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	bookmarks, err := queries.GetWatchedUserNewBookmarks(ctx, pgtype.Timestamp{Time: since.UTC(), Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return adapter.WatchedUserNewBookmarksToEntityModel(bookmarks), nil
 }
@@ -9,6 +9,40 @@ import (
 	"context"
 )
 
+// iteratorForInsertFetchRunItems implements pgx.CopyFromSource.
+type iteratorForInsertFetchRunItems struct {
+	rows                 []InsertFetchRunItemsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertFetchRunItems) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertFetchRunItems) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].RunID,
+		r.rows[0].Item,
+	}, nil
+}
+
+func (r iteratorForInsertFetchRunItems) Err() error {
+	return nil
+}
+
+// @desc: copy items of run. all items are pending first
+func (q *Queries) InsertFetchRunItems(ctx context.Context, arg []InsertFetchRunItemsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"fetchrunitems"}, []string{"run_id", "item"}, &iteratorForInsertFetchRunItems{rows: arg})
+}
+
 // iteratorForInsertURLs implements pgx.CopyFromSource.
 type iteratorForInsertURLs struct {
 	rows                 []InsertURLsParams
@@ -30,6 +64,7 @@ func (r *iteratorForInsertURLs) Next() bool {
 func (r iteratorForInsertURLs) Values() ([]interface{}, error) {
 	return []interface{}{
 		r.rows[0].UrlAddress,
+		r.rows[0].UrlHash,
 		r.rows[0].CategoryCode,
 	}, nil
 }
@@ -40,5 +75,41 @@ func (r iteratorForInsertURLs) Err() error {
 
 // @desc: Deprecated. insert urls if not existed
 func (q *Queries) InsertURLs(ctx context.Context, arg []InsertURLsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"urls"}, []string{"url_address", "category_code"}, &iteratorForInsertURLs{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"urls"}, []string{"url_address", "url_hash", "category_code"}, &iteratorForInsertURLs{rows: arg})
+}
+
+// iteratorForInsertUserURLStagings implements pgx.CopyFromSource.
+type iteratorForInsertUserURLStagings struct {
+	rows                 []InsertUserURLStagingsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertUserURLStagings) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertUserURLStagings) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].UrlID,
+		r.rows[0].UserName,
+		r.rows[0].Comment,
+		r.rows[0].IsDeleted,
+	}, nil
+}
+
+func (r iteratorForInsertUserURLStagings) Err() error {
+	return nil
+}
+
+// @desc: copy bookmarked users of url into staging table
+func (q *Queries) InsertUserURLStagings(ctx context.Context, arg []InsertUserURLStagingsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"userurlstagings"}, []string{"url_id", "user_name", "comment", "is_deleted"}, &iteratorForInsertUserURLStagings{rows: arg})
 }
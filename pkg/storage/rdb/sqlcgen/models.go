@@ -8,6 +8,35 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Alert struct {
+	AlertID   int32
+	UrlID     int32
+	Rule      string
+	Value     float64
+	Threshold float64
+	CreatedAt pgtype.Timestamp
+}
+
+type Apikey struct {
+	ApiKeyID   int32
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scope      string
+	RateLimit  int32
+	DailyQuota int32
+	IsRevoked  bool
+	CreatedAt  pgtype.Timestamp
+	LastUsedAt pgtype.Timestamp
+	RevokedAt  pgtype.Timestamp
+}
+
+type Apikeyusage struct {
+	ApiKeyID     int32
+	UsageDate    pgtype.Date
+	RequestCount int32
+}
+
 type Category struct {
 	CategoryID   int32
 	CategoryCode string
@@ -17,9 +46,27 @@ type Category struct {
 	UpdatedAt    pgtype.Timestamp
 }
 
+type Fetchrun struct {
+	RunID      int32
+	Command    string
+	Status     string
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	FinishedAt pgtype.Timestamp
+}
+
+type Fetchrunitem struct {
+	RunItemID int32
+	RunID     int32
+	Item      string
+	Status    string
+	UpdatedAt pgtype.Timestamp
+}
+
 type Url struct {
 	UrlID           int32
 	UrlAddress      string
+	UrlHash         string
 	CategoryCode    pgtype.Text
 	IsAll           pgtype.Bool
 	Title           pgtype.Text
@@ -31,6 +78,18 @@ type Url struct {
 	UpdatedAt       pgtype.Timestamp
 }
 
+type Urlscore struct {
+	UrlID           int32
+	Score           pgtype.Float8
+	PrivateUserRate pgtype.Float8
+	NewUserRate     pgtype.Float8
+	DeletedUserRate pgtype.Float8
+	Burstiness      pgtype.Float8
+	UserOverlap     pgtype.Float8
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
 type User struct {
 	UserID        int32
 	UserName      string
@@ -40,11 +99,42 @@ type User struct {
 	UpdatedAt     pgtype.Timestamp
 }
 
+type Userbookmarkcounthistory struct {
+	HistoryID     int32
+	UserID        int32
+	BookmarkCount int32
+	ObservedAt    pgtype.Timestamp
+}
+
 type Userurl struct {
 	UserUrlID int32
 	UserID    int32
 	UrlID     int32
+	Comment   pgtype.Text
 	IsDeleted pgtype.Bool
+	RemovedAt pgtype.Timestamp
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
 }
+
+type Userurlstaging struct {
+	UrlID     int32
+	UserName  string
+	Comment   pgtype.Text
+	IsDeleted pgtype.Bool
+}
+
+type Watchedurl struct {
+	WatchedUrlID int32
+	UrlAddress   string
+	UrlHash      string
+	Note         pgtype.Text
+	CreatedAt    pgtype.Timestamp
+}
+
+type Watcheduser struct {
+	WatchedUserID int32
+	UserName      string
+	Note          pgtype.Text
+	CreatedAt     pgtype.Timestamp
+}
@@ -12,18 +12,24 @@ import (
 )
 
 const bulkInsertUrls = `-- name: BulkInsertUrls :exec
-CALL bulk_insert_urls($1, $2, $3)
+CALL bulk_insert_urls($1, $2, $3, $4)
 `
 
 type BulkInsertUrlsParams struct {
 	BulkInsertUrls   interface{}
 	BulkInsertUrls_2 interface{}
 	BulkInsertUrls_3 interface{}
+	BulkInsertUrls_4 interface{}
 }
 
-// @desc: insert urls by stored procedure. conflicts must be ignored. arg1: array of urls, arg2: array of category, arg3: array of isAll flag.
+// @desc: insert urls by stored procedure. conflicts must be ignored. arg1: array of urls, arg2: array of url hashes, arg3: array of category, arg4: array of isAll flag.
 func (q *Queries) BulkInsertUrls(ctx context.Context, arg BulkInsertUrlsParams) error {
-	_, err := q.db.Exec(ctx, bulkInsertUrls, arg.BulkInsertUrls, arg.BulkInsertUrls_2, arg.BulkInsertUrls_3)
+	_, err := q.db.Exec(ctx, bulkInsertUrls,
+		arg.BulkInsertUrls,
+		arg.BulkInsertUrls_2,
+		arg.BulkInsertUrls_3,
+		arg.BulkInsertUrls_4,
+	)
 	return err
 }
 
@@ -54,9 +60,258 @@ func (q *Queries) CountGetBookmarkedUsersURLCounts(ctx context.Context) (int64,
 	return count, err
 }
 
+const deleteURL = `-- name: DeleteURL :exec
+DELETE FROM URLs WHERE url_id = $1
+`
+
+// @desc: delete url. UserURLs, URLScores and Alerts of url must be moved or deleted before
+func (q *Queries) DeleteURL(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, deleteURL, urlID)
+	return err
+}
+
+const deleteURLScore = `-- name: DeleteURLScore :exec
+DELETE FROM URLScores WHERE url_id = $1
+`
+
+// @desc: delete suspicion score of url
+func (q *Queries) DeleteURLScore(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, deleteURLScore, urlID)
+	return err
+}
+
+const deleteUserURLStagings = `-- name: DeleteUserURLStagings :exec
+DELETE FROM UserURLStagings WHERE url_id = $1
+`
+
+// @desc: delete rows of url in staging table
+func (q *Queries) DeleteUserURLStagings(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, deleteUserURLStagings, urlID)
+	return err
+}
+
+const deleteUserURLsByURLID = `-- name: DeleteUserURLsByURLID :exec
+DELETE FROM UserURLs WHERE url_id = $1
+`
+
+// @desc: delete UserURLs of url
+func (q *Queries) DeleteUserURLsByURLID(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, deleteUserURLsByURLID, urlID)
+	return err
+}
+
+const deleteWatchedURL = `-- name: DeleteWatchedURL :execrows
+DELETE FROM WatchedURLs WHERE url_hash = $1
+`
+
+// @desc: unwatch url
+func (q *Queries) DeleteWatchedURL(ctx context.Context, urlHash string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWatchedURL, urlHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteWatchedUser = `-- name: DeleteWatchedUser :execrows
+DELETE FROM WatchedUsers WHERE user_name = $1
+`
+
+// @desc: unwatch user
+func (q *Queries) DeleteWatchedUser(ctx context.Context, userName string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWatchedUser, userName)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAlertedRules = `-- name: GetAlertedRules :many
+SELECT DISTINCT
+  rule
+FROM
+  Alerts
+WHERE
+  url_id = $1
+  AND created_at >= $2::timestamp
+`
+
+type GetAlertedRulesParams struct {
+	UrlID int32
+	Since pgtype.Timestamp
+}
+
+// @desc: get rules alerted for url since given time to deduplicate alerts
+func (q *Queries) GetAlertedRules(ctx context.Context, arg GetAlertedRulesParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAlertedRules, arg.UrlID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var rule string
+		if err := rows.Scan(&rule); err != nil {
+			return nil, err
+		}
+		items = append(items, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllComments = `-- name: GetAllComments :many
+SELECT
+  url.url_address, u.user_name, u.bookmark_count, uu.comment
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND uu.comment <> ''
+ORDER BY
+  url.url_address, u.user_name
+`
+
+type GetAllCommentsRow struct {
+	UrlAddress    string
+	UserName      string
+	BookmarkCount pgtype.Int4
+	Comment       pgtype.Text
+}
+
+// @desc: get all bookmark comments with user's bookmark count
+func (q *Queries) GetAllComments(ctx context.Context) ([]GetAllCommentsRow, error) {
+	rows, err := q.db.Query(ctx, getAllComments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllCommentsRow
+	for rows.Next() {
+		var i GetAllCommentsRow
+		if err := rows.Scan(
+			&i.UrlAddress,
+			&i.UserName,
+			&i.BookmarkCount,
+			&i.Comment,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllURLAddresses = `-- name: GetAllURLAddresses :many
+SELECT
+  u.url_id, u.url_address, u.url_hash, u.bookmark_count
+FROM
+  URLs u
+ORDER BY
+  u.url_id
+`
+
+type GetAllURLAddressesRow struct {
+	UrlID         int32
+	UrlAddress    string
+	UrlHash       string
+	BookmarkCount pgtype.Int4
+}
+
+// @desc: get all url addresses including deleted urls to find duplicated urls
+func (q *Queries) GetAllURLAddresses(ctx context.Context) ([]GetAllURLAddressesRow, error) {
+	rows, err := q.db.Query(ctx, getAllURLAddresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllURLAddressesRow
+	for rows.Next() {
+		var i GetAllURLAddressesRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.UrlHash,
+			&i.BookmarkCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllURLScores = `-- name: GetAllURLScores :many
+SELECT
+  url.url_id, url.url_address, url.title,
+  s.score, s.private_user_rate, s.new_user_rate, s.deleted_user_rate, s.burstiness, s.user_overlap
+FROM
+  URLScores s
+  INNER JOIN URLs url ON s.url_id = url.url_id
+WHERE
+  url.is_deleted = FALSE
+ORDER BY
+  s.score DESC
+`
+
+type GetAllURLScoresRow struct {
+	UrlID           int32
+	UrlAddress      string
+	Title           pgtype.Text
+	Score           pgtype.Float8
+	PrivateUserRate pgtype.Float8
+	NewUserRate     pgtype.Float8
+	DeletedUserRate pgtype.Float8
+	Burstiness      pgtype.Float8
+	UserOverlap     pgtype.Float8
+}
+
+// @desc: get suspicion scores of all urls
+func (q *Queries) GetAllURLScores(ctx context.Context) ([]GetAllURLScoresRow, error) {
+	rows, err := q.db.Query(ctx, getAllURLScores)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllURLScoresRow
+	for rows.Next() {
+		var i GetAllURLScoresRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.Title,
+			&i.Score,
+			&i.PrivateUserRate,
+			&i.NewUserRate,
+			&i.DeletedUserRate,
+			&i.Burstiness,
+			&i.UserOverlap,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllURLs = `-- name: GetAllURLs :many
 SELECT DISTINCT ON (u.url_address)
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
 FROM
   URLs u
 WHERE
@@ -68,6 +323,7 @@ ORDER BY
 type GetAllURLsRow struct {
 	UrlID           int32
 	UrlAddress      string
+	UrlHash         string
 	CategoryCode    pgtype.Text
 	Title           pgtype.Text
 	BookmarkCount   pgtype.Int4
@@ -88,6 +344,7 @@ func (q *Queries) GetAllURLs(ctx context.Context) ([]GetAllURLsRow, error) {
 		if err := rows.Scan(
 			&i.UrlID,
 			&i.UrlAddress,
+			&i.UrlHash,
 			&i.CategoryCode,
 			&i.Title,
 			&i.BookmarkCount,
@@ -104,6 +361,106 @@ func (q *Queries) GetAllURLs(ctx context.Context) ([]GetAllURLsRow, error) {
 	return items, nil
 }
 
+const getApiKeyByHash = `-- name: GetApiKeyByHash :one
+SELECT
+  api_key_id, name, key_prefix, scope, rate_limit, daily_quota, is_revoked, created_at, last_used_at, revoked_at
+FROM
+  ApiKeys
+WHERE
+  key_hash = $1
+`
+
+type GetApiKeyByHashRow struct {
+	ApiKeyID   int32
+	Name       string
+	KeyPrefix  string
+	Scope      string
+	RateLimit  int32
+	DailyQuota int32
+	IsRevoked  bool
+	CreatedAt  pgtype.Timestamp
+	LastUsedAt pgtype.Timestamp
+	RevokedAt  pgtype.Timestamp
+}
+
+// @desc: get API key by hash of key to authenticate request
+func (q *Queries) GetApiKeyByHash(ctx context.Context, keyHash string) (GetApiKeyByHashRow, error) {
+	row := q.db.QueryRow(ctx, getApiKeyByHash, keyHash)
+	var i GetApiKeyByHashRow
+	err := row.Scan(
+		&i.ApiKeyID,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.Scope,
+		&i.RateLimit,
+		&i.DailyQuota,
+		&i.IsRevoked,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getApiKeys = `-- name: GetApiKeys :many
+SELECT
+  k.api_key_id, k.name, k.key_prefix, k.scope, k.rate_limit, k.daily_quota, k.is_revoked,
+  k.created_at, k.last_used_at, k.revoked_at,
+  COALESCE(u.request_count, 0)::int AS request_count
+FROM
+  ApiKeys k
+  LEFT JOIN ApiKeyUsages u ON k.api_key_id = u.api_key_id AND u.usage_date = $1::date
+ORDER BY
+  k.api_key_id
+`
+
+type GetApiKeysRow struct {
+	ApiKeyID     int32
+	Name         string
+	KeyPrefix    string
+	Scope        string
+	RateLimit    int32
+	DailyQuota   int32
+	IsRevoked    bool
+	CreatedAt    pgtype.Timestamp
+	LastUsedAt   pgtype.Timestamp
+	RevokedAt    pgtype.Timestamp
+	RequestCount int32
+}
+
+// @desc: get all API keys with request count of given date
+func (q *Queries) GetApiKeys(ctx context.Context, usageDate pgtype.Date) ([]GetApiKeysRow, error) {
+	rows, err := q.db.Query(ctx, getApiKeys, usageDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApiKeysRow
+	for rows.Next() {
+		var i GetApiKeysRow
+		if err := rows.Scan(
+			&i.ApiKeyID,
+			&i.Name,
+			&i.KeyPrefix,
+			&i.Scope,
+			&i.RateLimit,
+			&i.DailyQuota,
+			&i.IsRevoked,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.RequestCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAveragePrivateUserRates = `-- name: GetAveragePrivateUserRates :many
 SELECT
   category_code, AVG(private_user_rate) AS average_private_user_rate
@@ -180,45 +537,45 @@ func (q *Queries) GetBookmarkedUsersURLCounts(ctx context.Context) ([]GetBookmar
 	return items, nil
 }
 
-const getURLsByPrivateRate = `-- name: GetURLsByPrivateRate :many
+const getCommentsByURLs = `-- name: GetCommentsByURLs :many
 SELECT
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
-FROM 
-  URLs u
-WHERE 
-  private_user_rate >= $1 
-ORDER BY 
-  private_user_rate DESC
+  url.url_address, u.user_name, u.bookmark_count, uu.comment
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND uu.comment <> ''
+  AND url.url_hash = ANY($1::text[])
+ORDER BY
+  url.url_address, u.user_name
 `
 
-type GetURLsByPrivateRateRow struct {
-	UrlID           int32
-	UrlAddress      string
-	CategoryCode    pgtype.Text
-	Title           pgtype.Text
-	BookmarkCount   pgtype.Int4
-	NamedUserCount  pgtype.Int4
-	PrivateUserRate pgtype.Float8
+type GetCommentsByURLsRow struct {
+	UrlAddress    string
+	UserName      string
+	BookmarkCount pgtype.Int4
+	Comment       pgtype.Text
 }
 
-// @desc: get urls by private_user_rate
-func (q *Queries) GetURLsByPrivateRate(ctx context.Context, privateUserRate pgtype.Float8) ([]GetURLsByPrivateRateRow, error) {
-	rows, err := q.db.Query(ctx, getURLsByPrivateRate, privateUserRate)
+// @desc: get bookmark comments with user's bookmark count by multiple urls
+func (q *Queries) GetCommentsByURLs(ctx context.Context, dollar_1 []string) ([]GetCommentsByURLsRow, error) {
+	rows, err := q.db.Query(ctx, getCommentsByURLs, dollar_1)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetURLsByPrivateRateRow
+	var items []GetCommentsByURLsRow
 	for rows.Next() {
-		var i GetURLsByPrivateRateRow
+		var i GetCommentsByURLsRow
 		if err := rows.Scan(
-			&i.UrlID,
 			&i.UrlAddress,
-			&i.CategoryCode,
-			&i.Title,
+			&i.UserName,
 			&i.BookmarkCount,
-			&i.NamedUserCount,
-			&i.PrivateUserRate,
+			&i.Comment,
 		); err != nil {
 			return nil, err
 		}
@@ -230,46 +587,254 @@ func (q *Queries) GetURLsByPrivateRate(ctx context.Context, privateUserRate pgty
 	return items, nil
 }
 
-const getURLsByURLAddresses = `-- name: GetURLsByURLAddresses :many
-SELECT DISTINCT ON (u.url_address)
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+const getFetchRun = `-- name: GetFetchRun :one
+SELECT
+  r.run_id, r.command, r.status, r.created_at, r.updated_at, r.finished_at,
+  COUNT(i.run_item_id)::int AS total_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'done')::int AS done_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'failed')::int AS failed_count
 FROM
-  URLs u
+  FetchRuns r
+  LEFT JOIN FetchRunItems i ON r.run_id = i.run_id
 WHERE
-  u.is_deleted = FALSE
-AND
-  u.url_address = ANY($1::text[])
+  r.run_id = $1
+GROUP BY
+  r.run_id
+`
+
+type GetFetchRunRow struct {
+	RunID       int32
+	Command     string
+	Status      string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	FinishedAt  pgtype.Timestamp
+	TotalCount  int32
+	DoneCount   int32
+	FailedCount int32
+}
+
+// @desc: get run with counts of items by status
+func (q *Queries) GetFetchRun(ctx context.Context, runID int32) (GetFetchRunRow, error) {
+	row := q.db.QueryRow(ctx, getFetchRun, runID)
+	var i GetFetchRunRow
+	err := row.Scan(
+		&i.RunID,
+		&i.Command,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TotalCount,
+		&i.DoneCount,
+		&i.FailedCount,
+	)
+	return i, err
+}
+
+const getFetchRuns = `-- name: GetFetchRuns :many
+SELECT
+  r.run_id, r.command, r.status, r.created_at, r.updated_at, r.finished_at,
+  COUNT(i.run_item_id)::int AS total_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'done')::int AS done_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'failed')::int AS failed_count
+FROM
+  FetchRuns r
+  LEFT JOIN FetchRunItems i ON r.run_id = i.run_id
+GROUP BY
+  r.run_id
 ORDER BY
-  u.url_address, u.url_id
+  r.run_id DESC
+LIMIT $1
 `
 
-type GetURLsByURLAddressesRow struct {
-	UrlID           int32
-	UrlAddress      string
-	CategoryCode    pgtype.Text
-	Title           pgtype.Text
-	BookmarkCount   pgtype.Int4
-	NamedUserCount  pgtype.Int4
-	PrivateUserRate pgtype.Float8
+type GetFetchRunsRow struct {
+	RunID       int32
+	Command     string
+	Status      string
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	FinishedAt  pgtype.Timestamp
+	TotalCount  int32
+	DoneCount   int32
+	FailedCount int32
 }
 
-// @desc: get url information by url address
-func (q *Queries) GetURLsByURLAddresses(ctx context.Context, dollar_1 []string) ([]GetURLsByURLAddressesRow, error) {
-	rows, err := q.db.Query(ctx, getURLsByURLAddresses, dollar_1)
+// @desc: get latest runs with counts of items by status
+func (q *Queries) GetFetchRuns(ctx context.Context, limit int32) ([]GetFetchRunsRow, error) {
+	rows, err := q.db.Query(ctx, getFetchRuns, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetURLsByURLAddressesRow
+	var items []GetFetchRunsRow
 	for rows.Next() {
-		var i GetURLsByURLAddressesRow
+		var i GetFetchRunsRow
 		if err := rows.Scan(
-			&i.UrlID,
-			&i.UrlAddress,
-			&i.CategoryCode,
-			&i.Title,
-			&i.BookmarkCount,
-			&i.NamedUserCount,
+			&i.RunID,
+			&i.Command,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FinishedAt,
+			&i.TotalCount,
+			&i.DoneCount,
+			&i.FailedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMaxSharedUserCount = `-- name: GetMaxSharedUserCount :one
+SELECT
+  COALESCE(MAX(shared.user_count), 0)::int AS max_shared_user_count
+FROM
+  (
+    SELECT
+      uu2.url_id,
+      COUNT(uu2.user_id) AS user_count
+    FROM
+      UserURLs uu1
+      INNER JOIN UserURLs uu2 ON uu1.user_id = uu2.user_id AND uu1.url_id <> uu2.url_id
+    WHERE
+      uu1.url_id = $1
+      AND uu1.is_deleted = FALSE
+      AND uu2.is_deleted = FALSE
+    GROUP BY
+      uu2.url_id
+  ) AS shared
+`
+
+// @desc: get max number of users shared with another url
+func (q *Queries) GetMaxSharedUserCount(ctx context.Context, urlID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getMaxSharedUserCount, urlID)
+	var max_shared_user_count int32
+	err := row.Scan(&max_shared_user_count)
+	return max_shared_user_count, err
+}
+
+const getStaleUserNames = `-- name: GetStaleUserNames :many
+SELECT
+  u.user_name
+FROM
+  Users u
+WHERE
+  u.is_deleted = FALSE
+  AND (u.updated_at < $1::timestamp OR u.updated_at = u.created_at)
+ORDER BY
+  EXISTS (
+    SELECT 1
+    FROM
+      UserURLs uu
+      INNER JOIN URLs url ON uu.url_id = url.url_id
+    WHERE
+      uu.user_id = u.user_id
+      AND uu.is_deleted = FALSE
+      AND url.is_deleted = FALSE
+      AND url.updated_at >= $2::timestamp
+  ) DESC,
+  (u.updated_at = u.created_at) DESC,
+  u.updated_at ASC
+LIMIT $3::int
+`
+
+type GetStaleUserNamesParams struct {
+	StaleBefore pgtype.Timestamp
+	RecentSince pgtype.Timestamp
+	MaxUsers    int32
+}
+
+// @desc: get users whose bookmark count is not refreshed since stale_before.
+// users tied to urls fetched since recent_since come first, then never refreshed users, then oldest refreshed users
+func (q *Queries) GetStaleUserNames(ctx context.Context, arg GetStaleUserNamesParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getStaleUserNames, arg.StaleBefore, arg.RecentSince, arg.MaxUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var user_name string
+		if err := rows.Scan(&user_name); err != nil {
+			return nil, err
+		}
+		items = append(items, user_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getURLsByFilter = `-- name: GetURLsByFilter :many
+SELECT
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+FROM
+  URLs u
+WHERE
+  u.is_deleted = FALSE
+  AND ($1::text IS NULL OR u.category_code = $1::text)
+  AND ($2::timestamp IS NULL OR u.created_at >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR u.created_at < $3::timestamp)
+ORDER BY
+  CASE WHEN $4::text = 'bookmark_count' THEN u.bookmark_count END DESC NULLS LAST,
+  CASE WHEN $4::text = 'private_user_rate' THEN u.private_user_rate END DESC NULLS LAST,
+  u.url_id
+LIMIT $6::int
+OFFSET $5::int
+`
+
+type GetURLsByFilterParams struct {
+	CategoryCode pgtype.Text
+	FromDate     pgtype.Timestamp
+	ToDate       pgtype.Timestamp
+	OrderBy      string
+	PageOffset   int32
+	PageLimit    pgtype.Int4
+}
+
+type GetURLsByFilterRow struct {
+	UrlID           int32
+	UrlAddress      string
+	UrlHash         string
+	CategoryCode    pgtype.Text
+	Title           pgtype.Text
+	BookmarkCount   pgtype.Int4
+	NamedUserCount  pgtype.Int4
+	PrivateUserRate pgtype.Float8
+}
+
+// @desc: get urls by category, created date range, order and pagination. null parameter means no filter
+func (q *Queries) GetURLsByFilter(ctx context.Context, arg GetURLsByFilterParams) ([]GetURLsByFilterRow, error) {
+	rows, err := q.db.Query(ctx, getURLsByFilter,
+		arg.CategoryCode,
+		arg.FromDate,
+		arg.ToDate,
+		arg.OrderBy,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetURLsByFilterRow
+	for rows.Next() {
+		var i GetURLsByFilterRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.UrlHash,
+			&i.CategoryCode,
+			&i.Title,
+			&i.BookmarkCount,
+			&i.NamedUserCount,
 			&i.PrivateUserRate,
 		); err != nil {
 			return nil, err
@@ -282,46 +847,587 @@ func (q *Queries) GetURLsByURLAddresses(ctx context.Context, dollar_1 []string)
 	return items, nil
 }
 
-const getUrlID = `-- name: GetUrlID :one
+const getURLsByPrivateRate = `-- name: GetURLsByPrivateRate :many
+SELECT
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+FROM 
+  URLs u
+WHERE 
+  private_user_rate >= $1 
+ORDER BY 
+  private_user_rate DESC
+`
+
+type GetURLsByPrivateRateRow struct {
+	UrlID           int32
+	UrlAddress      string
+	UrlHash         string
+	CategoryCode    pgtype.Text
+	Title           pgtype.Text
+	BookmarkCount   pgtype.Int4
+	NamedUserCount  pgtype.Int4
+	PrivateUserRate pgtype.Float8
+}
+
+// @desc: get urls by private_user_rate
+func (q *Queries) GetURLsByPrivateRate(ctx context.Context, privateUserRate pgtype.Float8) ([]GetURLsByPrivateRateRow, error) {
+	rows, err := q.db.Query(ctx, getURLsByPrivateRate, privateUserRate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetURLsByPrivateRateRow
+	for rows.Next() {
+		var i GetURLsByPrivateRateRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.UrlHash,
+			&i.CategoryCode,
+			&i.Title,
+			&i.BookmarkCount,
+			&i.NamedUserCount,
+			&i.PrivateUserRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getURLsByURLAddresses = `-- name: GetURLsByURLAddresses :many
+SELECT DISTINCT ON (u.url_address)
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+FROM
+  URLs u
+WHERE
+  u.is_deleted = FALSE
+AND
+  u.url_hash = ANY($1::text[])
+ORDER BY
+  u.url_address, u.url_id
+`
+
+type GetURLsByURLAddressesRow struct {
+	UrlID           int32
+	UrlAddress      string
+	UrlHash         string
+	CategoryCode    pgtype.Text
+	Title           pgtype.Text
+	BookmarkCount   pgtype.Int4
+	NamedUserCount  pgtype.Int4
+	PrivateUserRate pgtype.Float8
+}
+
+// @desc: get url information by hashes of url address
+func (q *Queries) GetURLsByURLAddresses(ctx context.Context, dollar_1 []string) ([]GetURLsByURLAddressesRow, error) {
+	rows, err := q.db.Query(ctx, getURLsByURLAddresses, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetURLsByURLAddressesRow
+	for rows.Next() {
+		var i GetURLsByURLAddressesRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.UrlHash,
+			&i.CategoryCode,
+			&i.Title,
+			&i.BookmarkCount,
+			&i.NamedUserCount,
+			&i.PrivateUserRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnfinishedFetchRunItems = `-- name: GetUnfinishedFetchRunItems :many
+SELECT
+  item
+FROM
+  FetchRunItems
+WHERE
+  run_id = $1
+  AND status <> 'done'
+ORDER BY
+  run_item_id
+`
+
+// @desc: get items of run which are not done yet. failed items are retried
+func (q *Queries) GetUnfinishedFetchRunItems(ctx context.Context, runID int32) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUnfinishedFetchRunItems, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUrlID = `-- name: GetUrlID :one
+SELECT
+  u.url_id
+FROM
+  URLs u
+WHERE
+  u.url_hash = $1
+`
+
+// @desc: get target url_id by hash of url address
+func (q *Queries) GetUrlID(ctx context.Context, urlHash string) (int32, error) {
+	row := q.db.QueryRow(ctx, getUrlID, urlHash)
+	var url_id int32
+	err := row.Scan(&url_id)
+	return url_id, err
+}
+
+const getUserBookmarkCountHistory = `-- name: GetUserBookmarkCountHistory :many
+SELECT
+  h.bookmark_count, h.observed_at
+FROM
+  UserBookmarkCountHistories h
+  INNER JOIN Users u ON h.user_id = u.user_id
+WHERE
+  u.user_name = $1
+ORDER BY
+  h.observed_at
+`
+
+type GetUserBookmarkCountHistoryRow struct {
+	BookmarkCount int32
+	ObservedAt    pgtype.Timestamp
+}
+
+// @desc: get observations of user's bookmark count in time order
+func (q *Queries) GetUserBookmarkCountHistory(ctx context.Context, userName string) ([]GetUserBookmarkCountHistoryRow, error) {
+	rows, err := q.db.Query(ctx, getUserBookmarkCountHistory, userName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserBookmarkCountHistoryRow
+	for rows.Next() {
+		var i GetUserBookmarkCountHistoryRow
+		if err := rows.Scan(&i.BookmarkCount, &i.ObservedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserBookmarkCountsByCategory = `-- name: GetUserBookmarkCountsByCategory :many
+SELECT
+  u.bookmark_count
+FROM
+  Users u
+  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND url.category_code = $1
+`
+
+// @desc: get bookmark count of users who bookmarked urls in category
+func (q *Queries) GetUserBookmarkCountsByCategory(ctx context.Context, categoryCode pgtype.Text) ([]pgtype.Int4, error) {
+	rows, err := q.db.Query(ctx, getUserBookmarkCountsByCategory, categoryCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.Int4
+	for rows.Next() {
+		var bookmark_count pgtype.Int4
+		if err := rows.Scan(&bookmark_count); err != nil {
+			return nil, err
+		}
+		items = append(items, bookmark_count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserByName = `-- name: GetUserByName :one
+SELECT
+  u.user_id, u.user_name, u.bookmark_count, u.is_deleted, u.created_at, u.updated_at
+FROM
+  Users u
+WHERE
+  u.user_name = $1
+`
+
+// @desc: get user by user name
+func (q *Queries) GetUserByName(ctx context.Context, userName string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByName, userName)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.UserName,
+		&i.BookmarkCount,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserGrowths = `-- name: GetUserGrowths :many
+SELECT
+  u.user_name,
+  (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at))[1]::int AS first_count,
+  (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at DESC))[1]::int AS last_count,
+  ((ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at DESC))[1]
+    - (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at))[1])::int AS growth,
+  COALESCE(MAX(h.jump), 0)::int AS max_jump,
+  MIN(h.observed_at)::timestamp AS first_observed_at,
+  MAX(h.observed_at)::timestamp AS last_observed_at,
+  COUNT(*)::int AS observation_count
+FROM
+  (
+    SELECT
+      user_id, bookmark_count, observed_at,
+      bookmark_count - LAG(bookmark_count) OVER (PARTITION BY user_id ORDER BY observed_at) AS jump
+    FROM
+      UserBookmarkCountHistories
+    WHERE
+      observed_at >= $1::timestamp
+  ) AS h
+  INNER JOIN Users u ON h.user_id = u.user_id
+WHERE
+  u.is_deleted = FALSE
+GROUP BY
+  u.user_name
+HAVING
+  COUNT(*) >= 2
+ORDER BY
+  growth DESC, max_jump DESC, u.user_name
+LIMIT $2::int
+`
+
+type GetUserGrowthsParams struct {
+	Since    pgtype.Timestamp
+	MaxUsers int32
+}
+
+type GetUserGrowthsRow struct {
+	UserName         string
+	FirstCount       int32
+	LastCount        int32
+	Growth           int32
+	MaxJump          int32
+	FirstObservedAt  pgtype.Timestamp
+	LastObservedAt   pgtype.Timestamp
+	ObservationCount int32
+}
+
+// @desc: get growth of user's bookmark count since given time. max_jump is max increase between consecutive observations
+func (q *Queries) GetUserGrowths(ctx context.Context, arg GetUserGrowthsParams) ([]GetUserGrowthsRow, error) {
+	rows, err := q.db.Query(ctx, getUserGrowths, arg.Since, arg.MaxUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserGrowthsRow
+	for rows.Next() {
+		var i GetUserGrowthsRow
+		if err := rows.Scan(
+			&i.UserName,
+			&i.FirstCount,
+			&i.LastCount,
+			&i.Growth,
+			&i.MaxJump,
+			&i.FirstObservedAt,
+			&i.LastObservedAt,
+			&i.ObservationCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNames = `-- name: GetUserNames :many
+SELECT
+  u.user_name
+FROM
+  Users u
+WHERE
+  u.is_deleted = FALSE
+`
+
+// @desc: get users
+func (q *Queries) GetUserNames(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var user_name string
+		if err := rows.Scan(&user_name); err != nil {
+			return nil, err
+		}
+		items = append(items, user_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNamesByURL = `-- name: GetUserNamesByURL :many
+SELECT
+  u.user_name
+FROM
+  Users u
+  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND url.url_hash = $1
+`
+
+// @desc: get target users by url
+func (q *Queries) GetUserNamesByURL(ctx context.Context, urlHash string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserNamesByURL, urlHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var user_name string
+		if err := rows.Scan(&user_name); err != nil {
+			return nil, err
+		}
+		items = append(items, user_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNamesByURLs = `-- name: GetUserNamesByURLs :many
+SELECT
+  u.user_name
+FROM
+  Users u
+  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND url.url_hash = ANY($1::text[])
+`
+
+// @desc: get target users by multiple urls
+func (q *Queries) GetUserNamesByURLs(ctx context.Context, dollar_1 []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserNamesByURLs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var user_name string
+		if err := rows.Scan(&user_name); err != nil {
+			return nil, err
+		}
+		items = append(items, user_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserURLsByUserName = `-- name: GetUserURLsByUserName :many
+SELECT
+  url.url_id, url.url_address, url.title, url.category_code,
+  uu.comment, uu.is_deleted, uu.removed_at, uu.created_at,
+  COALESCE(s.score, 0)::float AS score
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+  LEFT JOIN URLScores s ON uu.url_id = s.url_id
+WHERE
+  u.user_name = $1
+  AND url.is_deleted = FALSE
+ORDER BY
+  uu.created_at DESC, url.url_id DESC
+`
+
+type GetUserURLsByUserNameRow struct {
+	UrlID        int32
+	UrlAddress   string
+	Title        pgtype.Text
+	CategoryCode pgtype.Text
+	Comment      pgtype.Text
+	IsDeleted    pgtype.Bool
+	RemovedAt    pgtype.Timestamp
+	CreatedAt    pgtype.Timestamp
+	Score        float64
+}
+
+// @desc: get urls bookmarked by user including removed bookmarks. created_at is when bookmark was found first
+func (q *Queries) GetUserURLsByUserName(ctx context.Context, userName string) ([]GetUserURLsByUserNameRow, error) {
+	rows, err := q.db.Query(ctx, getUserURLsByUserName, userName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserURLsByUserNameRow
+	for rows.Next() {
+		var i GetUserURLsByUserNameRow
+		if err := rows.Scan(
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.Title,
+			&i.CategoryCode,
+			&i.Comment,
+			&i.IsDeleted,
+			&i.RemovedAt,
+			&i.CreatedAt,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersByURL = `-- name: GetUsersByURL :many
+SELECT
+  u.user_name, u.bookmark_count
+FROM
+  Users u
+  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND url.url_hash = $1
+ORDER BY
+  u.bookmark_count DESC
+`
+
+type GetUsersByURLRow struct {
+	UserName      string
+	BookmarkCount pgtype.Int4
+}
+
+// @desc: get target users by url
+func (q *Queries) GetUsersByURL(ctx context.Context, urlHash string) ([]GetUsersByURLRow, error) {
+	rows, err := q.db.Query(ctx, getUsersByURL, urlHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUsersByURLRow
+	for rows.Next() {
+		var i GetUsersByURLRow
+		if err := rows.Scan(&i.UserName, &i.BookmarkCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersSharingSuspiciousURLs = `-- name: GetUsersSharingSuspiciousURLs :many
 SELECT
-  u.url_id
+  u2.user_name, COUNT(DISTINCT uu2.url_id)::int AS shared_url_count
 FROM
-  URLs u
+  Users u1
+  INNER JOIN UserURLs uu1 ON u1.user_id = uu1.user_id
+  INNER JOIN URLScores s ON uu1.url_id = s.url_id
+  INNER JOIN UserURLs uu2 ON uu1.url_id = uu2.url_id AND uu1.user_id <> uu2.user_id
+  INNER JOIN Users u2 ON uu2.user_id = u2.user_id
 WHERE
-  u.url_address = $1
+  u1.user_name = $1
+  AND s.score >= $2::float
+  AND uu1.is_deleted = FALSE
+  AND uu2.is_deleted = FALSE
+  AND u2.is_deleted = FALSE
+GROUP BY
+  u2.user_name
+ORDER BY
+  shared_url_count DESC, u2.user_name
+LIMIT $3::int
 `
 
-// @desc: get target url_id by url address
-func (q *Queries) GetUrlID(ctx context.Context, urlAddress string) (int32, error) {
-	row := q.db.QueryRow(ctx, getUrlID, urlAddress)
-	var url_id int32
-	err := row.Scan(&url_id)
-	return url_id, err
+type GetUsersSharingSuspiciousURLsParams struct {
+	UserName string
+	MinScore float64
+	MaxUsers int32
 }
 
-const getUserNames = `-- name: GetUserNames :many
-SELECT
-  u.user_name
-FROM
-  Users u
-WHERE
-  u.is_deleted = FALSE
-`
+type GetUsersSharingSuspiciousURLsRow struct {
+	UserName       string
+	SharedUrlCount int32
+}
 
-// @desc: get users
-func (q *Queries) GetUserNames(ctx context.Context) ([]string, error) {
-	rows, err := q.db.Query(ctx, getUserNames)
+// @desc: get other users who bookmarked same suspicious urls as the user. suspicious url has score greater than or equal to min_score
+func (q *Queries) GetUsersSharingSuspiciousURLs(ctx context.Context, arg GetUsersSharingSuspiciousURLsParams) ([]GetUsersSharingSuspiciousURLsRow, error) {
+	rows, err := q.db.Query(ctx, getUsersSharingSuspiciousURLs, arg.UserName, arg.MinScore, arg.MaxUsers)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetUsersSharingSuspiciousURLsRow
 	for rows.Next() {
-		var user_name string
-		if err := rows.Scan(&user_name); err != nil {
+		var i GetUsersSharingSuspiciousURLsRow
+		if err := rows.Scan(&i.UserName, &i.SharedUrlCount); err != nil {
 			return nil, err
 		}
-		items = append(items, user_name)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -329,34 +1435,49 @@ func (q *Queries) GetUserNames(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
-const getUserNamesByURL = `-- name: GetUserNamesByURL :many
+const getWatchedURLs = `-- name: GetWatchedURLs :many
 SELECT
-  u.user_name
+  COALESCE(u.url_address, w.url_address)::text AS url_address, w.note, w.created_at,
+  COALESCE(u.title, '')::text AS title,
+  COALESCE(u.bookmark_count, 0)::int AS bookmark_count,
+  u.updated_at AS fetched_at
 FROM
-  Users u
-  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
-  INNER JOIN URLs url ON uu.url_id = url.url_id
-WHERE
-  u.is_deleted = FALSE
-  AND url.is_deleted = FALSE
-  AND uu.is_deleted = FALSE
-  AND url.url_address = $1
+  WatchedURLs w
+  LEFT JOIN URLs u ON w.url_hash = u.url_hash
+ORDER BY
+  w.watched_url_id
 `
 
-// @desc: get target users by url
-func (q *Queries) GetUserNamesByURL(ctx context.Context, urlAddress string) ([]string, error) {
-	rows, err := q.db.Query(ctx, getUserNamesByURL, urlAddress)
+type GetWatchedURLsRow struct {
+	UrlAddress    string
+	Note          pgtype.Text
+	CreatedAt     pgtype.Timestamp
+	Title         string
+	BookmarkCount int32
+	FetchedAt     pgtype.Timestamp
+}
+
+// @desc: get watched urls with stored data of url if it's fetched. stored address has priority
+func (q *Queries) GetWatchedURLs(ctx context.Context) ([]GetWatchedURLsRow, error) {
+	rows, err := q.db.Query(ctx, getWatchedURLs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetWatchedURLsRow
 	for rows.Next() {
-		var user_name string
-		if err := rows.Scan(&user_name); err != nil {
+		var i GetWatchedURLsRow
+		if err := rows.Scan(
+			&i.UrlAddress,
+			&i.Note,
+			&i.CreatedAt,
+			&i.Title,
+			&i.BookmarkCount,
+			&i.FetchedAt,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, user_name)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -364,34 +1485,51 @@ func (q *Queries) GetUserNamesByURL(ctx context.Context, urlAddress string) ([]s
 	return items, nil
 }
 
-const getUserNamesByURLs = `-- name: GetUserNamesByURLs :many
+const getWatchedUserNewBookmarks = `-- name: GetWatchedUserNewBookmarks :many
 SELECT
-  u.user_name
+  w.user_name, u.url_id, u.url_address, u.title, uu.comment, uu.created_at
 FROM
-  Users u
-  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
-  INNER JOIN URLs url ON uu.url_id = url.url_id
+  WatchedUsers w
+  INNER JOIN Users us ON w.user_name = us.user_name
+  INNER JOIN UserURLs uu ON us.user_id = uu.user_id
+  INNER JOIN URLs u ON uu.url_id = u.url_id
 WHERE
-  u.is_deleted = FALSE
-  AND url.is_deleted = FALSE
-  AND uu.is_deleted = FALSE
-  AND url.url_address = ANY($1::text[])
+  uu.is_deleted = FALSE
+  AND uu.created_at >= GREATEST(w.created_at, $1::timestamp)
+ORDER BY
+  uu.created_at DESC, w.user_name
 `
 
-// @desc: get target users by multiple urls
-func (q *Queries) GetUserNamesByURLs(ctx context.Context, dollar_1 []string) ([]string, error) {
-	rows, err := q.db.Query(ctx, getUserNamesByURLs, dollar_1)
+type GetWatchedUserNewBookmarksRow struct {
+	UserName   string
+	UrlID      int32
+	UrlAddress string
+	Title      pgtype.Text
+	Comment    pgtype.Text
+	CreatedAt  pgtype.Timestamp
+}
+
+// @desc: get bookmarks of watched users stored after they are watched and since given time
+func (q *Queries) GetWatchedUserNewBookmarks(ctx context.Context, since pgtype.Timestamp) ([]GetWatchedUserNewBookmarksRow, error) {
+	rows, err := q.db.Query(ctx, getWatchedUserNewBookmarks, since)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetWatchedUserNewBookmarksRow
 	for rows.Next() {
-		var user_name string
-		if err := rows.Scan(&user_name); err != nil {
+		var i GetWatchedUserNewBookmarksRow
+		if err := rows.Scan(
+			&i.UserName,
+			&i.UrlID,
+			&i.UrlAddress,
+			&i.Title,
+			&i.Comment,
+			&i.CreatedAt,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, user_name)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -399,38 +1537,48 @@ func (q *Queries) GetUserNamesByURLs(ctx context.Context, dollar_1 []string) ([]
 	return items, nil
 }
 
-const getUsersByURL = `-- name: GetUsersByURL :many
+const getWatchedUsers = `-- name: GetWatchedUsers :many
 SELECT
-  u.user_name, u.bookmark_count
+  w.user_name, w.note, w.created_at,
+  COALESCE(us.bookmark_count, 0)::int AS bookmark_count,
+  COUNT(uu.user_url_id)::int AS new_bookmark_count
 FROM
-  Users u
-  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
-  INNER JOIN URLs url ON uu.url_id = url.url_id
-WHERE
-  u.is_deleted = FALSE
-  AND url.is_deleted = FALSE
-  AND uu.is_deleted = FALSE
-  AND url.url_address = $1
+  WatchedUsers w
+  LEFT JOIN Users us ON w.user_name = us.user_name
+  LEFT JOIN UserURLs uu ON us.user_id = uu.user_id
+    AND uu.is_deleted = FALSE
+    AND uu.created_at >= w.created_at
+GROUP BY
+  w.watched_user_id, us.bookmark_count
 ORDER BY
-  u.bookmark_count DESC
+  w.watched_user_id
 `
 
-type GetUsersByURLRow struct {
-	UserName      string
-	BookmarkCount pgtype.Int4
+type GetWatchedUsersRow struct {
+	UserName         string
+	Note             pgtype.Text
+	CreatedAt        pgtype.Timestamp
+	BookmarkCount    int32
+	NewBookmarkCount int32
 }
 
-// @desc: get target users by url
-func (q *Queries) GetUsersByURL(ctx context.Context, urlAddress string) ([]GetUsersByURLRow, error) {
-	rows, err := q.db.Query(ctx, getUsersByURL, urlAddress)
+// @desc: get watched users with bookmark count and count of bookmarks stored after user is watched
+func (q *Queries) GetWatchedUsers(ctx context.Context) ([]GetWatchedUsersRow, error) {
+	rows, err := q.db.Query(ctx, getWatchedUsers)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetUsersByURLRow
+	var items []GetWatchedUsersRow
 	for rows.Next() {
-		var i GetUsersByURLRow
-		if err := rows.Scan(&i.UserName, &i.BookmarkCount); err != nil {
+		var i GetWatchedUsersRow
+		if err := rows.Scan(
+			&i.UserName,
+			&i.Note,
+			&i.CreatedAt,
+			&i.BookmarkCount,
+			&i.NewBookmarkCount,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -441,26 +1589,127 @@ func (q *Queries) GetUsersByURL(ctx context.Context, urlAddress string) ([]GetUs
 	return items, nil
 }
 
+const incrementApiKeyUsage = `-- name: IncrementApiKeyUsage :one
+INSERT INTO ApiKeyUsages (api_key_id, usage_date, request_count)
+VALUES ($1, $2::date, 1)
+ON CONFLICT (api_key_id, usage_date)
+DO UPDATE SET
+  request_count = ApiKeyUsages.request_count + 1
+WHERE
+  $3::int = 0 OR ApiKeyUsages.request_count < $3::int
+RETURNING
+  request_count
+`
+
+type IncrementApiKeyUsageParams struct {
+	ApiKeyID   int32
+	UsageDate  pgtype.Date
+	DailyQuota int32
+}
+
+// @desc: count request of API key per day. no row is returned when daily quota is exceeded
+func (q *Queries) IncrementApiKeyUsage(ctx context.Context, arg IncrementApiKeyUsageParams) (int32, error) {
+	row := q.db.QueryRow(ctx, incrementApiKeyUsage, arg.ApiKeyID, arg.UsageDate, arg.DailyQuota)
+	var request_count int32
+	err := row.Scan(&request_count)
+	return request_count, err
+}
+
+const insertAlert = `-- name: InsertAlert :exec
+INSERT INTO Alerts (url_id, rule, value, threshold)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertAlertParams struct {
+	UrlID     int32
+	Rule      string
+	Value     float64
+	Threshold float64
+}
+
+// @desc: record alert sent for url
+func (q *Queries) InsertAlert(ctx context.Context, arg InsertAlertParams) error {
+	_, err := q.db.Exec(ctx, insertAlert,
+		arg.UrlID,
+		arg.Rule,
+		arg.Value,
+		arg.Threshold,
+	)
+	return err
+}
+
+const insertApiKey = `-- name: InsertApiKey :one
+INSERT INTO ApiKeys (name, key_prefix, key_hash, scope, rate_limit, daily_quota)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING
+  api_key_id
+`
+
+type InsertApiKeyParams struct {
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scope      string
+	RateLimit  int32
+	DailyQuota int32
+}
+
+// @desc: issue API key. only hash of key is stored
+func (q *Queries) InsertApiKey(ctx context.Context, arg InsertApiKeyParams) (int32, error) {
+	row := q.db.QueryRow(ctx, insertApiKey,
+		arg.Name,
+		arg.KeyPrefix,
+		arg.KeyHash,
+		arg.Scope,
+		arg.RateLimit,
+		arg.DailyQuota,
+	)
+	var api_key_id int32
+	err := row.Scan(&api_key_id)
+	return api_key_id, err
+}
+
+const insertFetchRun = `-- name: InsertFetchRun :one
+INSERT INTO FetchRuns (command)
+VALUES ($1)
+RETURNING
+  run_id
+`
+
+// @desc: start run of fetch command
+func (q *Queries) InsertFetchRun(ctx context.Context, command string) (int32, error) {
+	row := q.db.QueryRow(ctx, insertFetchRun, command)
+	var run_id int32
+	err := row.Scan(&run_id)
+	return run_id, err
+}
+
+type InsertFetchRunItemsParams struct {
+	RunID int32
+	Item  string
+}
+
 const insertURL = `-- name: InsertURL :one
 WITH insert_result AS (
-	INSERT INTO URLs (url_address, category_code)
-	VALUES ($1, $2)
-	ON CONFLICT (url_address, category_code) DO NOTHING
+	INSERT INTO URLs (url_address, url_hash, category_code)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (url_hash) DO NOTHING
 	RETURNING url_id
 )
 SELECT url_id FROM insert_result
 UNION ALL
-SELECT url_id FROM URLs WHERE url_address = $1 AND category_code = $2 LIMIT 1
+SELECT url_id FROM URLs WHERE url_hash = $2 LIMIT 1
 `
 
 type InsertURLParams struct {
 	UrlAddress   string
+	UrlHash      string
 	CategoryCode pgtype.Text
 }
 
 // @desc: Deprecated. insert url if not existed and return url_id
 func (q *Queries) InsertURL(ctx context.Context, arg InsertURLParams) (int32, error) {
-	row := q.db.QueryRow(ctx, insertURL, arg.UrlAddress, arg.CategoryCode)
+	row := q.db.QueryRow(ctx, insertURL, arg.UrlAddress, arg.UrlHash, arg.CategoryCode)
 	var url_id int32
 	err := row.Scan(&url_id)
 	return url_id, err
@@ -468,6 +1717,7 @@ func (q *Queries) InsertURL(ctx context.Context, arg InsertURLParams) (int32, er
 
 type InsertURLsParams struct {
 	UrlAddress   string
+	UrlHash      string
 	CategoryCode pgtype.Text
 }
 
@@ -489,6 +1739,179 @@ func (q *Queries) InsertUser(ctx context.Context, userName string) (int32, error
 	return user_id, err
 }
 
+const insertUserBookmarkCountHistory = `-- name: InsertUserBookmarkCountHistory :exec
+INSERT INTO UserBookmarkCountHistories (user_id, bookmark_count)
+VALUES ($1, $2)
+`
+
+type InsertUserBookmarkCountHistoryParams struct {
+	UserID        int32
+	BookmarkCount int32
+}
+
+// @desc: insert observation of user's bookmark count
+func (q *Queries) InsertUserBookmarkCountHistory(ctx context.Context, arg InsertUserBookmarkCountHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertUserBookmarkCountHistory, arg.UserID, arg.BookmarkCount)
+	return err
+}
+
+type InsertUserURLStagingsParams struct {
+	UrlID     int32
+	UserName  string
+	Comment   pgtype.Text
+	IsDeleted pgtype.Bool
+}
+
+const insertWatchedURL = `-- name: InsertWatchedURL :execrows
+INSERT INTO WatchedURLs (url_address, url_hash, note)
+VALUES ($1, $2, $3)
+ON CONFLICT (url_hash) DO NOTHING
+`
+
+type InsertWatchedURLParams struct {
+	UrlAddress string
+	UrlHash    string
+	Note       pgtype.Text
+}
+
+// @desc: watch url. no row is inserted if url is already watched
+func (q *Queries) InsertWatchedURL(ctx context.Context, arg InsertWatchedURLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertWatchedURL, arg.UrlAddress, arg.UrlHash, arg.Note)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertWatchedUser = `-- name: InsertWatchedUser :execrows
+INSERT INTO WatchedUsers (user_name, note)
+VALUES ($1, $2)
+ON CONFLICT (user_name) DO NOTHING
+`
+
+type InsertWatchedUserParams struct {
+	UserName string
+	Note     pgtype.Text
+}
+
+// @desc: watch user. no row is inserted if user is already watched
+func (q *Queries) InsertWatchedUser(ctx context.Context, arg InsertWatchedUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertWatchedUser, arg.UserName, arg.Note)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const mergeUserURLs = `-- name: MergeUserURLs :exec
+INSERT INTO UserURLs (user_id, url_id, comment, is_deleted, removed_at)
+SELECT
+  uu.user_id, $1::int, uu.comment, uu.is_deleted, uu.removed_at
+FROM
+  UserURLs uu
+WHERE
+  uu.url_id = $2::int
+ON CONFLICT (user_id, url_id)
+DO UPDATE SET
+    comment = COALESCE(NULLIF(UserURLs.comment, ''), EXCLUDED.comment),
+    is_deleted = UserURLs.is_deleted AND EXCLUDED.is_deleted,
+    removed_at = CASE
+      WHEN UserURLs.is_deleted AND EXCLUDED.is_deleted THEN LEAST(UserURLs.removed_at, EXCLUDED.removed_at)
+    END
+`
+
+type MergeUserURLsParams struct {
+	ToUrlID   int32
+	FromUrlID int32
+}
+
+// @desc: move UserURLs of duplicated url to kept url. non-empty comment of kept url has priority
+func (q *Queries) MergeUserURLs(ctx context.Context, arg MergeUserURLsParams) error {
+	_, err := q.db.Exec(ctx, mergeUserURLs, arg.ToUrlID, arg.FromUrlID)
+	return err
+}
+
+const moveAlerts = `-- name: MoveAlerts :exec
+UPDATE Alerts
+  SET url_id = $1::int
+WHERE url_id = $2::int
+`
+
+type MoveAlertsParams struct {
+	ToUrlID   int32
+	FromUrlID int32
+}
+
+// @desc: move alerts of duplicated url to kept url to keep deduplication
+func (q *Queries) MoveAlerts(ctx context.Context, arg MoveAlertsParams) error {
+	_, err := q.db.Exec(ctx, moveAlerts, arg.ToUrlID, arg.FromUrlID)
+	return err
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :execrows
+UPDATE ApiKeys
+  SET is_revoked = TRUE, revoked_at = CURRENT_TIMESTAMP
+WHERE api_key_id = $1 AND is_revoked = FALSE
+`
+
+// @desc: revoke API key. revoked key is kept to show history
+func (q *Queries) RevokeApiKey(ctx context.Context, apiKeyID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeApiKey, apiKeyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateApiKeyLastUsed = `-- name: UpdateApiKeyLastUsed :exec
+UPDATE ApiKeys
+  SET last_used_at = CURRENT_TIMESTAMP
+WHERE api_key_id = $1
+`
+
+// @desc: record last time API key was used
+func (q *Queries) UpdateApiKeyLastUsed(ctx context.Context, apiKeyID int32) error {
+	_, err := q.db.Exec(ctx, updateApiKeyLastUsed, apiKeyID)
+	return err
+}
+
+const updateFetchRunItemStatus = `-- name: UpdateFetchRunItemStatus :exec
+UPDATE FetchRunItems
+  SET status = $3, updated_at = CURRENT_TIMESTAMP
+WHERE run_id = $1 AND item = $2
+`
+
+type UpdateFetchRunItemStatusParams struct {
+	RunID  int32
+	Item   string
+	Status string
+}
+
+// @desc: update progress of item in run
+func (q *Queries) UpdateFetchRunItemStatus(ctx context.Context, arg UpdateFetchRunItemStatusParams) error {
+	_, err := q.db.Exec(ctx, updateFetchRunItemStatus, arg.RunID, arg.Item, arg.Status)
+	return err
+}
+
+const updateFetchRunStatus = `-- name: UpdateFetchRunStatus :exec
+UPDATE FetchRuns
+  SET status = $1,
+  updated_at = CURRENT_TIMESTAMP,
+  finished_at = CASE WHEN $1 = 'running' THEN NULL ELSE CURRENT_TIMESTAMP END
+WHERE run_id = $2
+`
+
+type UpdateFetchRunStatusParams struct {
+	Status string
+	RunID  int32
+}
+
+// @desc: update status of run. finished_at is set when run is not running
+func (q *Queries) UpdateFetchRunStatus(ctx context.Context, arg UpdateFetchRunStatusParams) error {
+	_, err := q.db.Exec(ctx, updateFetchRunStatus, arg.Status, arg.RunID)
+	return err
+}
+
 const updateURL = `-- name: UpdateURL :execrows
 UPDATE URLs
 SET
@@ -523,6 +1946,25 @@ func (q *Queries) UpdateURL(ctx context.Context, arg UpdateURLParams) (int64, er
 	return result.RowsAffected(), nil
 }
 
+const updateURLHash = `-- name: UpdateURLHash :exec
+UPDATE URLs
+SET
+    url_hash = $2
+WHERE
+    url_id = $1
+`
+
+type UpdateURLHashParams struct {
+	UrlID   int32
+	UrlHash string
+}
+
+// @desc: update url hash with hash of normalized address. url address is kept as it is
+func (q *Queries) UpdateURLHash(ctx context.Context, arg UpdateURLHashParams) error {
+	_, err := q.db.Exec(ctx, updateURLHash, arg.UrlID, arg.UrlHash)
+	return err
+}
+
 const updateUserBookmarkCount = `-- name: UpdateUserBookmarkCount :one
 UPDATE Users
   SET bookmark_count = $1, updated_at = CURRENT_TIMESTAMP
@@ -544,14 +1986,26 @@ func (q *Queries) UpdateUserBookmarkCount(ctx context.Context, arg UpdateUserBoo
 	return user_id, err
 }
 
+const updateUserDeleted = `-- name: UpdateUserDeleted :exec
+UPDATE Users
+  SET is_deleted = TRUE, updated_at = CURRENT_TIMESTAMP
+WHERE user_name = $1
+`
+
+// @desc: mark user as deleted when user's page no longer exists
+func (q *Queries) UpdateUserDeleted(ctx context.Context, userName string) error {
+	_, err := q.db.Exec(ctx, updateUserDeleted, userName)
+	return err
+}
+
 const upsertURL = `-- name: UpsertURL :one
-INSERT INTO URLs (url_address, title, bookmark_count, named_user_count, private_user_rate) 
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (url_address) 
+INSERT INTO URLs (url_address, url_hash, title, bookmark_count, named_user_count, private_user_rate) 
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (url_hash) 
 DO UPDATE SET
-    bookmark_count = $3,
-    named_user_count = $4,
-    private_user_rate = $5,
+    bookmark_count = $4,
+    named_user_count = $5,
+    private_user_rate = $6,
     is_deleted = FALSE,
     updated_at = EXCLUDED.updated_at 
 RETURNING url_id
@@ -559,6 +2013,7 @@ RETURNING url_id
 
 type UpsertURLParams struct {
 	UrlAddress      string
+	UrlHash         string
 	Title           pgtype.Text
 	BookmarkCount   pgtype.Int4
 	NamedUserCount  pgtype.Int4
@@ -569,6 +2024,7 @@ type UpsertURLParams struct {
 func (q *Queries) UpsertURL(ctx context.Context, arg UpsertURLParams) (int32, error) {
 	row := q.db.QueryRow(ctx, upsertURL,
 		arg.UrlAddress,
+		arg.UrlHash,
 		arg.Title,
 		arg.BookmarkCount,
 		arg.NamedUserCount,
@@ -579,40 +2035,92 @@ func (q *Queries) UpsertURL(ctx context.Context, arg UpsertURLParams) (int32, er
 	return url_id, err
 }
 
-const upsertUser = `-- name: UpsertUser :one
-INSERT INTO Users (user_name) 
-VALUES ($1)
-ON CONFLICT (user_name) 
-DO UPDATE SET 
-    is_deleted = FALSE,
-    updated_at = EXCLUDED.updated_at 
-RETURNING user_id
+const upsertURLScore = `-- name: UpsertURLScore :exec
+INSERT INTO URLScores (
+  url_id, score, private_user_rate, new_user_rate, deleted_user_rate, burstiness, user_overlap
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (url_id)
+DO UPDATE SET
+    score = EXCLUDED.score,
+    private_user_rate = EXCLUDED.private_user_rate,
+    new_user_rate = EXCLUDED.new_user_rate,
+    deleted_user_rate = EXCLUDED.deleted_user_rate,
+    burstiness = EXCLUDED.burstiness,
+    user_overlap = EXCLUDED.user_overlap
 `
 
-// @desc: insert user if not existed, update user with is_deleted=false if existed
-func (q *Queries) UpsertUser(ctx context.Context, userName string) (int32, error) {
-	row := q.db.QueryRow(ctx, upsertUser, userName)
-	var user_id int32
-	err := row.Scan(&user_id)
-	return user_id, err
+type UpsertURLScoreParams struct {
+	UrlID           int32
+	Score           pgtype.Float8
+	PrivateUserRate pgtype.Float8
+	NewUserRate     pgtype.Float8
+	DeletedUserRate pgtype.Float8
+	Burstiness      pgtype.Float8
+	UserOverlap     pgtype.Float8
 }
 
-const upsertUserURLs = `-- name: UpsertUserURLs :exec
-INSERT INTO UserURLs (user_id, url_id) 
-VALUES ($1, $2)
-ON CONFLICT (user_id, url_id) 
-DO UPDATE SET 
-    is_deleted = FALSE,
+// @desc: insert or update suspicion score of url
+func (q *Queries) UpsertURLScore(ctx context.Context, arg UpsertURLScoreParams) error {
+	_, err := q.db.Exec(ctx, upsertURLScore,
+		arg.UrlID,
+		arg.Score,
+		arg.PrivateUserRate,
+		arg.NewUserRate,
+		arg.DeletedUserRate,
+		arg.Burstiness,
+		arg.UserOverlap,
+	)
+	return err
+}
+
+const upsertUserURLsFromStagings = `-- name: UpsertUserURLsFromStagings :exec
+INSERT INTO UserURLs (user_id, url_id, comment, is_deleted, removed_at)
+SELECT
+  u.user_id, s.url_id, s.comment, s.is_deleted,
+  CASE WHEN s.is_deleted THEN CURRENT_TIMESTAMP END
+FROM
+  UserURLStagings s
+  INNER JOIN Users u ON s.user_name = u.user_name
+WHERE
+  s.url_id = $1
+ORDER BY
+  u.user_id
+ON CONFLICT (user_id, url_id)
+DO UPDATE SET
+    comment = EXCLUDED.comment,
+    is_deleted = EXCLUDED.is_deleted,
+    removed_at = CASE
+      WHEN EXCLUDED.is_deleted THEN COALESCE(UserURLs.removed_at, EXCLUDED.removed_at)
+    END,
     updated_at = EXCLUDED.updated_at
 `
 
-type UpsertUserURLsParams struct {
-	UserID int32
-	UrlID  int32
+// @desc: upsert UserURLs in staging table of url. comment is overwritten with fetched one, so edited or cleared comment is reflected.
+// removed_at keeps first detected time while bookmark is removed, and is cleared when user bookmarks again
+func (q *Queries) UpsertUserURLsFromStagings(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, upsertUserURLsFromStagings, urlID)
+	return err
 }
 
-// @desc: insert UserURLs if not existed, update UserURLs with is_deleted=false if existed
-func (q *Queries) UpsertUserURLs(ctx context.Context, arg UpsertUserURLsParams) error {
-	_, err := q.db.Exec(ctx, upsertUserURLs, arg.UserID, arg.UrlID)
+const upsertUsersFromStagings = `-- name: UpsertUsersFromStagings :exec
+INSERT INTO Users (user_name)
+SELECT DISTINCT
+  s.user_name
+FROM
+  UserURLStagings s
+WHERE
+  s.url_id = $1
+ORDER BY
+  s.user_name
+ON CONFLICT (user_name) DO NOTHING
+`
+
+// @desc: insert Users in staging table of url. users are sorted to keep lock order among concurrent transactions
+// existing users are not updated, so that updated_at keeps when bookmark_count was refreshed.
+// deletion is set only when user's page returns 404 and is never cleared here,
+// because staging includes users who removed bookmark and are kept in MongoDB forever
+func (q *Queries) UpsertUsersFromStagings(ctx context.Context, urlID int32) error {
+	_, err := q.db.Exec(ctx, upsertUsersFromStagings, urlID)
 	return err
 }
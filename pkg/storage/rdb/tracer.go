@@ -0,0 +1,88 @@
+package rdb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+//
+// Tracing of PostgreSQL calls
+//
+
+// queryTracer creates child span of caller for each query and copy
+// span name is taken from sqlc query name such as `-- name: GetUserByName :one`
+type queryTracer struct {
+	tracer oteltrace.Tracer
+}
+
+func newQueryTracer() *queryTracer {
+	return &queryTracer{
+		tracer: otel.Tracer("github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"),
+	}
+}
+
+func (q *queryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	ctx, _ = q.tracer.Start(ctx, "postgres:"+queryName(data.SQL),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+		),
+	)
+	return ctx
+}
+
+func (q *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	endSpan(ctx, data.Err)
+}
+
+func (q *queryTracer) TraceCopyFromStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceCopyFromStartData,
+) context.Context {
+	ctx, _ = q.tracer.Start(ctx, "postgres:copy "+data.TableName.Sanitize(),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+		oteltrace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.StringSlice("db.copy.columns", data.ColumnNames),
+		),
+	)
+	return ctx
+}
+
+func (q *queryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	endSpan(ctx, data.Err)
+}
+
+func endSpan(ctx context.Context, err error) {
+	span := oteltrace.SpanFromContext(ctx)
+	if err != nil && !IsNoRows(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryName returns sqlc query name, or `query` if sql is not generated by sqlc
+func queryName(sql string) string {
+	const prefix = "-- name: "
+	if !strings.HasPrefix(sql, prefix) {
+		return "query"
+	}
+	fields := strings.Fields(strings.TrimPrefix(sql, prefix))
+	if len(fields) == 0 {
+		return "query"
+	}
+	return fields[0]
+}
@@ -0,0 +1,120 @@
+package tracer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// OTLPConfig is connection setting of OTLP exporter
+type OTLPConfig struct {
+	Endpoint string            // host:port of collector
+	Headers  map[string]string // e.g. API key required by collector
+	Insecure bool              // disable TLS
+	CAFile   string            // CA certificate to verify collector. system CA is used if empty
+}
+
+// OTLP Provider for any collector (HTTP)
+// - endpoint: localhost:4318
+func NewOTLPHTTPProvider(
+	conf *OTLPConfig,
+	res *Resource,
+	tracerName string,
+	sampler sdktrace.Sampler,
+) (*SDKProvider, error) {
+	traceExporter, err := newOTLPHTTPExporter(conf)
+	if err != nil {
+		return nil, err
+	}
+	return newSDKProvider(traceExporter, res, tracerName, sampler, nil), nil
+}
+
+// OTLP Provider for any collector (gRPC)
+// - endpoint: localhost:4317
+func NewOTLPGRPCProvider(
+	conf *OTLPConfig,
+	res *Resource,
+	tracerName string,
+	sampler sdktrace.Sampler,
+) (*SDKProvider, error) {
+	traceExporter, err := newOTLPGRPCExporter(conf)
+	if err != nil {
+		return nil, err
+	}
+	return newSDKProvider(traceExporter, res, tracerName, sampler, nil), nil
+}
+
+func newOTLPHTTPExporter(conf *OTLPConfig) (*otlptrace.Exporter, error) {
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(conf.Endpoint),
+	}
+	if len(conf.Headers) != 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(conf.Headers))
+	}
+	if conf.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else {
+		tlsConf, err := newTLSConfig(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConf))
+	}
+
+	traceExporter, err := otlptrace.New(context.Background(), otlptracehttp.NewClient(opts...))
+	if err != nil {
+		return nil, errors.New("failed to create OTLP HTTP trace exporter")
+	}
+	return traceExporter, nil
+}
+
+func newOTLPGRPCExporter(conf *OTLPConfig) (*otlptrace.Exporter, error) {
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(conf.Endpoint),
+	}
+	if len(conf.Headers) != 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(conf.Headers))
+	}
+	if conf.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		tlsConf, err := newTLSConfig(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConf)))
+	}
+
+	traceExporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, errors.New("failed to create OTLP gRPC trace exporter")
+	}
+	return traceExporter, nil
+}
+
+// caFile allows empty, then system CA is used
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConf, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracer CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate is found in tracer CA file: %s", caFile)
+	}
+	tlsConf.RootCAs = pool
+	return tlsConf, nil
+}
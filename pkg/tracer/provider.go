@@ -0,0 +1,54 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SDKProvider is tracer backed by OpenTelemetry SDK. exporter decides where spans are sent
+type SDKProvider struct {
+	tp     *sdktrace.TracerProvider
+	tracer oteltrace.Tracer
+	// called after tracer provider is shut down e.g. closing file
+	closer func() error
+}
+
+func newSDKProvider(
+	exporter sdktrace.SpanExporter,
+	res *Resource,
+	tracerName string,
+	sampler sdktrace.Sampler,
+	propagator propagation.TextMapPropagator,
+) *SDKProvider {
+	// define tracer provider
+	tp := tracerProvider(exporter, res, sampler)
+
+	// set OpenTelemetry as global telemetry
+	setGlobalTelemtetry(tp, propagator)
+
+	return &SDKProvider{
+		tp:     tp,
+		tracer: tp.Tracer(tracerName),
+	}
+}
+
+func (s *SDKProvider) NewSpan(
+	ctx context.Context,
+	name string,
+	opts ...oteltrace.SpanStartOption,
+) (context.Context, oteltrace.Span) {
+	return s.tracer.Start(ctx, name, opts...)
+}
+
+// Note: must be called before main is done
+func (s *SDKProvider) Close(ctx context.Context) error {
+	err := s.tp.Shutdown(ctx)
+	if s.closer != nil {
+		err = errors.Join(err, s.closer())
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package tracer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Stdout Provider for offline debugging
+// spans are written as JSON to stdout, or appended to file if file is given
+func NewStdoutProvider(
+	file string,
+	res *Resource,
+	tracerName string,
+	sampler sdktrace.Sampler,
+) (*SDKProvider, error) {
+	var (
+		writer io.Writer = os.Stdout
+		closer func() error
+	)
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tracer file: %w", err)
+		}
+		writer = f
+		closer = f.Close
+	}
+
+	traceExporter, err := stdouttrace.New(stdouttrace.WithWriter(writer), stdouttrace.WithPrettyPrint())
+	if err != nil {
+		if closer != nil {
+			_ = closer()
+		}
+		return nil, errors.New("failed to create stdout trace exporter")
+	}
+
+	provider := newSDKProvider(traceExporter, res, tracerName, sampler, nil)
+	provider.closer = closer
+	return provider, nil
+}
@@ -2,8 +2,11 @@ package tracer
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -30,27 +33,98 @@ const (
 	TracerModeNOOP       TracerMode = iota // no tracer
 	TracerModeJaegerHTTP                   // Jaeger with HTTP
 	TracerModeJaegerGRPC                   // Jaeger with gRPC
-	TracerModeDataDog                      // Datadog
+	TracerModeOTLPHTTP                     // any OTLP collector with HTTP
+	TracerModeOTLPGRPC                     // any OTLP collector with gRPC
+	TracerModeDataDog                      // Datadog Agent with OTLP ingestion
+	TracerModeStdout                       // stdout or file for offline debugging
 )
 
+// TracerJaegerDeprecated is former value of Jaeger tracer which is alias of jaeger_http
+const TracerJaegerDeprecated = "jaeger"
+
 func ValidateTracerEnv(value string) TracerMode {
 	switch value {
-	case "jaeger_http":
+	case "jaeger_http", TracerJaegerDeprecated:
 		return TracerModeJaegerHTTP
 	case "jaeger_grpc":
 		return TracerModeJaegerGRPC
+	case "otlp", "otlp_http":
+		return TracerModeOTLPHTTP
+	case "otlp_grpc":
+		return TracerModeOTLPGRPC
 	case "datadog":
 		return TracerModeDataDog
+	case "stdout":
+		return TracerModeStdout
 	default:
 		return TracerModeNOOP
 	}
 }
 
+// DefaultEndpoint returns default endpoint of collector for mode
+func (t TracerMode) DefaultEndpoint() string {
+	switch t {
+	case TracerModeJaegerHTTP, TracerModeOTLPHTTP, TracerModeDataDog:
+		return "localhost:4318"
+	case TracerModeJaegerGRPC, TracerModeOTLPGRPC:
+		return "localhost:4317"
+	default:
+		return ""
+	}
+}
+
+// Resource describes service which produces spans
+type Resource struct {
+	ServiceName string
+	Version     string
+	Attributes  map[string]string // e.g. deployment.environment
+}
+
+func (r *Resource) toResource() *resource.Resource {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(r.ServiceName),
+		semconv.ServiceVersionKey.String(r.Version),
+	}
+	// sort keys to keep attributes in same order
+	keys := make([]string, 0, len(r.Attributes))
+	for key := range r.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attrs = append(attrs, attribute.String(key, r.Attributes[key]))
+	}
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
+
+// NewSampler returns sampler by name which is same as OTEL_TRACES_SAMPLER
+// - always_on, always_off, traceidratio, parentbased_always_on, parentbased_traceidratio
+// ratio is used by ratio based sampler and must be in [0, 1]
+func NewSampler(name string, ratio float64) (sdktrace.Sampler, error) {
+	if ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("sample ratio must be between 0 and 1: %v", ratio)
+	}
+	switch name {
+	case "", "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_traceidratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("sampler is invalid: %s", name)
+	}
+}
+
 // define tracer provider
 // sampler allows nil
 func tracerProvider(
 	traceExporter sdktrace.SpanExporter,
-	serviceName, version string,
+	res *Resource,
 	sampler sdktrace.Sampler,
 ) *sdktrace.TracerProvider {
 	if sampler == nil {
@@ -60,11 +134,7 @@ func tracerProvider(
 	return sdktrace.NewTracerProvider(
 		sdktrace.WithSampler(sampler), // set sampling
 		sdktrace.WithBatcher(traceExporter),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(serviceName),
-			semconv.ServiceVersionKey.String(version)),
-		),
+		sdktrace.WithResource(res.toResource()),
 	)
 }
 
@@ -0,0 +1,168 @@
+package urlnorm
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+//
+// URL normalization for deduplication
+// normalized URL is used only as unique key. original URL is stored and fetched,
+// because Hatena matches entries by exact URL
+//
+
+// MaxLength is max length of url
+// url_address column has no limit, but too long url is treated as invalid
+const MaxLength = 4096
+
+var (
+	ErrEmpty       = errors.New("url is empty")
+	ErrInvalid     = errors.New("url is invalid")
+	ErrUnsupported = errors.New("url scheme must be http or https")
+	ErrTooLong     = fmt.Errorf("url must be less than or equal to %d characters", MaxLength)
+)
+
+// query parameters which are used only for tracking
+var trackingParams = map[string]struct{}{
+	"fbclid":  {},
+	"gclid":   {},
+	"yclid":   {},
+	"msclkid": {},
+	"igshid":  {},
+	"mc_cid":  {},
+	"mc_eid":  {},
+	"_ga":     {},
+	"ref_src": {},
+}
+
+// Normalize returns canonical URL
+// - scheme: http is merged into https
+// - host: lower case, default port and trailing dot are removed
+// - path: empty path is `/`, trailing slash is removed except root
+// - query: tracking parameters such as utm_* are removed, remaining parameters are sorted
+// - fragment is removed
+func Normalize(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", ErrEmpty
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalid, rawURL)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupported, rawURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%w: %s", ErrInvalid, rawURL)
+	}
+
+	u.Scheme = "https"
+	u.User = nil
+	u.Host = normalizeHost(u.Host)
+	if err := normalizePath(u); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalid, rawURL)
+	}
+	u.RawQuery = normalizeQuery(u.RawQuery)
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	normalized := u.String()
+	if len(normalized) > MaxLength {
+		return "", fmt.Errorf("%w: %s", ErrTooLong, normalized)
+	}
+	return normalized, nil
+}
+
+// UniqueURLs validates given urls and removes urls which have same normalized URL keeping order
+// returned urls are original ones without surrounding spaces
+func UniqueURLs(rawURLs []string) ([]string, error) {
+	urls := make([]string, 0, len(rawURLs))
+	seen := make(map[string]struct{}, len(rawURLs))
+	for _, rawURL := range rawURLs {
+		normalized, err := Normalize(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		urls = append(urls, strings.TrimSpace(rawURL))
+	}
+	return urls, nil
+}
+
+func normalizeHost(host string) string {
+	host = strings.ToLower(host)
+	if h, port, err := net.SplitHostPort(host); err == nil && (port == "80" || port == "443") {
+		host = h
+		if strings.Contains(host, ":") {
+			// IPv6
+			host = "[" + host + "]"
+		}
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// keep original escaping of path
+func normalizePath(u *url.URL) error {
+	escaped := u.EscapedPath()
+	if escaped == "" {
+		escaped = "/"
+	}
+	if len(escaped) > 1 {
+		escaped = strings.TrimRight(escaped, "/")
+		if escaped == "" {
+			escaped = "/"
+		}
+	}
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return err
+	}
+	u.Path = path
+	u.RawPath = escaped
+	return nil
+}
+
+// keep original escaping of query
+func normalizeQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	params := strings.Split(rawQuery, "&")
+	kept := make([]string, 0, len(params))
+	for _, param := range params {
+		if param == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(param, "=")
+		if isTrackingParam(key) {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	sort.Strings(kept)
+	return strings.Join(kept, "&")
+}
+
+func isTrackingParam(key string) bool {
+	if k, err := url.QueryUnescape(key); err == nil {
+		key = k
+	}
+	key = strings.ToLower(key)
+	if strings.HasPrefix(key, "utm_") {
+		return true
+	}
+	_, ok := trackingParams[key]
+	return ok
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+var ErrAPIKeyNotFound = errors.New("API key is not found or already revoked")
+
+// APIKeyUsecaser is admin usecase to issue and revoke API keys of web server
+type APIKeyUsecaser interface {
+	Issue(
+		ctx context.Context,
+		name string,
+		scope entities.APIKeyScope,
+		rateLimit, dailyQuota int,
+	) (int32, string, error)
+	Revoke(ctx context.Context, apiKeyID int32) error
+	List(ctx context.Context) ([]entities.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	logger     logger.Logger
+	tracer     tracer.Tracer
+	apiKeyRepo repository.APIKeyRepositorier
+}
+
+func NewAPIKeyUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	apiKeyRepo repository.APIKeyRepositorier,
+) (*apiKeyUsecase, error) {
+	return &apiKeyUsecase{
+		logger:     logger,
+		tracer:     tracer,
+		apiKeyRepo: apiKeyRepo,
+	}, nil
+}
+
+// Issue generates key and stores its hash. returned key can't be shown again
+func (a *apiKeyUsecase) Issue(
+	ctx context.Context,
+	name string,
+	scope entities.APIKeyScope,
+	rateLimit, dailyQuota int,
+) (int32, string, error) {
+	a.logger.Info("apiKeyUsecase Issue", "name", name, "scope", scope)
+
+	ctx, span := a.tracer.NewSpan(ctx, "apiKeyUsecase:Issue()")
+	defer span.End()
+
+	// validation
+	if name == "" {
+		return 0, "", errors.New("name is required")
+	}
+	if rateLimit < 0 || dailyQuota < 0 {
+		return 0, "", errors.New("rate limit and daily quota must not be negative")
+	}
+
+	key, err := entities.NewAPIKey()
+	if err != nil {
+		a.logger.Error("failed to generate API key", "error", err)
+		return 0, "", err
+	}
+	apiKeyID, err := a.apiKeyRepo.InsertAPIKey(ctx, name, key, scope, rateLimit, dailyQuota)
+	if err != nil {
+		a.logger.Error("failed to call apiKeyRepo.InsertAPIKey()", "error", err)
+		return 0, "", err
+	}
+	return apiKeyID, key, nil
+}
+
+func (a *apiKeyUsecase) Revoke(ctx context.Context, apiKeyID int32) error {
+	a.logger.Info("apiKeyUsecase Revoke", "api_key_id", apiKeyID)
+
+	ctx, span := a.tracer.NewSpan(ctx, "apiKeyUsecase:Revoke()")
+	defer span.End()
+
+	revoked, err := a.apiKeyRepo.RevokeAPIKey(ctx, apiKeyID)
+	if err != nil {
+		a.logger.Error("failed to call apiKeyRepo.RevokeAPIKey()", "api_key_id", apiKeyID, "error", err)
+		return err
+	}
+	if !revoked {
+		return fmt.Errorf("%w: %d", ErrAPIKeyNotFound, apiKeyID)
+	}
+	return nil
+}
+
+// List returns all API keys including revoked keys with request count of today
+func (a *apiKeyUsecase) List(ctx context.Context) ([]entities.APIKey, error) {
+	a.logger.Info("apiKeyUsecase List")
+
+	ctx, span := a.tracer.NewSpan(ctx, "apiKeyUsecase:List()")
+	defer span.End()
+
+	apiKeys, err := a.apiKeyRepo.GetAPIKeys(ctx, entities.APIKeyUsageDate(time.Now()))
+	if err != nil {
+		a.logger.Error("failed to call apiKeyRepo.GetAPIKeys()", "error", err)
+		return nil, err
+	}
+	return apiKeys, nil
+}
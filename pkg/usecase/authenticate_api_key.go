@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+var (
+	ErrAPIKeyInvalid       = errors.New("API key is invalid")
+	ErrAPIKeyRevoked       = errors.New("API key is revoked")
+	ErrAPIKeyScope         = errors.New("API key doesn't have required scope")
+	ErrAPIKeyRateLimited   = errors.New("rate limit of API key is exceeded")
+	ErrAPIKeyQuotaExceeded = errors.New("daily quota of API key is exceeded")
+)
+
+type AuthenticateAPIKeyUsecaser interface {
+	Execute(ctx context.Context, key string, required entities.APIKeyScope) (*entities.APIKey, error)
+}
+
+type authenticateAPIKeyUsecase struct {
+	logger     logger.Logger
+	tracer     tracer.Tracer
+	apiKeyRepo repository.APIKeyRepositorier
+	// rate limiter per API key. rate limit is per process
+	mu       sync.Mutex
+	limiters map[int32]*apiKeyLimiter
+}
+
+// apiKeyLimiter is rebuilt when rate limit of API key is changed
+type apiKeyLimiter struct {
+	rateLimit int
+	limiter   *rate.Limiter
+}
+
+func NewAuthenticateAPIKeyUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	apiKeyRepo repository.APIKeyRepositorier,
+) (*authenticateAPIKeyUsecase, error) {
+	return &authenticateAPIKeyUsecase{
+		logger:     logger,
+		tracer:     tracer,
+		apiKeyRepo: apiKeyRepo,
+		limiters:   make(map[int32]*apiKeyLimiter),
+	}, nil
+}
+
+// Authenticate API key of request, then check scope, rate limit and daily quota in order
+// request is counted for daily quota only when it's accepted
+func (a *authenticateAPIKeyUsecase) Execute(
+	ctx context.Context,
+	key string,
+	required entities.APIKeyScope,
+) (*entities.APIKey, error) {
+	ctx, span := a.tracer.NewSpan(ctx, "authenticateAPIKeyUsecase:Execute()")
+	defer span.End()
+
+	if key == "" {
+		return nil, ErrAPIKeyInvalid
+	}
+	apiKey, err := a.apiKeyRepo.GetAPIKeyByKey(ctx, key)
+	if err != nil {
+		if rdb.IsNoRows(err) {
+			return nil, ErrAPIKeyInvalid
+		}
+		logger.FromContext(ctx).Error("failed to call apiKeyRepo.GetAPIKeyByKey()", "error", err)
+		return nil, err
+	}
+	if apiKey.IsRevoked {
+		return nil, ErrAPIKeyRevoked
+	}
+	if !apiKey.Scope.Allows(required) {
+		return nil, ErrAPIKeyScope
+	}
+	if !a.limiter(apiKey).Allow() {
+		return nil, ErrAPIKeyRateLimited
+	}
+
+	accepted, err := a.apiKeyRepo.IncrementAPIKeyUsage(
+		ctx,
+		apiKey.ID,
+		entities.APIKeyUsageDate(time.Now()),
+		apiKey.DailyQuota,
+	)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to call apiKeyRepo.IncrementAPIKeyUsage()", "error", err)
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrAPIKeyQuotaExceeded
+	}
+	return apiKey, nil
+}
+
+// token bucket which allows burst of rate limit per minute
+// limiter is rebuilt when rate limit of API key is changed after it's created
+func (a *authenticateAPIKeyUsecase) limiter(apiKey *entities.APIKey) *rate.Limiter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cached, ok := a.limiters[apiKey.ID]
+	if !ok || cached.rateLimit != apiKey.RateLimit {
+		cached = &apiKeyLimiter{rateLimit: apiKey.RateLimit, limiter: newRateLimiter(apiKey.RateLimit)}
+		a.limiters[apiKey.ID] = cached
+	}
+	return cached.limiter
+}
+
+func newRateLimiter(rateLimit int) *rate.Limiter {
+	if rateLimit == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Every(time.Minute/time.Duration(rateLimit)), rateLimit)
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+// fakeAPIKeyRepository returns apiKey for any key, and counts usage up to daily quota
+type fakeAPIKeyRepository struct {
+	apiKey   *entities.APIKey
+	getErr   error
+	usageErr error
+	usage    int
+}
+
+func (*fakeAPIKeyRepository) Close(_ context.Context) {}
+
+func (*fakeAPIKeyRepository) InsertAPIKey(
+	_ context.Context,
+	_, _ string,
+	_ entities.APIKeyScope,
+	_, _ int,
+) (int32, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (f *fakeAPIKeyRepository) GetAPIKeyByKey(_ context.Context, _ string) (*entities.APIKey, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	apiKey := *f.apiKey
+	return &apiKey, nil
+}
+
+func (*fakeAPIKeyRepository) GetAPIKeys(_ context.Context, _ time.Time) ([]entities.APIKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (*fakeAPIKeyRepository) RevokeAPIKey(_ context.Context, _ int32) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
+func (f *fakeAPIKeyRepository) IncrementAPIKeyUsage(
+	_ context.Context,
+	_ int32,
+	_ time.Time,
+	dailyQuota int,
+) (bool, error) {
+	if f.usageErr != nil {
+		return false, f.usageErr
+	}
+	if dailyQuota != 0 && f.usage >= dailyQuota {
+		return false, nil
+	}
+	f.usage++
+	return true, nil
+}
+
+func newTestAuthenticateAPIKeyUsecase(t *testing.T, repo *fakeAPIKeyRepository) *authenticateAPIKeyUsecase {
+	t.Helper()
+
+	authUsecase, err := NewAuthenticateAPIKeyUsecase(logger.NewNoopLogger(), tracer.NewNoopProvider(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authUsecase
+}
+
+func TestAuthenticateAPIKeyUsecaseExecute(t *testing.T) {
+	readKey := &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeRead}
+	dbErr := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		key       string
+		repo      *fakeAPIKeyRepository
+		required  entities.APIKeyScope
+		wantErr   error
+		wantUsage int
+	}{
+		{
+			name:     "accepted",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: readKey},
+			required: entities.APIKeyScopeRead,
+			// request is counted
+			wantUsage: 1,
+		},
+		{
+			name:     "write key for read scope",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeWrite}},
+			required: entities.APIKeyScopeRead,
+			// request is counted
+			wantUsage: 1,
+		},
+		{
+			name:     "empty key",
+			key:      "",
+			repo:     &fakeAPIKeyRepository{apiKey: readKey},
+			required: entities.APIKeyScopeRead,
+			wantErr:  ErrAPIKeyInvalid,
+		},
+		{
+			name:     "unknown key",
+			key:      "hta_unknown",
+			repo:     &fakeAPIKeyRepository{getErr: pgx.ErrNoRows},
+			required: entities.APIKeyScopeRead,
+			wantErr:  ErrAPIKeyInvalid,
+		},
+		{
+			name:     "revoked",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeWrite, IsRevoked: true}},
+			required: entities.APIKeyScopeRead,
+			wantErr:  ErrAPIKeyRevoked,
+		},
+		{
+			name:     "read key for write scope",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: readKey},
+			required: entities.APIKeyScopeWrite,
+			wantErr:  ErrAPIKeyScope,
+		},
+		{
+			name:     "quota exceeded",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeRead, DailyQuota: 3}, usage: 3},
+			required: entities.APIKeyScopeRead,
+			wantErr:  ErrAPIKeyQuotaExceeded,
+			// rejected request is not counted
+			wantUsage: 3,
+		},
+		{
+			name:     "error of getting key",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{getErr: dbErr},
+			required: entities.APIKeyScopeRead,
+			wantErr:  dbErr,
+		},
+		{
+			name:     "error of counting usage",
+			key:      "hta_key",
+			repo:     &fakeAPIKeyRepository{apiKey: readKey, usageErr: dbErr},
+			required: entities.APIKeyScopeRead,
+			wantErr:  dbErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUsecase := newTestAuthenticateAPIKeyUsecase(t, tt.repo)
+			apiKey, err := authUsecase.Execute(context.Background(), tt.key, tt.required)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && apiKey.ID != tt.repo.apiKey.ID {
+				t.Errorf("Execute() api key id = %d, want %d", apiKey.ID, tt.repo.apiKey.ID)
+			}
+			if tt.repo.usage != tt.wantUsage {
+				t.Errorf("usage = %d, want %d", tt.repo.usage, tt.wantUsage)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKeyUsecaseRateLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		rateLimit    int
+		requests     int
+		wantAccepted int
+	}{
+		{name: "burst of rate limit is accepted", rateLimit: 3, requests: 5, wantAccepted: 3},
+		{name: "unlimited", rateLimit: 0, requests: 100, wantAccepted: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAPIKeyRepository{
+				apiKey: &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeRead, RateLimit: tt.rateLimit},
+			}
+			authUsecase := newTestAuthenticateAPIKeyUsecase(t, repo)
+
+			var accepted int
+			for range tt.requests {
+				_, err := authUsecase.Execute(context.Background(), "hta_key", entities.APIKeyScopeRead)
+				switch {
+				case err == nil:
+					accepted++
+				case !errors.Is(err, ErrAPIKeyRateLimited):
+					t.Fatalf("Execute() error = %v, want %v", err, ErrAPIKeyRateLimited)
+				}
+			}
+			if accepted != tt.wantAccepted {
+				t.Errorf("accepted = %d, want %d", accepted, tt.wantAccepted)
+			}
+			// rate limited request is not counted for daily quota
+			if repo.usage != tt.wantAccepted {
+				t.Errorf("usage = %d, want %d", repo.usage, tt.wantAccepted)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKeyUsecaseRateLimitChanged(t *testing.T) {
+	repo := &fakeAPIKeyRepository{
+		apiKey: &entities.APIKey{ID: 1, Scope: entities.APIKeyScopeRead, RateLimit: 1},
+	}
+	authUsecase := newTestAuthenticateAPIKeyUsecase(t, repo)
+	ctx := context.Background()
+
+	if _, err := authUsecase.Execute(ctx, "hta_key", entities.APIKeyScopeRead); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := authUsecase.Execute(ctx, "hta_key", entities.APIKeyScopeRead); !errors.Is(err, ErrAPIKeyRateLimited) {
+		t.Fatalf("Execute() error = %v, want %v", err, ErrAPIKeyRateLimited)
+	}
+
+	// rate limit is raised without restarting process
+	repo.apiKey.RateLimit = 2
+	for i := range 2 {
+		if _, err := authUsecase.Execute(ctx, "hta_key", entities.APIKeyScopeRead); err != nil {
+			t.Fatalf("Execute() #%d after rate limit is changed error = %v", i, err)
+		}
+	}
+
+	// other API key has its own limiter
+	repo.apiKey = &entities.APIKey{ID: 2, Scope: entities.APIKeyScopeRead, RateLimit: 1}
+	if _, err := authUsecase.Execute(ctx, "hta_other", entities.APIKeyScopeRead); err != nil {
+		t.Fatalf("Execute() of other key error = %v", err)
+	}
+}
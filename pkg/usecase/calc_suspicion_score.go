@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type CalcSuspicionScoreUsecaser interface {
+	Execute(ctx context.Context, urls []string) error
+}
+
+type calcSuspicionScoreUsecase struct {
+	logger             logger.Logger
+	tracer             tracer.Tracer
+	suspicionScoreRepo repository.SuspicionScoreRepositorier
+	weights            *entities.ScoreWeights
+}
+
+func NewCalcSuspicionScoreUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	suspicionScoreRepo repository.SuspicionScoreRepositorier,
+	weightsFile string,
+) (*calcSuspicionScoreUsecase, error) {
+	weights, err := loadScoreWeights(weightsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calcSuspicionScoreUsecase{
+		logger:             logger,
+		tracer:             tracer,
+		suspicionScoreRepo: suspicionScoreRepo,
+		weights:            weights,
+	}, nil
+}
+
+// load weights from JSON file. default weights are used if file is not given
+func loadScoreWeights(weightsFile string) (*entities.ScoreWeights, error) {
+	if weightsFile == "" {
+		return entities.DefaultScoreWeights(), nil
+	}
+	data, err := os.ReadFile(weightsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read weights file: %w", err)
+	}
+	var weights entities.ScoreWeights
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return nil, fmt.Errorf("failed to parse weights file: %w", err)
+	}
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	return &weights, nil
+}
+
+// Combine private user rate, new user rate, deleted user rate, burstiness and user overlap
+// into suspicion score per URL, then save it to DB
+
+func (c *calcSuspicionScoreUsecase) Execute(ctx context.Context, urls []string) error {
+	c.logger.Info("calcSuspicionScoreUsecase Execute", "urls length", len(urls))
+
+	ctx, span := c.tracer.NewSpan(ctx, "calcSuspicionScoreUsecase:Execute()")
+	defer span.End()
+
+	// get urls from DB
+	var entityURLs []entities.URL
+	var err error
+	if len(urls) == 0 {
+		entityURLs, err = c.suspicionScoreRepo.GetAllURLs(ctx)
+		if err != nil {
+			c.logger.Error("failed to call suspicionScoreRepo.GetAllURLs()", "error", err)
+			return err
+		}
+	} else {
+		entityURLs, err = c.suspicionScoreRepo.GetURLsByURLAddresses(ctx, urls)
+		if err != nil {
+			c.logger.Error(
+				"failed to call suspicionScoreRepo.GetURLsByURLAddresses()",
+				"url_count", len(urls),
+				"error", err,
+			)
+			return err
+		}
+	}
+	c.logger.Info("url count", "count", len(entityURLs))
+
+	scores := make([]entities.SuspicionScore, 0, len(entityURLs))
+	for _, entityURL := range entityURLs {
+		// stop when canceled by SIGINT/SIGTERM. calculated scores are already saved
+		if err := ctx.Err(); err != nil {
+			c.logger.Warn("calculation is canceled", "calculated_count", len(scores), "error", err)
+			return err
+		}
+		signals, err := c.signals(ctx, &entityURL)
+		if err != nil {
+			continue
+		}
+		score := entities.SuspicionScore{
+			URLID:   entityURL.ID,
+			URL:     entityURL.Address,
+			Title:   entityURL.Title,
+			Score:   c.weights.Score(signals),
+			Signals: *signals,
+		}
+		if err := c.suspicionScoreRepo.UpsertURLScore(ctx, &score); err != nil {
+			c.logger.Error(
+				"failed to call suspicionScoreRepo.UpsertURLScore()",
+				"url", entityURL.Address,
+				"error", err,
+			)
+			continue
+		}
+		scores = append(scores, score)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	for _, score := range scores {
+		c.print(&score)
+	}
+
+	return nil
+}
+
+func (c *calcSuspicionScoreUsecase) signals(
+	ctx context.Context,
+	entityURL *entities.URL,
+) (*entities.SuspicionSignals, error) {
+	ctx, span := c.tracer.NewSpan(
+		ctx,
+		"calcSuspicionScoreUsecase:signals()",
+		oteltrace.WithAttributes(attribute.String("url", entityURL.Address)),
+	)
+	defer span.End()
+
+	signals := entities.SuspicionSignals{
+		PrivateUserRate: entityURL.PrivateUserRate / 100,
+	}
+
+	// new user rate
+	users, err := c.suspicionScoreRepo.GetUsersByURL(ctx, entityURL.Address)
+	if err != nil {
+		c.logger.Error(
+			"failed to call suspicionScoreRepo.GetUsersByURL()",
+			"url", entityURL.Address,
+			"error", err,
+		)
+		return nil, err
+	}
+	if len(users) != 0 {
+		signals.NewUserRate = entities.NewUserRate(users)
+
+		// user overlap
+		sharedUserCount, err := c.suspicionScoreRepo.GetMaxSharedUserCount(ctx, entityURL.ID)
+		if err != nil {
+			c.logger.Error(
+				"failed to call suspicionScoreRepo.GetMaxSharedUserCount()",
+				"url", entityURL.Address,
+				"error", err,
+			)
+			return nil, err
+		}
+		signals.UserOverlap = min(float64(sharedUserCount)/float64(len(users)), 1)
+	}
+
+	// deleted user rate and burstiness from time series
+	summaries, err := c.suspicionScoreRepo.ReadEntitySummaries(ctx, entityURL.Address)
+	if err != nil {
+		c.logger.Error(
+			"failed to call suspicionScoreRepo.ReadEntitySummaries()",
+			"url", entityURL.Address,
+			"error", err,
+		)
+		return nil, err
+	}
+	if len(summaries) != 0 {
+		latest := summaries[len(summaries)-1]
+		if latest.UserCount != 0 {
+			signals.DeletedUserRate = float64(latest.DeletedUserCount) / float64(latest.UserCount)
+		}
+		signals.Burstiness = entities.Burstiness(summaries)
+	}
+
+	return &signals, nil
+}
+
+func (c *calcSuspicionScoreUsecase) print(score *entities.SuspicionScore) {
+	contributions := c.weights.Contributions(&score.Signals)
+
+	fmt.Println("----------------------------------------------------------------------")
+	fmt.Printf(" Title: %s,\n URL: %s\n", score.Title, score.URL)
+	fmt.Printf(" Suspicion score: %.1f\n", score.Score)
+	fmt.Printf(" signal / value / points\n")
+	fmt.Printf(
+		" - private user rate:  %.3f  %5.1f\n",
+		score.Signals.PrivateUserRate,
+		contributions.PrivateUserRate,
+	)
+	fmt.Printf(" - new user rate:      %.3f  %5.1f\n", score.Signals.NewUserRate, contributions.NewUserRate)
+	fmt.Printf(
+		" - deleted user rate:  %.3f  %5.1f\n",
+		score.Signals.DeletedUserRate,
+		contributions.DeletedUserRate,
+	)
+	fmt.Printf(" - burstiness:         %.3f  %5.1f\n", score.Signals.Burstiness, contributions.Burstiness)
+	fmt.Printf(" - user overlap:       %.3f  %5.1f\n", score.Signals.UserOverlap, contributions.UserOverlap)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/alert"
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type EvaluateAlertsUsecaser interface {
+	Execute(ctx context.Context, urls []string) ([]*entities.Alert, error)
+}
+
+type evaluateAlertsUsecase struct {
+	logger      logger.Logger
+	tracer      tracer.Tracer
+	alertRepo   repository.AlertRepositorier
+	sink        alert.Sink
+	rules       *entities.AlertRules
+	dedupWindow time.Duration
+}
+
+func NewEvaluateAlertsUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	alertRepo repository.AlertRepositorier,
+	sink alert.Sink,
+	rules *entities.AlertRules,
+	dedupWindow time.Duration,
+) (*evaluateAlertsUsecase, error) {
+	return &evaluateAlertsUsecase{
+		logger:      logger,
+		tracer:      tracer,
+		alertRepo:   alertRepo,
+		sink:        sink,
+		rules:       rules,
+		dedupWindow: dedupWindow,
+	}, nil
+}
+
+// Evaluate alert rules for given URLs and send alerts to sink
+// rule already alerted for URL within dedup window is skipped.
+// alerts are recorded only when they are sent, so that they are retried by next run
+
+func (e *evaluateAlertsUsecase) Execute(ctx context.Context, urls []string) ([]*entities.Alert, error) {
+	e.logger.Info("evaluateAlertsUsecase Execute", "urls length", len(urls))
+
+	ctx, span := e.tracer.NewSpan(ctx, "evaluateAlertsUsecase:Execute()")
+	defer span.End()
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	entityURLs, err := e.alertRepo.GetURLsByURLAddresses(ctx, urls)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to call alertRepo.GetURLsByURLAddresses()", "error", err)
+		return nil, err
+	}
+
+	since := time.Now().Add(-e.dedupWindow)
+	var alerts []*entities.Alert
+	for _, entityURL := range entityURLs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		urlAlerts, err := e.evaluate(ctx, &entityURL, since)
+		if err != nil {
+			continue
+		}
+		alerts = append(alerts, urlAlerts...)
+	}
+	if len(alerts) == 0 {
+		logger.FromContext(ctx).Info("no alert", "url_count", len(entityURLs))
+		return nil, nil
+	}
+
+	now := time.Now()
+	for _, alert := range alerts {
+		alert.CreatedAt = now
+	}
+	if err := e.sink.Send(ctx, alerts); err != nil {
+		logger.FromContext(ctx).Error("failed to send alerts", "alert_count", len(alerts), "error", err)
+		return nil, err
+	}
+	for _, alert := range alerts {
+		// alert which is not recorded may be sent again by next run
+		if err := e.alertRepo.InsertAlert(context.WithoutCancel(ctx), alert); err != nil {
+			logger.FromContext(ctx).Warn("failed to call alertRepo.InsertAlert()",
+				"url", alert.URL,
+				"rule", alert.Rule,
+				"error", err,
+			)
+		}
+	}
+	logger.FromContext(ctx).Info("alerts sent", "alert_count", len(alerts))
+	return alerts, nil
+}
+
+// evaluate rules for url and drop rules alerted since given time
+func (e *evaluateAlertsUsecase) evaluate(
+	ctx context.Context,
+	entityURL *entities.URL,
+	since time.Time,
+) ([]*entities.Alert, error) {
+	ctx, span := e.tracer.NewSpan(
+		ctx,
+		"evaluateAlertsUsecase:evaluate()",
+		oteltrace.WithAttributes(attribute.String("url", entityURL.Address)),
+	)
+	defer span.End()
+	ctx = logger.WithFields(ctx, "url", entityURL.Address)
+
+	signals, err := e.signals(ctx, entityURL)
+	if err != nil {
+		return nil, err
+	}
+	alerts := e.rules.Evaluate(entityURL, signals)
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	alertedRules, err := e.alertRepo.GetAlertedRules(ctx, entityURL.ID, since)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to call alertRepo.GetAlertedRules()", "error", err)
+		return nil, err
+	}
+	return slices.DeleteFunc(alerts, func(alert *entities.Alert) bool {
+		if slices.Contains(alertedRules, alert.Rule) {
+			logger.FromContext(ctx).Debug("alert is deduplicated", "rule", alert.Rule)
+			return true
+		}
+		return false
+	}), nil
+}
+
+// signals of enabled rules
+func (e *evaluateAlertsUsecase) signals(
+	ctx context.Context,
+	entityURL *entities.URL,
+) (*entities.AlertSignals, error) {
+	signals := entities.AlertSignals{
+		PrivateUserRate: entityURL.PrivateUserRate / 100,
+	}
+
+	if e.rules.NewUserRate != 0 {
+		users, err := e.alertRepo.GetUsersByURL(ctx, entityURL.Address)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to call alertRepo.GetUsersByURL()", "error", err)
+			return nil, err
+		}
+		signals.NewUserRate = entities.NewUserRate(users)
+	}
+
+	if e.rules.DeletionSpike != 0 || e.rules.Burst != 0 {
+		summaries, err := e.alertRepo.ReadEntitySummaries(ctx, entityURL.Address)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to call alertRepo.ReadEntitySummaries()", "error", err)
+			return nil, err
+		}
+		signals.DeletionSpike = entities.DeletionSpike(summaries)
+		signals.Burst = entities.Burstiness(summaries)
+	}
+	return &signals, nil
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+// fakeAlertRepository keeps recorded alerts in memory
+type fakeAlertRepository struct {
+	urls      []entities.URL
+	summaries []*entities.BookmarkSummary
+	alerts    []*entities.Alert
+}
+
+func (*fakeAlertRepository) Close(_ context.Context) {}
+
+func (f *fakeAlertRepository) GetURLsByURLAddresses(_ context.Context, _ []string) ([]entities.URL, error) {
+	return f.urls, nil
+}
+
+func (*fakeAlertRepository) GetUsersByURL(_ context.Context, _ string) ([]entities.RDBUser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeAlertRepository) GetAlertedRules(
+	_ context.Context,
+	urlID int32,
+	since time.Time,
+) ([]entities.AlertRule, error) {
+	var rules []entities.AlertRule
+	for _, alert := range f.alerts {
+		if alert.URLID == urlID && !alert.CreatedAt.Before(since) {
+			rules = append(rules, alert.Rule)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeAlertRepository) InsertAlert(_ context.Context, alert *entities.Alert) error {
+	f.alerts = append(f.alerts, alert)
+	return nil
+}
+
+func (f *fakeAlertRepository) ReadEntitySummaries(_ context.Context, _ string) ([]*entities.BookmarkSummary, error) {
+	return f.summaries, nil
+}
+
+type fakeAlertSink struct {
+	err   error
+	calls int
+	sent  []*entities.Alert
+}
+
+func (*fakeAlertSink) Name() string {
+	return "fake"
+}
+
+func (f *fakeAlertSink) Send(_ context.Context, alerts []*entities.Alert) error {
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, alerts...)
+	return nil
+}
+
+func TestEvaluateAlertsUsecaseExecute(t *testing.T) {
+	const dedupWindow = time.Hour
+	const urlID = 1
+	rules := &entities.AlertRules{PrivateUserRate: 0.5, DeletionSpike: 0.1}
+	// private user rate: 0.6, deletion spike: 0.2
+	url := entities.URL{ID: urlID, Address: "https://example.com/a", PrivateUserRate: 60}
+	summaries := []*entities.BookmarkSummary{
+		{UserCount: 100, DeletedUserCount: 0},
+		{UserCount: 100, DeletedUserCount: 20},
+	}
+	alerted := func(rule entities.AlertRule, ago time.Duration) *entities.Alert {
+		return &entities.Alert{URLID: urlID, Rule: rule, CreatedAt: time.Now().Add(-ago)}
+	}
+	sendErr := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		urls        []entities.URL
+		alerted     []*entities.Alert
+		sinkErr     error
+		wantErr     error
+		wantRules   []entities.AlertRule
+		wantSend    bool
+		wantAlerted int
+	}{
+		{
+			name:        "no previous alert",
+			urls:        []entities.URL{url},
+			wantRules:   []entities.AlertRule{entities.AlertRulePrivateUserRate, entities.AlertRuleDeletionSpike},
+			wantSend:    true,
+			wantAlerted: 2,
+		},
+		{
+			name:        "alerted inside dedup window",
+			urls:        []entities.URL{url},
+			alerted:     []*entities.Alert{alerted(entities.AlertRulePrivateUserRate, dedupWindow-time.Minute)},
+			wantRules:   []entities.AlertRule{entities.AlertRuleDeletionSpike},
+			wantSend:    true,
+			wantAlerted: 2,
+		},
+		{
+			name: "all rules alerted inside dedup window",
+			urls: []entities.URL{url},
+			alerted: []*entities.Alert{
+				alerted(entities.AlertRulePrivateUserRate, time.Minute),
+				alerted(entities.AlertRuleDeletionSpike, time.Minute),
+			},
+			wantRules:   nil,
+			wantSend:    false,
+			wantAlerted: 2,
+		},
+		{
+			name:        "alerted outside dedup window",
+			urls:        []entities.URL{url},
+			alerted:     []*entities.Alert{alerted(entities.AlertRulePrivateUserRate, dedupWindow+time.Minute)},
+			wantRules:   []entities.AlertRule{entities.AlertRulePrivateUserRate, entities.AlertRuleDeletionSpike},
+			wantSend:    true,
+			wantAlerted: 3,
+		},
+		{
+			name:        "other url alerted inside dedup window",
+			urls:        []entities.URL{url},
+			alerted:     []*entities.Alert{{URLID: urlID + 1, Rule: entities.AlertRulePrivateUserRate, CreatedAt: time.Now()}},
+			wantRules:   []entities.AlertRule{entities.AlertRulePrivateUserRate, entities.AlertRuleDeletionSpike},
+			wantSend:    true,
+			wantAlerted: 3,
+		},
+		{
+			name:      "no url",
+			urls:      nil,
+			wantRules: nil,
+			wantSend:  false,
+		},
+		{
+			// alerts are not recorded, so that they are sent by next run
+			name:        "sink failed",
+			urls:        []entities.URL{url},
+			sinkErr:     sendErr,
+			wantErr:     sendErr,
+			wantRules:   nil,
+			wantSend:    true,
+			wantAlerted: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAlertRepository{urls: tt.urls, summaries: summaries, alerts: tt.alerted}
+			sink := &fakeAlertSink{err: tt.sinkErr}
+			evaluateUsecase, err := NewEvaluateAlertsUsecase(
+				logger.NewNoopLogger(),
+				tracer.NewNoopProvider(),
+				repo,
+				sink,
+				rules,
+				dedupWindow,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			alerts, err := evaluateUsecase.Execute(context.Background(), []string{url.Address})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(alerts) != len(tt.wantRules) {
+				t.Fatalf("Execute() returned %d alerts, want %v", len(alerts), tt.wantRules)
+			}
+			for i, alert := range alerts {
+				if alert.Rule != tt.wantRules[i] {
+					t.Errorf("Execute()[%d].Rule = %s, want %s", i, alert.Rule, tt.wantRules[i])
+				}
+			}
+			if sent := sink.calls != 0; sent != tt.wantSend {
+				t.Errorf("sink called = %v, want %v", sent, tt.wantSend)
+			}
+			if tt.sinkErr == nil && len(sink.sent) != len(tt.wantRules) {
+				t.Errorf("sink sent %d alerts, want %d", len(sink.sent), len(tt.wantRules))
+			}
+			if len(repo.alerts) != tt.wantAlerted {
+				t.Errorf("recorded alerts = %d, want %d", len(repo.alerts), tt.wantAlerted)
+			}
+		})
+	}
+}
+
+func TestEvaluateAlertsUsecaseExecuteTwice(t *testing.T) {
+	url := entities.URL{ID: 1, Address: "https://example.com/a", PrivateUserRate: 60}
+	repo := &fakeAlertRepository{urls: []entities.URL{url}}
+	sink := &fakeAlertSink{}
+	evaluateUsecase, err := NewEvaluateAlertsUsecase(
+		logger.NewNoopLogger(),
+		tracer.NewNoopProvider(),
+		repo,
+		sink,
+		&entities.AlertRules{PrivateUserRate: 0.5},
+		time.Hour,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if _, err := evaluateUsecase.Execute(ctx, []string{url.Address}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+	// second run is deduplicated by alert recorded by first run
+	if sink.calls != 1 || len(sink.sent) != 1 {
+		t.Errorf("sink called %d times with %d alerts, want once with 1 alert", sink.calls, len(sink.sent))
+	}
+}
@@ -6,26 +6,42 @@ import (
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/semaphore"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/fetcher"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
 	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+	"github.com/hiromaily/hatena-analyzer/pkg/urlnorm"
 )
 
 type FetchBookmarkUsecaser interface {
-	Execute(ctx context.Context, urls []string, isVerbose bool) error
+	Execute(
+		ctx context.Context,
+		urls []string,
+		filter *entities.URLFilter,
+		watchedOnly bool,
+		isVerbose bool,
+		resumeRunID int32,
+	) error
 }
 
 type fetchBookmarkUsecase struct {
 	logger            logger.Logger
 	tracer            tracer.Tracer
+	metrics           metrics.Metrics
 	bookmarkRepo      repository.FetchBookmarkRepositorier
 	entityJSONFetcher fetcher.EntityJSONFetcher
-	maxWorker         int64 // for semaphore
+	runRecorder       *fetchRunRecorder
+	alertUsecase      EvaluateAlertsUsecaser // nil if alerting is disabled
+	maxWorker         int64                  // for semaphore
 }
 
 // TODO
@@ -35,8 +51,12 @@ type fetchBookmarkUsecase struct {
 func NewFetchBookmarkUsecase(
 	logger logger.Logger,
 	tracer tracer.Tracer,
+	metrics metrics.Metrics,
+	notifier progress.Notifier,
 	bookmarkRepo repository.FetchBookmarkRepositorier,
+	runRepo repository.FetchRunRepositorier,
 	entityJSONFetcher fetcher.EntityJSONFetcher,
+	alertUsecase EvaluateAlertsUsecaser,
 	maxWorker int64,
 ) (*fetchBookmarkUsecase, error) {
 	// validation
@@ -47,124 +67,268 @@ func NewFetchBookmarkUsecase(
 	return &fetchBookmarkUsecase{
 		logger:            logger,
 		tracer:            tracer,
+		metrics:           metrics,
 		bookmarkRepo:      bookmarkRepo,
 		entityJSONFetcher: entityJSONFetcher,
+		runRecorder:       newFetchRunRecorder(metrics, notifier, runRepo, entities.RunCommandFetchBookmark),
+		alertUsecase:      alertUsecase,
 		maxWorker:         maxWorker,
 	}, nil
 }
 
 // Fetch bookmark users, title, count related given URLs using Hatena entity API and save data to DB
+// Watched URLs are always fetched in addition to given URLs, or only them if watchedOnly is true
+// Progress is persisted per URL, and run is resumed from remaining URLs if resumeRunID is given
+// Alert rules are evaluated for fetched URLs after run
 
-func (f *fetchBookmarkUsecase) Execute(ctx context.Context, urls []string, isVerbose bool) error {
-	f.logger.Info("fetchBookmarkUsecase Execute", "urls length", len(urls))
+func (f *fetchBookmarkUsecase) Execute(
+	ctx context.Context,
+	urls []string,
+	filter *entities.URLFilter,
+	watchedOnly bool,
+	isVerbose bool,
+	resumeRunID int32,
+) error {
+	f.logger.Info("fetchBookmarkUsecase Execute", "urls length", len(urls), "watched_only", watchedOnly)
 
 	// must be closed dbClient
 	// defer f.bookmarkRepo.Close(ctx)
 
-	_, span := f.tracer.NewSpan(ctx, "fetchBookmarkUsecase:Execute()")
-	defer func() {
-		span.End()
-		f.tracer.Close(ctx)
-	}()
+	ctx, span := f.tracer.NewSpan(ctx, "fetchBookmarkUsecase:Execute()")
+	defer span.End()
+
+	// resume run
+	if resumeRunID != 0 {
+		addresses, err := f.runRecorder.resume(ctx, resumeRunID)
+		if err != nil {
+			return err
+		}
+		entityURLs := make([]entities.URL, 0, len(addresses))
+		for _, address := range addresses {
+			entityURLs = append(entityURLs, entities.URL{Address: address})
+		}
+		return f.concurrentExecuter(ctx, resumeRunID, entityURLs, isVerbose)
+	}
 
 	// get urls from DB if needed
+	// empty filter selects all urls
+	// watchedOnly is for frequent run, and only watched urls are added below
 	var entityURLs []entities.URL
-	if len(urls) == 0 {
+	switch {
+	case watchedOnly:
+	case len(urls) == 0:
 		var err error
-		entityURLs, err = f.bookmarkRepo.GetAllURLs(ctx)
+		entityURLs, err = f.bookmarkRepo.GetURLsByFilter(ctx, filter)
 		if err != nil {
-			f.logger.Error("failed to call bookmarkRepo.GetAllURLs()", "error", err)
+			f.logger.Error("failed to call bookmarkRepo.GetURLsByFilter()", "error", err)
 			return err
 		}
-	} else {
-		for _, url := range urls {
-			// TODO: validate URL
+	default:
+		uniqueURLs, err := urlnorm.UniqueURLs(urls)
+		if err != nil {
+			f.logger.Error("failed to validate URLs", "error", err)
+			return err
+		}
+		// given urls are replaced with stored addresses which have same normalized address
+		// because InfluxDB and MongoDB are keyed by url address
+		storedURLs, err := f.bookmarkRepo.GetURLsByURLAddresses(ctx, uniqueURLs)
+		if err != nil {
+			f.logger.Error("failed to call bookmarkRepo.GetURLsByURLAddresses()", "error", err)
+			return err
+		}
+		for _, url := range entities.ResolveURLAddresses(uniqueURLs, storedURLs) {
 			entityURLs = append(entityURLs, entities.URL{Address: url})
 		}
 	}
 
-	return f.concurrentExecuter(ctx, entityURLs, isVerbose)
+	entityURLs, err := f.addWatchedURLs(ctx, entityURLs)
+	if err != nil {
+		return err
+	}
+
+	addresses := make([]string, 0, len(entityURLs))
+	for _, entityURL := range entityURLs {
+		addresses = append(addresses, entityURL.Address)
+	}
+	runID, err := f.runRecorder.start(ctx, addresses)
+	if err != nil {
+		return err
+	}
+
+	return f.concurrentExecuter(ctx, runID, entityURLs, isVerbose)
+}
+
+// add watched urls which are not included in given urls
+// watched url which is not stored yet is inserted by fetching
+func (f *fetchBookmarkUsecase) addWatchedURLs(
+	ctx context.Context,
+	entityURLs []entities.URL,
+) ([]entities.URL, error) {
+	watchedURLs, err := f.bookmarkRepo.GetWatchedURLs(ctx)
+	if err != nil {
+		f.logger.Error("failed to call bookmarkRepo.GetWatchedURLs()", "error", err)
+		return nil, err
+	}
+	included := make(map[string]struct{}, len(entityURLs))
+	for _, entityURL := range entityURLs {
+		included[entities.NewURLHash(entityURL.Address)] = struct{}{}
+	}
+	var addedCount int
+	for _, watchedURL := range watchedURLs {
+		hash := entities.NewURLHash(watchedURL.Address)
+		if _, ok := included[hash]; ok {
+			continue
+		}
+		included[hash] = struct{}{}
+		entityURLs = append(entityURLs, entities.URL{Address: watchedURL.Address})
+		addedCount++
+	}
+	f.logger.Info("watched urls", "count", len(watchedURLs), "added_count", addedCount)
+	return entityURLs, nil
 }
 
 func (f *fetchBookmarkUsecase) concurrentExecuter(
 	ctx context.Context,
+	runID int32,
 	entityURLs []entities.URL,
 	isVerbose bool,
 ) error {
+	// run_id is added to logs of all workers
+	ctx = logger.WithFields(ctx, "run_id", runID)
+
 	sem := semaphore.NewWeighted(f.maxWorker)
 	var wg sync.WaitGroup
 
-	f.logger.Info("start concurrentExecuter", "max_worker", f.maxWorker, "url_count", len(entityURLs))
+	logger.FromContext(ctx).Info("start concurrentExecuter",
+		"max_worker", f.maxWorker, "url_count", len(entityURLs))
 
-	for _, entityURL := range entityURLs {
-		wg.Add(1)
+	usecaseName := entities.RunCommandFetchBookmark.String()
+	f.metrics.SetMaxWorkers(usecaseName, f.maxWorker)
+
+	// fetched urls are evaluated by alert rules
+	var mu sync.Mutex
+	doneURLs := make([]string, 0, len(entityURLs))
 
+	var runErr error
+	for _, entityURL := range entityURLs {
+		// stop dispatching when canceled by SIGINT/SIGTERM
+		if err := ctx.Err(); err != nil {
+			logger.FromContext(ctx).Warn("run is canceled", "error", err)
+			runErr = err
+			break
+		}
 		// get semaphore
 		if err := sem.Acquire(ctx, 1); err != nil {
-			f.logger.Warn("failed to acquire semaphore", "error", err)
+			logger.FromContext(ctx).Warn("failed to acquire semaphore", "error", err)
+			runErr = err
 			break
 		}
+		f.metrics.IncWorker(usecaseName)
+		wg.Add(1)
 
 		go func(entityURL entities.URL) {
 			defer func() {
 				wg.Done()
 				sem.Release(1)
+				f.metrics.DecWorker(usecaseName)
 			}()
 
-			// load existing bookmark data from DB
-			existingBookmark, err := f.load(ctx, entityURL.Address)
-			if err != nil {
-				return
+			err := f.execute(ctx, runID, &entityURL, isVerbose)
+			f.runRecorder.progress(ctx, runID, entityURL.Address, err)
+			if err == nil {
+				mu.Lock()
+				doneURLs = append(doneURLs, entityURL.Address)
+				mu.Unlock()
 			}
+		}(entityURL)
+	}
+	// in-flight items finish or abort by canceled ctx
+	wg.Wait()
 
-			// set isDeleted = `true` on existingBookmark.Users
-			for userName := range existingBookmark.Users {
-				existingBookmark.Users[userName] = entities.BookmarkUser{
-					Name:        userName,
-					IsDeleted:   true,
-					IsCommented: existingBookmark.Users[userName].IsCommented,
-				}
-			}
+	f.runRecorder.finish(ctx, runID, runErr)
 
-			// retrieve latest data from URL
-			newBookmark, err := f.fetch(ctx, entityURL.Address)
-			if err != nil {
-				return
-			}
+	// failure of alerting doesn't fail the run. interrupted run is not evaluated
+	if f.alertUsecase != nil && runErr == nil {
+		if _, err := f.alertUsecase.Execute(ctx, doneURLs); err != nil {
+			logger.FromContext(ctx).Warn("failed to evaluate alerts", "error", err)
+		}
+	}
+	return runErr
+}
 
-			// update existingBookmark to save
-			existingBookmark.Title = newBookmark.Title
-			existingBookmark.Count = newBookmark.Count
-			existingBookmark.Timestamp = newBookmark.Timestamp
-			// overwrite `isDeleted` with `false` if user is still exist
-			for userName, user := range newBookmark.Users {
-				existingBookmark.Users[userName] = entities.BookmarkUser{
-					Name:        userName,
-					IsDeleted:   false,
-					IsCommented: user.IsCommented,
-				}
-			}
-			f.logger.Info("bookmark entity will be stored",
-				"url", entityURL.Address,
-				"newBookmark.Title", existingBookmark.Title,
-				"newBookmark.Count", existingBookmark.Count,
-				"newBookmark.User.Length", len(existingBookmark.Users),
-			)
+// fetch bookmark of url and save it
+func (f *fetchBookmarkUsecase) execute(
+	ctx context.Context,
+	runID int32,
+	entityURL *entities.URL,
+	isVerbose bool,
+) (err error) {
+	ctx, span := f.tracer.NewSpan(
+		ctx,
+		"fetchBookmarkUsecase:execute()",
+		oteltrace.WithAttributes(attribute.String("url", entityURL.Address)),
+	)
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	ctx = logger.WithFields(ctx, "url", entityURL.Address)
 
-			// save data
-			err = f.save(ctx, &entityURL, existingBookmark)
-			if err != nil {
-				return
-			}
+	// load existing bookmark data from DB
+	existingBookmark, err := f.load(ctx, entityURL.Address)
+	if err != nil {
+		return err
+	}
 
-			// Print data
-			if isVerbose {
-				f.print(existingBookmark)
-			}
-		}(entityURL)
+	// set isDeleted = `true` on existingBookmark.Users
+	for userName := range existingBookmark.Users {
+		existingBookmark.Users[userName] = entities.BookmarkUser{
+			Name:        userName,
+			IsDeleted:   true,
+			IsCommented: existingBookmark.Users[userName].IsCommented,
+			Comment:     existingBookmark.Users[userName].Comment,
+		}
 	}
-	wg.Wait()
 
+	// retrieve latest data from URL
+	newBookmark, err := f.fetch(ctx, entityURL.Address)
+	if err != nil {
+		return err
+	}
+	f.runRecorder.fetched(runID, entityURL.Address)
+
+	// update existingBookmark to save
+	existingBookmark.Title = newBookmark.Title
+	existingBookmark.Count = newBookmark.Count
+	existingBookmark.Timestamp = newBookmark.Timestamp
+	// overwrite `isDeleted` with `false` if user is still exist
+	for userName, user := range newBookmark.Users {
+		existingBookmark.Users[userName] = entities.BookmarkUser{
+			Name:        userName,
+			IsDeleted:   false,
+			IsCommented: user.IsCommented,
+			Comment:     user.Comment,
+		}
+	}
+	logger.FromContext(ctx).Info("bookmark entity will be stored",
+		"newBookmark.Title", existingBookmark.Title,
+		"newBookmark.Count", existingBookmark.Count,
+		"newBookmark.User.Length", len(existingBookmark.Users),
+	)
+
+	// save data
+	// fetched data is flushed even if ctx is canceled while saving
+	err = f.save(context.WithoutCancel(ctx), entityURL, existingBookmark)
+	if err != nil {
+		return err
+	}
+
+	// Print data
+	if isVerbose {
+		f.print(existingBookmark)
+	}
 	return nil
 }
 
@@ -186,18 +350,17 @@ func (f *fetchBookmarkUsecase) load(ctx context.Context, url string) (*entities.
 	// load bookmark content by URL from MongoDB
 	existingBookmark, err := f.bookmarkRepo.ReadEntity(ctx, url)
 	if err != nil {
-		f.logger.Error("failed to call bookmarkRepo.ReadEntity()", "url", url, "error", err)
+		logger.FromContext(ctx).Error("failed to call bookmarkRepo.ReadEntity()", "error", err)
 		return nil, err
 	}
 
 	if existingBookmark == nil {
-		f.logger.Debug("entity not found on MongoDB", "url", url)
+		logger.FromContext(ctx).Debug("entity not found on MongoDB")
 		// initialize entities.Bookmark
 		existingBookmark = &entities.Bookmark{}
 		existingBookmark.Users = make(map[string]entities.BookmarkUser)
 	} else {
-		f.logger.Info("bookmark entity loaded",
-			"url", url,
+		logger.FromContext(ctx).Info("bookmark entity loaded",
 			"existingBookmark.Title", existingBookmark.Title,
 			"existingBookmark.Count", existingBookmark.Count,
 			"existingBookmark.User.Length", len(existingBookmark.Users),
@@ -210,12 +373,11 @@ func (f *fetchBookmarkUsecase) load(ctx context.Context, url string) (*entities.
 func (f *fetchBookmarkUsecase) fetch(ctx context.Context, url string) (*entities.Bookmark, error) {
 	newBookmark, err := f.entityJSONFetcher.Fetch(ctx, url)
 	if err != nil {
-		f.logger.Error("failed to call fetchBookmarkData()", "url", url, "error", err)
+		logger.FromContext(ctx).Error("failed to call fetchBookmarkData()", "error", err)
 		return nil, err
 	}
-	f.logger.Info(
+	logger.FromContext(ctx).Info(
 		"data fetched",
-		"url", url,
 		"newBookmark.Title", newBookmark.Title,
 		"newBookmark.Count", newBookmark.Count,
 		"newBookmark.User.Length", len(newBookmark.Users),
@@ -232,9 +394,8 @@ func (f *fetchBookmarkUsecase) save(
 	// InfluxDB
 	err := f.bookmarkRepo.WriteEntitySummary(ctx, entityURL.Address, bookmark)
 	if err != nil {
-		f.logger.Error(
+		logger.FromContext(ctx).Error(
 			"failed to call bookmarkRepo.WriteEntitySummary()",
-			"url", entityURL.Address,
 			"error", err,
 		)
 		return err
@@ -243,7 +404,7 @@ func (f *fetchBookmarkUsecase) save(
 	// MongoDB
 	err = f.bookmarkRepo.WriteEntity(ctx, entityURL.Address, bookmark)
 	if err != nil {
-		f.logger.Error("failed to call bookmarkRepo.WriteEntity()", "url", entityURL.Address, "error", err)
+		logger.FromContext(ctx).Error("failed to call bookmarkRepo.WriteEntity()", "error", err)
 		return err
 	}
 
@@ -261,18 +422,16 @@ func (f *fetchBookmarkUsecase) save(
 			entities.PrivateUserRate(bookmark.Count, len(bookmark.Users)),
 		)
 		if err != nil && !rdb.IsNoRows(err) {
-			f.logger.Error(
+			logger.FromContext(ctx).Error(
 				"failed to call bookmarkRepo.UpsertURL()",
-				"url", entityURL.Address,
 				"error", err,
 			)
 			return err
 		}
 		if urlID == 0 {
 			err := errors.New("urlID is 0")
-			f.logger.Error(
+			logger.FromContext(ctx).Error(
 				"failed to call bookmarkRepo.UpsertURL()",
-				"url", entityURL.Address,
 				"error", err,
 			)
 			return err
@@ -290,7 +449,7 @@ func (f *fetchBookmarkUsecase) save(
 			entities.PrivateUserRate(bookmark.Count, len(bookmark.Users)),
 		)
 		if err != nil && !rdb.IsNoRows(err) {
-			f.logger.Error(
+			logger.FromContext(ctx).Error(
 				"failed to call bookmarkRepo.UpdateURL()",
 				"urlID", entityURL.ID,
 				"error", err,
@@ -299,28 +458,23 @@ func (f *fetchBookmarkUsecase) save(
 		}
 	}
 
-	// Upsert Users related to url on PostgreSQL DB
-	for _, users := range bookmark.Users {
-		// Users
-		userID, err := f.bookmarkRepo.UpsertUser(ctx, users.Name)
-		if err != nil {
-			f.logger.Warn("failed to call bookmarkRepo.UpsertUser()", "userName", users.Name, "error", err)
-		}
-		// UserURLs
-		err = f.bookmarkRepo.UpsertUserURLs(ctx, userID, entityURL.ID)
-		if err != nil {
-			// FIXED: ERROR: insert or update on table "userurls" violates foreign key
-			// constraint "userurls_url_id_fkey" (SQLSTATE 23503)
-			f.logger.Warn(
-				"failed to call bookmarkRepo.UpsertUserURLs()",
-				"userID", userID,
-				"urlID", entityURL.ID,
-				"error", err,
-			)
-		}
+	// Upsert Users related to url on PostgreSQL DB in bulk
+	users := make([]entities.BookmarkUser, 0, len(bookmark.Users))
+	for _, user := range bookmark.Users {
+		users = append(users, user)
+	}
+	err = f.bookmarkRepo.BulkUpsertUserURLs(ctx, entityURL.ID, users)
+	if err != nil {
+		logger.FromContext(ctx).Error(
+			"failed to call bookmarkRepo.BulkUpsertUserURLs()",
+			"urlID", entityURL.ID,
+			"user_count", len(users),
+			"error", err,
+		)
+		return err
 	}
 
-	f.logger.Info("bookmark data saved", "url", entityURL.Address)
+	logger.FromContext(ctx).Info("bookmark data saved")
 	return nil
 }
 
@@ -7,8 +7,10 @@ import (
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/fetcher"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+	"github.com/hiromaily/hatena-analyzer/pkg/urlnorm"
 )
 
 type FetchHatenaPageURLsUsecaser interface {
@@ -18,6 +20,7 @@ type FetchHatenaPageURLsUsecaser interface {
 type fetchHatenaPageURLsUsecase struct {
 	logger               logger.Logger
 	tracer               tracer.Tracer
+	metrics              metrics.Metrics
 	fetchURLRepo         repository.FetchURLRepositorier
 	hatenaPageURLFetcher fetcher.HatenaPageURLFetcher
 	categoryCode         entities.CategoryCode
@@ -30,6 +33,7 @@ type fetchHatenaPageURLsUsecase struct {
 func NewFetchHatenaPageURLsUsecase(
 	logger logger.Logger,
 	tracer tracer.Tracer,
+	metrics metrics.Metrics,
 	fetchURLRepo repository.FetchURLRepositorier,
 	hatenaPageURLFetcher fetcher.HatenaPageURLFetcher,
 	categoryCode entities.CategoryCode,
@@ -39,6 +43,7 @@ func NewFetchHatenaPageURLsUsecase(
 	return &fetchHatenaPageURLsUsecase{
 		logger:               logger,
 		tracer:               tracer,
+		metrics:              metrics,
 		fetchURLRepo:         fetchURLRepo,
 		hatenaPageURLFetcher: hatenaPageURLFetcher,
 		categoryCode:         categoryCode,
@@ -53,11 +58,8 @@ func (f *fetchHatenaPageURLsUsecase) Execute(ctx context.Context) error {
 	// must be closed dbClient
 	// defer f.fetchURLRepo.Close(ctx)
 
-	_, span := f.tracer.NewSpan(ctx, "fetchURLsUsecase:Execute()")
-	defer func() {
-		span.End()
-		f.tracer.Close(ctx)
-	}()
+	ctx, span := f.tracer.NewSpan(ctx, "fetchURLsUsecase:Execute()")
+	defer span.End()
 
 	targetURLs := []string{}
 	if f.categoryCode == entities.Unknown {
@@ -99,7 +101,12 @@ func (f *fetchHatenaPageURLsUsecase) Execute(ctx context.Context) error {
 		// if err := f.fetchURLRepo.InsertURLs(ctx, category, pageURLs); err != nil {
 		// 	f.logger.Error("failed to insert URLs", "category", category.String(), "error", err)
 		// }
-		urls, categories, isAlls := entities.LinkInfos(linkInfos).Extract()
+		urls, categories, isAlls := f.normalize(linkInfos).Extract()
+		if len(urls) == 0 {
+			f.logger.Warn("no valid URLs are fetched", "url", url)
+			continue
+		}
+		f.metrics.AddScrapedURLs(category.String(), len(urls))
 		if err := f.fetchURLRepo.CallBulkInsertURLs(ctx, urls, categories, isAlls); err != nil {
 			f.logger.Error("failed to insert URLs", "category", category.String(), "error", err)
 		}
@@ -109,3 +116,23 @@ func (f *fetchHatenaPageURLsUsecase) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+// validate fetched urls. invalid urls and urls which have same normalized URL are skipped
+// original urls are kept because Hatena matches entries by exact URL
+func (f *fetchHatenaPageURLsUsecase) normalize(linkInfos []entities.LinkInfo) entities.LinkInfos {
+	normalized := make(entities.LinkInfos, 0, len(linkInfos))
+	seen := make(map[string]struct{}, len(linkInfos))
+	for _, linkInfo := range linkInfos {
+		url, err := urlnorm.Normalize(linkInfo.Href)
+		if err != nil {
+			f.logger.Warn("skip invalid URL", "url", linkInfo.Href, "error", err)
+			continue
+		}
+		if _, ok := seen[url]; ok {
+			continue
+		}
+		seen[url] = struct{}{}
+		normalized = append(normalized, linkInfo)
+	}
+	return normalized
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+)
+
+var ErrRunNotFound = errors.New("run is not found")
+
+// fetchRunRecorder persists run ID and progress of each item
+// so that interrupted fetch command can be resumed by run ID
+// processed and failed items are counted as metrics, and published as progress events as well
+// logger is taken from context. progress and finish are called with context whose logger has run_id
+type fetchRunRecorder struct {
+	metrics  metrics.Metrics
+	notifier progress.Notifier
+	runRepo  repository.FetchRunRepositorier
+	command  entities.RunCommand
+
+	// counts of running runs. a usecase may run concurrently in web mode
+	mu     sync.Mutex
+	counts map[int32]*progress.Counts
+}
+
+func newFetchRunRecorder(
+	metrics metrics.Metrics,
+	notifier progress.Notifier,
+	runRepo repository.FetchRunRepositorier,
+	command entities.RunCommand,
+) *fetchRunRecorder {
+	return &fetchRunRecorder{
+		metrics:  metrics,
+		notifier: notifier,
+		runRepo:  runRepo,
+		command:  command,
+		counts:   make(map[int32]*progress.Counts),
+	}
+}
+
+// start creates run with all items as pending
+func (f *fetchRunRecorder) start(ctx context.Context, items []string) (int32, error) {
+	runID, err := f.runRepo.CreateFetchRun(ctx, f.command, items)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to call runRepo.CreateFetchRun()", "error", err)
+		return 0, err
+	}
+	logger.FromContext(ctx).Info("run started", "run_id", runID, "command", f.command, "item_count", len(items))
+	f.publish(runID, &progress.Event{Type: progress.EventStarted}, func(counts *progress.Counts) {
+		counts.Total = len(items)
+	})
+	return runID, nil
+}
+
+// resume returns items which are not done yet in the run
+func (f *fetchRunRecorder) resume(ctx context.Context, runID int32) ([]string, error) {
+	run, err := f.runRepo.GetFetchRun(ctx, runID)
+	if err != nil {
+		if rdb.IsNoRows(err) {
+			return nil, fmt.Errorf("%w: %d", ErrRunNotFound, runID)
+		}
+		logger.FromContext(ctx).Error("failed to call runRepo.GetFetchRun()", "run_id", runID, "error", err)
+		return nil, err
+	}
+	if run.Command != f.command {
+		return nil, fmt.Errorf("run %d is run of %s, not %s", runID, run.Command, f.command)
+	}
+
+	items, err := f.runRepo.GetUnfinishedFetchRunItems(ctx, runID)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to call runRepo.GetUnfinishedFetchRunItems()", "run_id", runID, "error", err)
+		return nil, err
+	}
+	if err := f.runRepo.UpdateFetchRunStatus(ctx, runID, entities.RunStatusRunning); err != nil {
+		logger.FromContext(ctx).Error("failed to call runRepo.UpdateFetchRunStatus()", "run_id", runID, "error", err)
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("run resumed",
+		"run_id", runID,
+		"command", f.command,
+		"done_count", run.DoneCount,
+		"remaining_count", len(items),
+	)
+	// failed items are retried, so they are counted again
+	f.publish(runID, &progress.Event{Type: progress.EventStarted}, func(counts *progress.Counts) {
+		counts.Total = run.DoneCount + len(items)
+		counts.Done = run.DoneCount
+	})
+	return items, nil
+}
+
+// fetched notifies that item was fetched and is being saved
+func (f *fetchRunRecorder) fetched(runID int32, item string) {
+	f.publish(runID, &progress.Event{Type: progress.EventFetched, Item: item}, nil)
+}
+
+// progress records result of item. failure to record doesn't stop run
+// item aborted by cancellation is kept as pending
+func (f *fetchRunRecorder) progress(ctx context.Context, runID int32, item string, itemErr error) {
+	status := entities.RunItemStatusDone
+	if itemErr != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		status = entities.RunItemStatusFailed
+	}
+	f.metrics.IncItem(f.command.String(), string(status))
+	err := f.runRepo.UpdateFetchRunItemStatus(context.WithoutCancel(ctx), runID, item, status)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to call runRepo.UpdateFetchRunItemStatus()", "item", item, "error", err)
+	}
+
+	if itemErr != nil {
+		f.publish(runID, &progress.Event{Type: progress.EventFailed, Item: item, Error: itemErr.Error()},
+			func(counts *progress.Counts) { counts.Failed++ })
+		return
+	}
+	f.publish(runID, &progress.Event{Type: progress.EventSaved, Item: item},
+		func(counts *progress.Counts) { counts.Done++ })
+}
+
+// finish records status of run. it is recorded even if ctx is canceled
+func (f *fetchRunRecorder) finish(ctx context.Context, runID int32, runErr error) {
+	status := entities.RunStatusCompleted
+	switch {
+	case errors.Is(runErr, context.Canceled), errors.Is(runErr, context.DeadlineExceeded):
+		status = entities.RunStatusInterrupted
+	case runErr != nil:
+		status = entities.RunStatusFailed
+	}
+	// finished event is published even if status can't be recorded
+	defer func() {
+		f.publish(runID, &progress.Event{Type: progress.EventFinished, Status: string(status)}, nil)
+		f.mu.Lock()
+		delete(f.counts, runID)
+		f.mu.Unlock()
+	}()
+	if err := f.runRepo.UpdateFetchRunStatus(context.WithoutCancel(ctx), runID, status); err != nil {
+		logger.FromContext(ctx).Warn("failed to call runRepo.UpdateFetchRunStatus()", "error", err)
+		return
+	}
+	logger.FromContext(ctx).Info("run finished", "command", f.command, "status", status)
+}
+
+// publish event with counts of run updated by update
+func (f *fetchRunRecorder) publish(runID int32, event *progress.Event, update func(counts *progress.Counts)) {
+	f.mu.Lock()
+	counts, ok := f.counts[runID]
+	if !ok {
+		counts = &progress.Counts{}
+		f.counts[runID] = counts
+	}
+	if update != nil {
+		update(counts)
+	}
+	event.Counts = *counts
+	f.mu.Unlock()
+
+	event.RunID = runID
+	event.Command = f.command.String()
+	event.Timestamp = time.Now()
+	f.notifier.Publish(event)
+}
@@ -4,31 +4,43 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/fetcher"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/metrics"
+	"github.com/hiromaily/hatena-analyzer/pkg/progress"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
 )
 
 type FetchUserBookmarkCountUsecaser interface {
-	Execute(ctx context.Context, urls []string) error
+	Execute(ctx context.Context, urls []string, policy *entities.UserRefreshPolicy, resumeRunID int32) error
 }
 
 type fetchUserBookmarkCountUsecase struct {
 	logger             logger.Logger
 	tracer             tracer.Tracer
+	metrics            metrics.Metrics
 	fetchUserRepo      repository.FetchUserRepositorier
 	userBMCountFetcher fetcher.UserBookmarkCountFetcher
+	runRecorder        *fetchRunRecorder
 	maxWorker          int64 // for semaphore
 }
 
 func NewFetchUserBookmarkCountUsecase(
 	logger logger.Logger,
 	tracer tracer.Tracer,
+	metrics metrics.Metrics,
+	notifier progress.Notifier,
 	fetchUserRepo repository.FetchUserRepositorier,
+	runRepo repository.FetchRunRepositorier,
 	userBMCountFetcher fetcher.UserBookmarkCountFetcher,
 	maxWorker int64,
 ) (*fetchUserBookmarkCountUsecase, error) {
@@ -39,36 +51,67 @@ func NewFetchUserBookmarkCountUsecase(
 	return &fetchUserBookmarkCountUsecase{
 		logger:             logger,
 		tracer:             tracer,
+		metrics:            metrics,
 		fetchUserRepo:      fetchUserRepo,
 		userBMCountFetcher: userBMCountFetcher,
+		runRecorder:        newFetchRunRecorder(metrics, notifier, runRepo, entities.RunCommandFetchUserBookmarkCount),
 		maxWorker:          maxWorker,
 	}, nil
 }
 
 // Fetch user's bookmark count of given urls by scraping
+// If urls are not given, stale users are selected by policy
 // Then save data to DB
-
-func (f *fetchUserBookmarkCountUsecase) Execute(ctx context.Context, urls []string) error {
+// Progress is persisted per user, and run is resumed from remaining users if resumeRunID is given
+
+func (f *fetchUserBookmarkCountUsecase) Execute(
+	ctx context.Context,
+	urls []string,
+	policy *entities.UserRefreshPolicy,
+	resumeRunID int32,
+) error {
 	f.logger.Info("fetchUserBookmarkCountUsecase Execute", "urls length", len(urls))
 
 	// must be closed dbClient
 	// defer f.fetchUserRepo.Close(ctx)
 
-	_, span := f.tracer.NewSpan(ctx, "fetchUserBookmarkCountUsecase:Execute()")
-	defer func() {
-		span.End()
-		f.tracer.Close(ctx)
-	}()
+	ctx, span := f.tracer.NewSpan(ctx, "fetchUserBookmarkCountUsecase:Execute()")
+	defer span.End()
+
+	// resume run
+	if resumeRunID != 0 {
+		users, err := f.runRecorder.resume(ctx, resumeRunID)
+		if err != nil {
+			return err
+		}
+		return f.concurrentExecuter(ctx, resumeRunID, users)
+	}
 
 	// get user list from DB
 	var users []string
 	var err error
 	if len(urls) == 0 {
-		users, err = f.fetchUserRepo.GetUserNames(ctx)
+		// validation
+		if policy == nil || policy.StaleDays <= 0 || policy.RecentDays <= 0 || policy.MaxUsers <= 0 {
+			return errors.New("stale days, recent days and max users must be positive")
+		}
+		now := time.Now()
+		users, err = f.fetchUserRepo.GetStaleUserNames(
+			ctx,
+			policy.StaleBefore(now),
+			policy.RecentSince(now),
+			policy.MaxUsers,
+		)
 		if err != nil {
-			f.logger.Error("failed to get users", "error", err)
+			f.logger.Error("failed to get stale users", "error", err)
 			return err
 		}
+		f.logger.Info("stale users",
+			"count", len(users),
+			"stale_days", policy.StaleDays,
+			"recent_days", policy.RecentDays,
+			"max_users", policy.MaxUsers,
+		)
 	} else {
 		users, err = f.fetchUserRepo.GetUserNamesByURLS(ctx, urls)
 		if err != nil {
@@ -76,48 +119,105 @@ func (f *fetchUserBookmarkCountUsecase) Execute(ctx context.Context, urls []stri
 			return err
 		}
 	}
+	runID, err := f.runRecorder.start(ctx, users)
+	if err != nil {
+		return err
+	}
+
 	// fetch user's bookmark count of given urls by scraping
-	return f.concurrentExecuter(ctx, users)
+	return f.concurrentExecuter(ctx, runID, users)
 }
 
-func (f *fetchUserBookmarkCountUsecase) concurrentExecuter(ctx context.Context, users []string) error {
+func (f *fetchUserBookmarkCountUsecase) concurrentExecuter(
+	ctx context.Context,
+	runID int32,
+	users []string,
+) error {
+	// run_id is added to logs of all workers
+	ctx = logger.WithFields(ctx, "run_id", runID)
+
 	sem := semaphore.NewWeighted(f.maxWorker)
 	var wg sync.WaitGroup
 
-	f.logger.Info("start concurrentExecuter", "max_worker", f.maxWorker, "user_count", len(users))
+	logger.FromContext(ctx).Info("start concurrentExecuter", "max_worker", f.maxWorker, "user_count", len(users))
 
-	for _, userName := range users {
-		wg.Add(1)
+	usecaseName := entities.RunCommandFetchUserBookmarkCount.String()
+	f.metrics.SetMaxWorkers(usecaseName, f.maxWorker)
 
+	var runErr error
+	for _, userName := range users {
+		// stop dispatching when canceled by SIGINT/SIGTERM
+		if err := ctx.Err(); err != nil {
+			logger.FromContext(ctx).Warn("run is canceled", "error", err)
+			runErr = err
+			break
+		}
 		// get semaphore
 		if err := sem.Acquire(ctx, 1); err != nil {
-			f.logger.Warn("failed to acquire semaphore", "error", err)
+			logger.FromContext(ctx).Warn("failed to acquire semaphore", "error", err)
+			runErr = err
 			break
 		}
+		f.metrics.IncWorker(usecaseName)
+		wg.Add(1)
 
 		go func(userName string) {
 			defer func() {
 				wg.Done()
 				sem.Release(1)
+				f.metrics.DecWorker(usecaseName)
 			}()
 
-			// 1. get user's bookmark count
-			bmCount, err := f.userBMCountFetcher.Fetch(ctx, userName)
-			if err != nil {
-				f.logger.Error("failed to get user bookmark count", "user_name", userName, "error", err)
-				return
-			}
-			// s.logger.Debug("user info", "user_name", userName, "bm_count", bmCount)
-
-			// 2. save data to DB
-			if err := f.fetchUserRepo.UpdateUserBookmarkCount(ctx, userName, bmCount); err != nil {
-				//FIXED: failed to deallocate cached statement(s): conn busy
-				f.logger.Error("failed to update user bookmark count", "user_name", userName, "error", err)
-				return
-			}
+			err := f.execute(ctx, runID, userName)
+			f.runRecorder.progress(ctx, runID, userName, err)
 		}(userName)
 	}
+	// in-flight items finish or abort by canceled ctx
 	wg.Wait()
 
+	f.runRecorder.finish(ctx, runID, runErr)
+	return runErr
+}
+
+// fetch user's bookmark count and save it
+func (f *fetchUserBookmarkCountUsecase) execute(ctx context.Context, runID int32, userName string) (err error) {
+	ctx, span := f.tracer.NewSpan(
+		ctx,
+		"fetchUserBookmarkCountUsecase:execute()",
+		oteltrace.WithAttributes(attribute.String("user_name", userName)),
+	)
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	ctx = logger.WithFields(ctx, "user_name", userName)
+
+	// 1. get user's bookmark count
+	bmCount, err := f.userBMCountFetcher.Fetch(ctx, userName)
+	if errors.Is(err, fetcher.ErrUserNotFound) {
+		// deleted user is skipped from next run
+		logger.FromContext(ctx).Warn("user is not found")
+		if err := f.fetchUserRepo.UpdateUserDeleted(ctx, userName); err != nil {
+			logger.FromContext(ctx).Error("failed to update user deleted", "error", err)
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get user bookmark count", "error", err)
+		return err
+	}
+	f.runRecorder.fetched(runID, userName)
+	// s.logger.Debug("user info", "user_name", userName, "bm_count", bmCount)
+
+	// 2. save data to DB
+	// fetched count is flushed even if ctx is canceled while saving
+	if err := f.fetchUserRepo.UpdateUserBookmarkCount(context.WithoutCancel(ctx), userName, bmCount); err != nil {
+		//FIXED: failed to deallocate cached statement(s): conn busy
+		logger.FromContext(ctx).Error("failed to update user bookmark count", "error", err)
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type ListRunsUsecaser interface {
+	Execute(ctx context.Context, limit int) ([]entities.Run, error)
+}
+
+type listRunsUsecase struct {
+	logger  logger.Logger
+	tracer  tracer.Tracer
+	runRepo repository.FetchRunRepositorier
+}
+
+func NewListRunsUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	runRepo repository.FetchRunRepositorier,
+) (*listRunsUsecase, error) {
+	return &listRunsUsecase{
+		logger:  logger,
+		tracer:  tracer,
+		runRepo: runRepo,
+	}, nil
+}
+
+// List latest runs of fetch commands with counts of items and durations
+
+func (l *listRunsUsecase) Execute(ctx context.Context, limit int) ([]entities.Run, error) {
+	l.logger.Info("listRunsUsecase Execute", "limit", limit)
+
+	ctx, span := l.tracer.NewSpan(ctx, "listRunsUsecase:Execute()")
+	defer span.End()
+
+	// validation
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+
+	runs, err := l.runRepo.GetFetchRuns(ctx, limit)
+	if err != nil {
+		l.logger.Error("failed to call runRepo.GetFetchRuns()", "error", err)
+		return nil, err
+	}
+	return runs, nil
+}
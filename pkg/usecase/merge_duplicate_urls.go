@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type MergeDuplicateURLsUsecaser interface {
+	Execute(ctx context.Context, isDryRun bool) error
+}
+
+type mergeDuplicateURLsUsecase struct {
+	logger       logger.Logger
+	tracer       tracer.Tracer
+	mergeURLRepo repository.MergeURLRepositorier
+}
+
+func NewMergeDuplicateURLsUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	mergeURLRepo repository.MergeURLRepositorier,
+) (*mergeDuplicateURLsUsecase, error) {
+	return &mergeDuplicateURLsUsecase{
+		logger:       logger,
+		tracer:       tracer,
+		mergeURLRepo: mergeURLRepo,
+	}, nil
+}
+
+// Merge urls stored in PostgreSQL which have same normalized address into the oldest one.
+// url addresses are kept as they are, and url_hash of kept url is updated with hash of normalized address.
+// Time series in InfluxDB and entities in MongoDB of duplicated urls are moved to address of kept url
+// before UserURLs are moved in PostgreSQL, so that merge can be retried when it fails halfway.
+
+func (m *mergeDuplicateURLsUsecase) Execute(ctx context.Context, isDryRun bool) error {
+	m.logger.Info("mergeDuplicateURLsUsecase Execute", "dry_run", isDryRun)
+
+	ctx, span := m.tracer.NewSpan(ctx, "mergeDuplicateURLsUsecase:Execute()")
+	defer span.End()
+
+	entityURLs, err := m.mergeURLRepo.GetAllURLAddresses(ctx)
+	if err != nil {
+		m.logger.Error("failed to call mergeURLRepo.GetAllURLAddresses()", "error", err)
+		return err
+	}
+	m.logger.Info("url count", "count", len(entityURLs))
+
+	targets := m.group(entityURLs)
+	m.logger.Info("urls to be merged or rehashed", "count", len(targets))
+
+	var mergedCount int
+	for _, target := range targets {
+		m.print(&target)
+		if isDryRun {
+			continue
+		}
+		if err := m.merge(ctx, &target); err != nil {
+			m.logger.Error(
+				"failed to merge urls",
+				"url", target.Kept.Address,
+				"error", err,
+			)
+			continue
+		}
+		mergedCount++
+	}
+	if isDryRun {
+		return nil
+	}
+	m.logger.Info("merged urls", "count", mergedCount)
+
+	// fail so that migration which requires rehash of urls doesn't pass silently
+	if failedCount := len(targets) - mergedCount; failedCount != 0 {
+		return fmt.Errorf("failed to merge %d urls", failedCount)
+	}
+	return nil
+}
+
+func (m *mergeDuplicateURLsUsecase) merge(ctx context.Context, target *entities.DuplicateURLs) error {
+	for _, duplicate := range target.Duplicates {
+		if duplicate.Address == target.Kept.Address {
+			continue
+		}
+		movedCount, err := m.mergeURLRepo.MoveEntitySummaries(ctx, duplicate.Address, target.Kept.Address)
+		if err != nil {
+			m.logger.Error("failed to call mergeURLRepo.MoveEntitySummaries()", "url", duplicate.Address, "error", err)
+			return err
+		}
+		m.logger.Debug("moved time series", "from", duplicate.Address, "to", target.Kept.Address, "count", movedCount)
+
+		if err := m.mergeURLRepo.MoveEntity(ctx, duplicate.Address, target.Kept.Address); err != nil {
+			m.logger.Error("failed to call mergeURLRepo.MoveEntity()", "url", duplicate.Address, "error", err)
+			return err
+		}
+	}
+	if err := m.mergeURLRepo.MergeDuplicateURLs(ctx, target); err != nil {
+		m.logger.Error("failed to call mergeURLRepo.MergeDuplicateURLs()", "url", target.Kept.Address, "error", err)
+		return err
+	}
+	return nil
+}
+
+// group urls by hash of normalized address. urls which are not duplicated and already have the hash are excluded
+// kept url is the oldest one
+func (*mergeDuplicateURLsUsecase) group(entityURLs []entities.URL) []entities.DuplicateURLs {
+	groups := make(map[string][]entities.URL)
+	var hashes []string
+	for _, entityURL := range entityURLs {
+		hash := entities.NewURLHash(entityURL.Address)
+		if _, ok := groups[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		groups[hash] = append(groups[hash], entityURL)
+	}
+
+	targets := make([]entities.DuplicateURLs, 0)
+	for _, hash := range hashes {
+		urls := groups[hash] // urls are sorted by url_id
+		if len(urls) == 1 && urls[0].Hash == hash {
+			continue
+		}
+		targets = append(targets, entities.DuplicateURLs{Hash: hash, Kept: urls[0], Duplicates: urls[1:]})
+	}
+	return targets
+}
+
+func (*mergeDuplicateURLsUsecase) print(target *entities.DuplicateURLs) {
+	fmt.Println("----------------------------------------------------------------------")
+	fmt.Printf(" hash: %s\n", target.Hash)
+	fmt.Printf(" - kept:      [%d] %s\n", target.Kept.ID, target.Kept.Address)
+	for _, duplicate := range target.Duplicates {
+		fmt.Printf(" - duplicate: [%d] %s\n", duplicate.ID, duplicate.Address)
+	}
+}
@@ -2,16 +2,21 @@ package usecase
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"slices"
 
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
 )
 
 type ViewBookmarkDetailsUsecaser interface {
-	Execute(ctx context.Context, urls []string) error
+	Execute(
+		ctx context.Context,
+		urls []string,
+		filter *entities.URLFilter,
+		buckets []int,
+	) ([]*entities.BookmarkDetails, error)
 }
 
 type bookmarkDetailsUsecase struct {
@@ -34,45 +39,53 @@ func NewViewBookmarkDetailsUsecase(
 	}, nil
 }
 
-func (b *bookmarkDetailsUsecase) Execute(ctx context.Context, urls []string) error {
-	b.logger.Info("bookmarkDetailsUsecase Execute", "urls length", len(urls))
+// Build histogram and percentiles of user's bookmark count per URL
+// and compare them with baseline of the URL's category
+
+func (b *bookmarkDetailsUsecase) Execute(
+	ctx context.Context,
+	urls []string,
+	filter *entities.URLFilter,
+	buckets []int,
+) ([]*entities.BookmarkDetails, error) {
+	b.logger.Info("bookmarkDetailsUsecase Execute", "urls length", len(urls), "buckets", buckets)
 
 	// must be closed dbClient
 	// defer b.bookmarkDetailsRepo.Close(ctx)
 
-	_, span := b.tracer.NewSpan(ctx, "bookmarkDetailsUsecase:Execute()")
-	defer func() {
-		span.End()
-		b.tracer.Close(ctx)
-	}()
+	ctx, span := b.tracer.NewSpan(ctx, "bookmarkDetailsUsecase:Execute()")
+	defer span.End()
 
-	// validation
-	if len(urls) == 0 {
-		return errors.New("urls is empty")
+	if len(buckets) == 0 {
+		buckets = entities.DefaultHistogramBuckets
 	}
 
-	// get urls from DB if needed
-	// if len(b.urls) == 0 {
-	// 	var err error
-	// 	entityURLs, err := b.bookmarkDetailsRepo.GetAllURLs(ctx)
-	// 	if err != nil {
-	// 		b.logger.Error("failed to call bookmarkDetailsRepo.GetAllURLs()", "error", err)
-	// 		return err
-	// 	}
-	// 	b.urls = entities.FilterURLAddress(entityURLs)
-	// }
-
 	// get url info from DB
-	urlModels, err := b.bookmarkDetailsRepo.GetURLsByURLAddresses(ctx, urls)
-	if err != nil {
-		b.logger.Error(
-			"failed to call bookmarkDetailsRepo.GetURLsByURLAddresses()",
-			"url_count", len(urls),
-			"error", err,
-		)
-		return err
+	var urlModels []entities.URL
+	var err error
+	if len(urls) == 0 {
+		urlModels, err = b.bookmarkDetailsRepo.GetURLsByFilter(ctx, filter)
+		if err != nil {
+			b.logger.Error("failed to call bookmarkDetailsRepo.GetURLsByFilter()", "error", err)
+			return nil, err
+		}
+	} else {
+		urlModels, err = b.bookmarkDetailsRepo.GetURLsByURLAddresses(ctx, urls)
+		if err != nil {
+			b.logger.Error(
+				"failed to call bookmarkDetailsRepo.GetURLsByURLAddresses()",
+				"url_count", len(urls),
+				"error", err,
+			)
+			return nil, err
+		}
+	}
+	if len(urlModels) == 0 {
+		return nil, ErrURLsNotFound
 	}
 
+	baselines := make(map[entities.CategoryCode]*entities.Distribution)
+	details := make([]*entities.BookmarkDetails, 0, len(urlModels))
 	for _, urlModel := range urlModels {
 		// get user by URL info from DB
 		users, err := b.bookmarkDetailsRepo.GetUsersByURL(ctx, urlModel.Address)
@@ -84,35 +97,64 @@ func (b *bookmarkDetailsUsecase) Execute(ctx context.Context, urls []string) err
 			)
 			continue
 		}
-		var count10, count100, count1000, count10000, countOver int
+		bmCounts := make([]int, 0, len(users))
+		var newUserCount int
 		for _, user := range users {
-			switch {
-			case user.BookmarkCount < 10:
-				count10++
-			case user.BookmarkCount < 100:
-				count100++
-			case user.BookmarkCount < 1000:
-				count1000++
-			case user.BookmarkCount < 10000:
-				count10000++
-			default:
-				countOver++
+			bmCounts = append(bmCounts, user.BookmarkCount)
+			// less 10 user must be suspicious
+			if user.BookmarkCount < entities.NewUserBookmarkCount {
+				newUserCount++
 			}
 		}
-		// calculate average
-		// less 10 user must be suspicious
-		newUserRate := float64(count10) / float64(urlModel.NamedUserCount) * 100
-
-		fmt.Println("----------------------------------------------------------------------")
-		fmt.Printf(" Title: %s,\n URL: %s\n", urlModel.Title, urlModel.Address)
-		fmt.Printf(" User's bookmark count / number of users whose bookmark count \n")
-		fmt.Printf(" - less 10:      %5d\n", count10)
-		fmt.Printf(" - less 100:     %5d\n", count100)
-		fmt.Printf(" - less 1000:    %5d\n", count1000)
-		fmt.Printf(" - less 10000:   %5d\n", count10000)
-		fmt.Printf(" - over 10000:   %5d\n", countOver)
-		fmt.Printf(" New user rate:  %.1f\n", newUserRate)
+
+		// users with less bookmark count come first because they are suspicious
+		slices.SortStableFunc(users, func(a, b entities.RDBUser) int {
+			return a.BookmarkCount - b.BookmarkCount
+		})
+
+		detail := &entities.BookmarkDetails{
+			URL:          urlModel.Address,
+			Title:        urlModel.Title,
+			CategoryCode: urlModel.CategoryCode,
+			Distribution: entities.NewDistribution(bmCounts, buckets),
+			Users:        users,
+		}
+		if len(users) != 0 {
+			detail.NewUserRate = float64(newUserCount) / float64(len(users)) * 100
+		}
+
+		// compare with category baseline
+		baseline, err := b.baseline(ctx, baselines, urlModel.CategoryCode, buckets)
+		if err == nil {
+			detail.Baseline = baseline
+			detail.Distance = detail.Distribution.Distance(baseline)
+		}
+		details = append(details, detail)
 	}
 
-	return nil
+	return details, nil
+}
+
+// baseline of category is cached because many urls share same category
+func (b *bookmarkDetailsUsecase) baseline(
+	ctx context.Context,
+	baselines map[entities.CategoryCode]*entities.Distribution,
+	categoryCode entities.CategoryCode,
+	buckets []int,
+) (*entities.Distribution, error) {
+	if baseline, ok := baselines[categoryCode]; ok {
+		return baseline, nil
+	}
+	bmCounts, err := b.bookmarkDetailsRepo.GetUserBookmarkCountsByCategory(ctx, categoryCode)
+	if err != nil {
+		b.logger.Error(
+			"failed to call bookmarkDetailsRepo.GetUserBookmarkCountsByCategory()",
+			"category", categoryCode.String(),
+			"error", err,
+		)
+		return nil, err
+	}
+	baseline := entities.NewDistribution(bmCounts, buckets)
+	baselines[categoryCode] = baseline
+	return baseline, nil
 }
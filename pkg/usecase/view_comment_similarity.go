@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/similarity"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type ViewCommentSimilarityUsecaser interface {
+	Execute(ctx context.Context, urls []string, threshold float64) error
+}
+
+type commentSimilarityUsecase struct {
+	logger                logger.Logger
+	tracer                tracer.Tracer
+	commentSimilarityRepo repository.CommentSimilarityRepositorier
+}
+
+func NewViewCommentSimilarityUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	commentSimilarityRepo repository.CommentSimilarityRepositorier,
+) (*commentSimilarityUsecase, error) {
+	return &commentSimilarityUsecase{
+		logger:                logger,
+		tracer:                tracer,
+		commentSimilarityRepo: commentSimilarityRepo,
+	}, nil
+}
+
+// Cluster near-identical comments per URL and across URLs to find templated comments posted by spam rings
+
+func (c *commentSimilarityUsecase) Execute(ctx context.Context, urls []string, threshold float64) error {
+	c.logger.Info("commentSimilarityUsecase Execute", "urls length", len(urls), "threshold", threshold)
+
+	ctx, span := c.tracer.NewSpan(ctx, "commentSimilarityUsecase:Execute()")
+	defer span.End()
+
+	clusterer, err := similarity.NewClusterer(threshold)
+	if err != nil {
+		return err
+	}
+
+	// get comments from DB
+	var comments []entities.Comment
+	if len(urls) == 0 {
+		comments, err = c.commentSimilarityRepo.GetAllComments(ctx)
+		if err != nil {
+			c.logger.Error("failed to call commentSimilarityRepo.GetAllComments()", "error", err)
+			return err
+		}
+	} else {
+		comments, err = c.commentSimilarityRepo.GetCommentsByURLs(ctx, urls)
+		if err != nil {
+			c.logger.Error(
+				"failed to call commentSimilarityRepo.GetCommentsByURLs()",
+				"url_count", len(urls),
+				"error", err,
+			)
+			return err
+		}
+	}
+	if len(comments) == 0 {
+		return errors.New("no comments are found")
+	}
+	c.logger.Info("comment count", "count", len(comments))
+
+	// per URL
+	commentsByURL := make(map[string][]entities.Comment)
+	var urlOrder []string
+	for _, comment := range comments {
+		if _, ok := commentsByURL[comment.URL]; !ok {
+			urlOrder = append(urlOrder, comment.URL)
+		}
+		commentsByURL[comment.URL] = append(commentsByURL[comment.URL], comment)
+	}
+
+	fmt.Printf("[Similar comments per URL: threshold %.2f]\n", threshold)
+	for _, url := range urlOrder {
+		clusters := c.cluster(clusterer, commentsByURL[url])
+		if len(clusters) == 0 {
+			continue
+		}
+		fmt.Println("----------------------------------------------------------------------")
+		fmt.Printf(" URL: %s\n", url)
+		for _, cluster := range clusters {
+			c.print(&cluster)
+		}
+	}
+	fmt.Println("")
+
+	// across URLs
+	fmt.Printf("[Similar comments across URLs: threshold %.2f]\n", threshold)
+	for _, cluster := range c.cluster(clusterer, comments) {
+		if cluster.URLCount() < 2 {
+			continue
+		}
+		fmt.Println("----------------------------------------------------------------------")
+		fmt.Printf(" URL count: %d\n", cluster.URLCount())
+		c.print(&cluster)
+	}
+
+	return nil
+}
+
+func (c *commentSimilarityUsecase) cluster(
+	clusterer *similarity.Clusterer,
+	comments []entities.Comment,
+) []entities.CommentCluster {
+	texts := make([]string, len(comments))
+	for i, comment := range comments {
+		texts[i] = comment.Text
+	}
+
+	clusters := clusterer.Cluster(texts)
+	commentClusters := make([]entities.CommentCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		commentCluster := entities.CommentCluster{Similarity: cluster.Similarity}
+		for _, idx := range cluster.Indexes {
+			commentCluster.Comments = append(commentCluster.Comments, comments[idx])
+		}
+		commentClusters = append(commentClusters, commentCluster)
+	}
+	return commentClusters
+}
+
+func (*commentSimilarityUsecase) print(cluster *entities.CommentCluster) {
+	fmt.Printf(
+		" - cluster: comment_count: %d, user_count: %d, similarity: %.2f\n",
+		len(cluster.Comments),
+		len(cluster.UserNames()),
+		cluster.Similarity,
+	)
+	for _, comment := range cluster.Comments {
+		fmt.Printf(
+			"   - user: %s (bookmark_count: %d), url: %s\n     comment: %s\n",
+			comment.UserName,
+			comment.BookmarkCount,
+			comment.URL,
+			comment.Text,
+		)
+	}
+}
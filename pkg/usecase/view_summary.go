@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
@@ -11,7 +13,25 @@ import (
 )
 
 type ViewSummaryUsecaser interface {
-	Execute(ctx context.Context, urls []string, threshold uint) error
+	Execute(ctx context.Context, urls []string, threshold uint, sortKey SummarySortKey) (*entities.Summary, error)
+}
+
+type SummarySortKey string
+
+const (
+	SummarySortKeyPrivateUserRate SummarySortKey = "private_user_rate"
+	SummarySortKeyScore           SummarySortKey = "score"
+)
+
+func ToSummarySortKey(s string) (SummarySortKey, error) {
+	switch SummarySortKey(s) {
+	case "", SummarySortKeyPrivateUserRate:
+		return SummarySortKeyPrivateUserRate, nil
+	case SummarySortKeyScore:
+		return SummarySortKeyScore, nil
+	default:
+		return "", fmt.Errorf("invalid sort key: %s", s)
+	}
 }
 
 type summaryUsecase struct {
@@ -37,17 +57,19 @@ func NewViewSummaryUsecase(
 	}, nil
 }
 
-func (s *summaryUsecase) Execute(ctx context.Context, urls []string, threshold uint) error {
-	s.logger.Info("summaryUsecase Execute", "urls length", len(urls))
+func (s *summaryUsecase) Execute(
+	ctx context.Context,
+	urls []string,
+	threshold uint,
+	sortKey SummarySortKey,
+) (*entities.Summary, error) {
+	s.logger.Info("summaryUsecase Execute", "urls length", len(urls), "sort", sortKey)
 
 	// must be closed dbClient
 	// defer s.summaryRepo.Close(ctx)
 
-	_, span := s.tracer.NewSpan(ctx, "summaryUsecase:Execute()")
-	defer func() {
-		span.End()
-		s.tracer.Close(ctx)
-	}()
+	ctx, span := s.tracer.NewSpan(ctx, "summaryUsecase:Execute()")
+	defer span.End()
 
 	// get urls from DB if needed
 	var entityURLs []entities.URL
@@ -56,7 +78,7 @@ func (s *summaryUsecase) Execute(ctx context.Context, urls []string, threshold u
 		entityURLs, err = s.summaryRepo.GetAllURLs(ctx)
 		if err != nil {
 			s.logger.Error("failed to call bookmarkRepo.GetAllURLs()", "error", err)
-			return err
+			return nil, err
 		}
 	} else {
 		entityURLs, err = s.summaryRepo.GetURLsByURLAddresses(ctx, urls)
@@ -66,41 +88,90 @@ func (s *summaryUsecase) Execute(ctx context.Context, urls []string, threshold u
 				"url_count", len(urls),
 				"error", err,
 			)
-			return err
+			return nil, err
 		}
 	}
 
 	s.logger.Info("url count", "count", len(entityURLs))
 
-	fmt.Printf("[Private user rate over threshold: %d]\n", threshold)
+	// suspicion scores calculated by calc-suspicion-score
+	scores, err := s.summaryRepo.GetAllURLScores(ctx)
+	if err != nil {
+		s.logger.Error("failed to call summaryRepo.GetAllURLScores()", "error", err)
+		return nil, err
+	}
+	scoreMap := make(map[int32]*entities.SuspicionScore, len(scores))
+	for i := range scores {
+		scoreMap[scores[i].URLID] = &scores[i]
+	}
+
+	// watched urls and new bookmarks of watched users are highlighted
+	watchedURLs, err := s.summaryRepo.GetWatchedURLs(ctx)
+	if err != nil {
+		s.logger.Error("failed to call summaryRepo.GetWatchedURLs()", "error", err)
+		return nil, err
+	}
+	watchedURLMap := make(map[string]struct{}, len(watchedURLs))
+	for _, watchedURL := range watchedURLs {
+		watchedURLMap[entities.NewURLHash(watchedURL.Address)] = struct{}{}
+	}
+	since := time.Now().AddDate(0, 0, -entities.DefaultWatchDays)
+	watchedUserBookmarks, err := s.summaryRepo.GetWatchedUserNewBookmarks(ctx, since)
+	if err != nil {
+		s.logger.Error("failed to call summaryRepo.GetWatchedUserNewBookmarks()", "error", err)
+		return nil, err
+	}
+	watchedUsersMap := entities.WatchedUsersByURLID(watchedUserBookmarks)
+
+	switch sortKey {
+	case SummarySortKeyScore:
+		sort.SliceStable(entityURLs, func(i, j int) bool {
+			return scoreOf(scoreMap, entityURLs[i].ID) > scoreOf(scoreMap, entityURLs[j].ID)
+		})
+	default:
+		sort.SliceStable(entityURLs, func(i, j int) bool {
+			return entityURLs[i].PrivateUserRate > entityURLs[j].PrivateUserRate
+		})
+	}
+
+	summary := &entities.Summary{
+		URLs:                 make([]*entities.URLSummary, 0, len(entityURLs)),
+		WatchedUserBookmarks: watchedUserBookmarks,
+	}
 	for _, entityURL := range entityURLs {
-		if entityURL.PrivateUserRate > float64(threshold) {
-			s.logger.Info(
-				"url info",
-				"url", entityURL.Address,
-				"title", entityURL.Title,
-				"bm_count", entityURL.BookmarkCount,
-				"user_count", entityURL.NamedUserCount,
-				"private_user_rate", entityURL.PrivateUserRate,
-			)
+		if entityURL.PrivateUserRate <= float64(threshold) {
+			continue
+		}
+		urlSummary := &entities.URLSummary{
+			URLID:           entityURL.ID,
+			URL:             entityURL.Address,
+			Title:           entityURL.Title,
+			CategoryCode:    entityURL.CategoryCode,
+			BookmarkCount:   entityURL.BookmarkCount,
+			UserCount:       entityURL.NamedUserCount,
+			PrivateUserRate: entityURL.PrivateUserRate,
+		}
+		if score, ok := scoreMap[entityURL.ID]; ok {
+			urlSummary.SuspicionScore = score.Score
+			urlSummary.Signals = &score.Signals
 		}
+		_, urlSummary.IsWatched = watchedURLMap[entities.NewURLHash(entityURL.Address)]
+		urlSummary.WatchedUsers = watchedUsersMap[entityURL.ID]
+		summary.URLs = append(summary.URLs, urlSummary)
 	}
-	fmt.Println("")
 
-	averageRates, err := s.summaryRepo.GetAveragePrivateUserRates(ctx)
+	summary.CategoryAverages, err = s.summaryRepo.GetAveragePrivateUserRates(ctx)
 	if err != nil {
 		s.logger.Error("failed to call summaryRepo.GetAveragePrivateUserRates()", "error", err)
-		return err
+		return nil, err
 	}
 
-	fmt.Println("[Average private user rate per category]")
-	for _, ave := range averageRates {
-		s.logger.Info(
-			"average private user rate",
-			"ave", ave.CategoryCode.String(),
-			"average_private_user_rate", ave.AveragePrivateUserRate,
-		)
-	}
+	return summary, nil
+}
 
-	return nil
+func scoreOf(scoreMap map[int32]*entities.SuspicionScore, urlID int32) float64 {
+	if score, ok := scoreMap[urlID]; ok {
+		return score.Score
+	}
+	return 0
 }
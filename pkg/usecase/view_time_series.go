@@ -3,18 +3,18 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
-	"time"
 
 	"github.com/hiromaily/hatena-analyzer/pkg/entities"
 	"github.com/hiromaily/hatena-analyzer/pkg/logger"
 	"github.com/hiromaily/hatena-analyzer/pkg/repository"
-	"github.com/hiromaily/hatena-analyzer/pkg/times"
 	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
 )
 
+// ErrURLsNotFound is returned when given urls are not stored or filter matches no url
+var ErrURLsNotFound = errors.New("no urls are found")
+
 type ViewTimeSeriesUsecaser interface {
-	Execute(ctx context.Context, urls []string) error
+	Execute(ctx context.Context, urls []string, filter *entities.URLFilter) ([]*entities.TimeSeries, error)
 }
 
 type timeSeriesUsecase struct {
@@ -35,23 +35,42 @@ func NewViewTimeSeriesUsecase(
 	}, nil
 }
 
-func (t *timeSeriesUsecase) Execute(ctx context.Context, urls []string) error {
+func (t *timeSeriesUsecase) Execute(
+	ctx context.Context,
+	urls []string,
+	filter *entities.URLFilter,
+) ([]*entities.TimeSeries, error) {
 	t.logger.Info("timeSeriesUsecase Execute", "urls length", len(urls))
 
 	// must be closed dbClient
 	// defer t.timeSeriesRepo.Close(ctx)
 
-	_, span := t.tracer.NewSpan(ctx, "timeSeriesUsecase:Execute()")
-	defer func() {
-		span.End()
-		t.tracer.Close(ctx)
-	}()
+	ctx, span := t.tracer.NewSpan(ctx, "timeSeriesUsecase:Execute()")
+	defer span.End()
 
-	// validation
+	// get urls from DB by filter if urls are not given
+	if len(urls) == 0 {
+		entityURLs, err := t.timeSeriesRepo.GetURLsByFilter(ctx, filter)
+		if err != nil {
+			t.logger.Error("failed to call timeSeriesRepo.GetURLsByFilter()", "error", err)
+			return nil, err
+		}
+		urls = entities.FilterURLAddress(entityURLs)
+		t.logger.Info("urls selected by filter", "url_count", len(urls))
+	} else {
+		// given urls are replaced with stored addresses because InfluxDB is keyed by url address
+		entityURLs, err := t.timeSeriesRepo.GetURLsByURLAddresses(ctx, urls)
+		if err != nil {
+			t.logger.Error("failed to call timeSeriesRepo.GetURLsByURLAddresses()", "error", err)
+			return nil, err
+		}
+		urls = entities.ResolveURLAddresses(urls, entityURLs)
+	}
 	if len(urls) == 0 {
-		return errors.New("urls is empty")
+		return nil, ErrURLsNotFound
 	}
 
+	timeSeriesList := make([]*entities.TimeSeries, 0, len(urls))
 	for _, url := range urls {
 		// get summaries from InfluxDB
 		summaries, err := t.timeSeriesRepo.ReadEntitySummaries(ctx, url)
@@ -64,20 +83,8 @@ func (t *timeSeriesUsecase) Execute(ctx context.Context, urls []string) error {
 			continue
 		}
 
-		fmt.Println("----------------------------------------------------------------------")
-		fmt.Printf(" Title: %s,\n URL: %s\n", summaries[0].Title, url)
-		fmt.Printf(" Time series\n")
-		for _, summary := range summaries {
-			fmt.Printf(
-				"  - %s: total_bookmark: %d, user_count: %d, deleted_user_count: %d, private user rate: %.1f\n",
-				times.ToJPTime(summary.Timestamp).Format(time.RFC3339),
-				summary.Count,
-				summary.UserCount,
-				summary.DeletedUserCount,
-				entities.PrivateUserRate(summary.Count, summary.UserCount),
-			)
-		}
+		timeSeriesList = append(timeSeriesList, entities.NewTimeSeries(url, summaries))
 	}
 
-	return nil
+	return timeSeriesList, nil
 }
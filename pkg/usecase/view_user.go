@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/storage/rdb"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+var ErrUserNotFound = errors.New("user is not found")
+
+// max number of users sharing suspicious urls in profile
+const maxSharedUsers = 20
+
+type ViewUserUsecaser interface {
+	Execute(ctx context.Context, userName string, minScore float64) (*entities.UserProfile, error)
+}
+
+type userProfileUsecase struct {
+	logger          logger.Logger
+	tracer          tracer.Tracer
+	userProfileRepo repository.UserProfileRepositorier
+}
+
+func NewViewUserUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	userProfileRepo repository.UserProfileRepositorier,
+) (*userProfileUsecase, error) {
+	return &userProfileUsecase{
+		logger:          logger,
+		tracer:          tracer,
+		userProfileRepo: userProfileRepo,
+	}, nil
+}
+
+// Collect stored information of a user: bookmark count history, bookmarked urls, categories,
+// removed bookmarks and other users sharing suspicious urls whose score is greater than or equal to minScore
+
+func (u *userProfileUsecase) Execute(
+	ctx context.Context,
+	userName string,
+	minScore float64,
+) (*entities.UserProfile, error) {
+	u.logger.Info("userProfileUsecase Execute", "user_name", userName, "min_score", minScore)
+
+	ctx, span := u.tracer.NewSpan(ctx, "userProfileUsecase:Execute()")
+	defer span.End()
+
+	// validation
+	if userName == "" {
+		return nil, errors.New("user name is empty")
+	}
+
+	profile, err := u.userProfileRepo.GetUserByName(ctx, userName)
+	if err != nil {
+		if rdb.IsNoRows(err) {
+			return nil, ErrUserNotFound
+		}
+		u.logger.Error("failed to call userProfileRepo.GetUserByName()", "user_name", userName, "error", err)
+		return nil, err
+	}
+
+	profile.History, err = u.userProfileRepo.GetUserBookmarkCountHistory(ctx, userName)
+	if err != nil {
+		u.logger.Error(
+			"failed to call userProfileRepo.GetUserBookmarkCountHistory()",
+			"user_name", userName,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	profile.Bookmarks, err = u.userProfileRepo.GetUserBookmarks(ctx, userName)
+	if err != nil {
+		u.logger.Error("failed to call userProfileRepo.GetUserBookmarks()", "user_name", userName, "error", err)
+		return nil, err
+	}
+	profile.Aggregate(minScore)
+
+	profile.SharedUsers, err = u.userProfileRepo.GetUsersSharingSuspiciousURLs(
+		ctx,
+		userName,
+		minScore,
+		maxSharedUsers,
+	)
+	if err != nil {
+		u.logger.Error(
+			"failed to call userProfileRepo.GetUsersSharingSuspiciousURLs()",
+			"user_name", userName,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type ViewUserGrowthUsecaser interface {
+	Execute(ctx context.Context, days, limit int) ([]entities.UserGrowth, error)
+}
+
+type userGrowthUsecase struct {
+	logger         logger.Logger
+	tracer         tracer.Tracer
+	userGrowthRepo repository.UserGrowthRepositorier
+}
+
+func NewViewUserGrowthUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	userGrowthRepo repository.UserGrowthRepositorier,
+) (*userGrowthUsecase, error) {
+	return &userGrowthUsecase{
+		logger:         logger,
+		tracer:         tracer,
+		userGrowthRepo: userGrowthRepo,
+	}, nil
+}
+
+// List fastest-growing users by increase of bookmark count observed in last given days
+
+func (u *userGrowthUsecase) Execute(ctx context.Context, days, limit int) ([]entities.UserGrowth, error) {
+	u.logger.Info("userGrowthUsecase Execute", "days", days, "limit", limit)
+
+	ctx, span := u.tracer.NewSpan(ctx, "userGrowthUsecase:Execute()")
+	defer span.End()
+
+	// validation
+	if days <= 0 || limit <= 0 {
+		return nil, errors.New("days and limit must be positive")
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	growths, err := u.userGrowthRepo.GetUserGrowths(ctx, since, limit)
+	if err != nil {
+		u.logger.Error("failed to call userGrowthRepo.GetUserGrowths()", "error", err)
+		return nil, err
+	}
+	u.logger.Info("user count", "count", len(growths))
+
+	return growths, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+type fakeUserGrowthRepository struct {
+	growths []entities.UserGrowth
+	// given arguments
+	since    time.Time
+	maxUsers int
+}
+
+func (*fakeUserGrowthRepository) Close(_ context.Context) {}
+
+func (f *fakeUserGrowthRepository) GetUserGrowths(
+	_ context.Context,
+	since time.Time,
+	maxUsers int,
+) ([]entities.UserGrowth, error) {
+	f.since = since
+	f.maxUsers = maxUsers
+	return f.growths, nil
+}
+
+func TestUserGrowthUsecaseExecute(t *testing.T) {
+	tests := []struct {
+		name    string
+		days    int
+		limit   int
+		wantErr bool
+	}{
+		{name: "valid", days: 7, limit: 10},
+		{name: "zero days", days: 0, limit: 10, wantErr: true},
+		{name: "negative days", days: -1, limit: 10, wantErr: true},
+		{name: "zero limit", days: 7, limit: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserGrowthRepository{growths: []entities.UserGrowth{{Name: "user", Growth: 10}}}
+			growthUsecase, err := NewViewUserGrowthUsecase(logger.NewNoopLogger(), tracer.NewNoopProvider(), repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before := time.Now()
+			growths, err := growthUsecase.Execute(context.Background(), tt.days, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(growths) != len(repo.growths) {
+				t.Errorf("Execute() returned %d growths, want %d", len(growths), len(repo.growths))
+			}
+			// observations of last given days
+			wantSince := before.AddDate(0, 0, -tt.days)
+			if repo.since.Before(wantSince) || repo.since.After(time.Now().AddDate(0, 0, -tt.days)) {
+				t.Errorf("since = %v, want %v", repo.since, wantSince)
+			}
+			if repo.maxUsers != tt.limit {
+				t.Errorf("maxUsers = %d, want %d", repo.maxUsers, tt.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hiromaily/hatena-analyzer/pkg/entities"
+	"github.com/hiromaily/hatena-analyzer/pkg/logger"
+	"github.com/hiromaily/hatena-analyzer/pkg/repository"
+	"github.com/hiromaily/hatena-analyzer/pkg/tracer"
+)
+
+var ErrWatchNotFound = errors.New("given urls and users are not watched")
+
+// WatchUsecaser manages watched urls and users
+// - watched url is fetched by every fetch-bookmark
+// - new bookmark of watched user is highlighted in reports
+type WatchUsecaser interface {
+	Add(ctx context.Context, urls, users []string, note string) (*entities.WatchChange, error)
+	Remove(ctx context.Context, urls, users []string) (*entities.WatchChange, error)
+	List(ctx context.Context, days int) (*entities.Watchlist, error)
+}
+
+type watchUsecase struct {
+	logger    logger.Logger
+	tracer    tracer.Tracer
+	watchRepo repository.WatchRepositorier
+}
+
+func NewWatchUsecase(
+	logger logger.Logger,
+	tracer tracer.Tracer,
+	watchRepo repository.WatchRepositorier,
+) (*watchUsecase, error) {
+	return &watchUsecase{
+		logger:    logger,
+		tracer:    tracer,
+		watchRepo: watchRepo,
+	}, nil
+}
+
+// Add watches urls and users. already watched ones are not included in returned change
+func (w *watchUsecase) Add(
+	ctx context.Context,
+	urls, users []string,
+	note string,
+) (*entities.WatchChange, error) {
+	w.logger.Info("watchUsecase Add", "urls length", len(urls), "users length", len(users))
+
+	ctx, span := w.tracer.NewSpan(ctx, "watchUsecase:Add()")
+	defer span.End()
+
+	if err := validateWatch(urls, users); err != nil {
+		return nil, err
+	}
+
+	change := entities.WatchChange{URLs: []string{}, Users: []string{}}
+	for _, url := range urls {
+		inserted, err := w.watchRepo.InsertWatchedURL(ctx, url, note)
+		if err != nil {
+			w.logger.Error("failed to call watchRepo.InsertWatchedURL()", "url", url, "error", err)
+			return nil, err
+		}
+		if inserted {
+			change.URLs = append(change.URLs, url)
+		}
+	}
+	for _, userName := range users {
+		inserted, err := w.watchRepo.InsertWatchedUser(ctx, userName, note)
+		if err != nil {
+			w.logger.Error("failed to call watchRepo.InsertWatchedUser()", "user_name", userName, "error", err)
+			return nil, err
+		}
+		if inserted {
+			change.Users = append(change.Users, userName)
+		}
+	}
+	return &change, nil
+}
+
+// Remove unwatches urls and users. ErrWatchNotFound is returned if none of them is watched
+func (w *watchUsecase) Remove(
+	ctx context.Context,
+	urls, users []string,
+) (*entities.WatchChange, error) {
+	w.logger.Info("watchUsecase Remove", "urls length", len(urls), "users length", len(users))
+
+	ctx, span := w.tracer.NewSpan(ctx, "watchUsecase:Remove()")
+	defer span.End()
+
+	if err := validateWatch(urls, users); err != nil {
+		return nil, err
+	}
+
+	change := entities.WatchChange{URLs: []string{}, Users: []string{}}
+	for _, url := range urls {
+		deleted, err := w.watchRepo.DeleteWatchedURL(ctx, url)
+		if err != nil {
+			w.logger.Error("failed to call watchRepo.DeleteWatchedURL()", "url", url, "error", err)
+			return nil, err
+		}
+		if deleted {
+			change.URLs = append(change.URLs, url)
+		}
+	}
+	for _, userName := range users {
+		deleted, err := w.watchRepo.DeleteWatchedUser(ctx, userName)
+		if err != nil {
+			w.logger.Error("failed to call watchRepo.DeleteWatchedUser()", "user_name", userName, "error", err)
+			return nil, err
+		}
+		if deleted {
+			change.Users = append(change.Users, userName)
+		}
+	}
+	if change.IsEmpty() {
+		return nil, ErrWatchNotFound
+	}
+	return &change, nil
+}
+
+// List returns watched urls and users with bookmarks of watched users stored in last given days
+func (w *watchUsecase) List(ctx context.Context, days int) (*entities.Watchlist, error) {
+	w.logger.Info("watchUsecase List", "days", days)
+
+	ctx, span := w.tracer.NewSpan(ctx, "watchUsecase:List()")
+	defer span.End()
+
+	// validation
+	if days <= 0 {
+		return nil, errors.New("days must be positive")
+	}
+
+	urls, err := w.watchRepo.GetWatchedURLs(ctx)
+	if err != nil {
+		w.logger.Error("failed to call watchRepo.GetWatchedURLs()", "error", err)
+		return nil, err
+	}
+	users, err := w.watchRepo.GetWatchedUsers(ctx)
+	if err != nil {
+		w.logger.Error("failed to call watchRepo.GetWatchedUsers()", "error", err)
+		return nil, err
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	bookmarks, err := w.watchRepo.GetWatchedUserNewBookmarks(ctx, since)
+	if err != nil {
+		w.logger.Error("failed to call watchRepo.GetWatchedUserNewBookmarks()", "error", err)
+		return nil, err
+	}
+	return &entities.Watchlist{
+		URLs:         urls,
+		Users:        users,
+		NewBookmarks: bookmarks,
+	}, nil
+}
+
+func validateWatch(urls, users []string) error {
+	if len(urls) == 0 && len(users) == 0 {
+		return errors.New("urls or users are required")
+	}
+	for _, userName := range users {
+		if err := entities.ValidateUserName(userName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,20 +1,20 @@
 -- name: GetURLsByURLAddresses :many
--- @desc: get url information by url address
+-- @desc: get url information by hashes of url address
 SELECT DISTINCT ON (u.url_address)
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
 FROM
   URLs u
 WHERE
   u.is_deleted = FALSE
 AND
-  u.url_address = ANY($1::text[])
+  u.url_hash = ANY($1::text[])
 ORDER BY
   u.url_address, u.url_id;
 
 -- name: GetAllURLs :many
 -- @desc: get all url addresses
 SELECT DISTINCT ON (u.url_address)
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
 FROM
   URLs u
 WHERE
@@ -23,18 +23,18 @@ ORDER BY
   u.url_address, u.url_id;
 
 -- name: GetUrlID :one
--- @desc: get target url_id by url address
+-- @desc: get target url_id by hash of url address
 SELECT
   u.url_id
 FROM
   URLs u
 WHERE
-  u.url_address = $1;
+  u.url_hash = $1;
 
 -- name: GetURLsByPrivateRate :many
 -- @desc: get urls by private_user_rate
 SELECT
-  u.url_id, u.url_address, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
 FROM 
   URLs u
 WHERE 
@@ -52,6 +52,32 @@ FROM
 WHERE
   u.is_deleted = FALSE;
 
+-- name: GetStaleUserNames :many
+-- @desc: get users whose bookmark count is not refreshed since stale_before.
+-- users tied to urls fetched since recent_since come first, then never refreshed users, then oldest refreshed users
+SELECT
+  u.user_name
+FROM
+  Users u
+WHERE
+  u.is_deleted = FALSE
+  AND (u.updated_at < sqlc.arg(stale_before)::timestamp OR u.updated_at = u.created_at)
+ORDER BY
+  EXISTS (
+    SELECT 1
+    FROM
+      UserURLs uu
+      INNER JOIN URLs url ON uu.url_id = url.url_id
+    WHERE
+      uu.user_id = u.user_id
+      AND uu.is_deleted = FALSE
+      AND url.is_deleted = FALSE
+      AND url.updated_at >= sqlc.arg(recent_since)::timestamp
+  ) DESC,
+  (u.updated_at = u.created_at) DESC,
+  u.updated_at ASC
+LIMIT sqlc.arg(max_users)::int;
+
 -- name: GetUserNamesByURL :many
 -- @desc: get target users by url
 SELECT
@@ -64,7 +90,7 @@ WHERE
   u.is_deleted = FALSE
   AND url.is_deleted = FALSE
   AND uu.is_deleted = FALSE
-  AND url.url_address = $1;
+  AND url.url_hash = $1;
 
 -- name: GetUserNamesByURLs :many
 -- @desc: get target users by multiple urls
@@ -78,7 +104,7 @@ WHERE
   u.is_deleted = FALSE
   AND url.is_deleted = FALSE
   AND uu.is_deleted = FALSE
-  AND url.url_address = ANY($1::text[]);
+  AND url.url_hash = ANY($1::text[]);
 
 -- name: GetUsersByURL :many
 -- @desc: get target users by url
@@ -92,7 +118,7 @@ WHERE
   u.is_deleted = FALSE
   AND url.is_deleted = FALSE
   AND uu.is_deleted = FALSE
-  AND url.url_address = $1
+  AND url.url_hash = $1
 ORDER BY
   u.bookmark_count DESC;
 
@@ -104,39 +130,95 @@ WHERE user_name = $2
 RETURNING
   user_id;
 
+-- name: UpdateUserDeleted :exec
+-- @desc: mark user as deleted when user's page no longer exists
+UPDATE Users
+  SET is_deleted = TRUE, updated_at = CURRENT_TIMESTAMP
+WHERE user_name = $1;
+
+-- name: InsertUserBookmarkCountHistory :exec
+-- @desc: insert observation of user's bookmark count
+INSERT INTO UserBookmarkCountHistories (user_id, bookmark_count)
+VALUES ($1, $2);
+
+-- name: GetUserBookmarkCountHistory :many
+-- @desc: get observations of user's bookmark count in time order
+SELECT
+  h.bookmark_count, h.observed_at
+FROM
+  UserBookmarkCountHistories h
+  INNER JOIN Users u ON h.user_id = u.user_id
+WHERE
+  u.user_name = $1
+ORDER BY
+  h.observed_at;
+
+-- name: GetUserGrowths :many
+-- @desc: get growth of user's bookmark count since given time. max_jump is max increase between consecutive observations
+SELECT
+  u.user_name,
+  (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at))[1]::int AS first_count,
+  (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at DESC))[1]::int AS last_count,
+  ((ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at DESC))[1]
+    - (ARRAY_AGG(h.bookmark_count ORDER BY h.observed_at))[1])::int AS growth,
+  COALESCE(MAX(h.jump), 0)::int AS max_jump,
+  MIN(h.observed_at)::timestamp AS first_observed_at,
+  MAX(h.observed_at)::timestamp AS last_observed_at,
+  COUNT(*)::int AS observation_count
+FROM
+  (
+    SELECT
+      user_id, bookmark_count, observed_at,
+      bookmark_count - LAG(bookmark_count) OVER (PARTITION BY user_id ORDER BY observed_at) AS jump
+    FROM
+      UserBookmarkCountHistories
+    WHERE
+      observed_at >= sqlc.arg(since)::timestamp
+  ) AS h
+  INNER JOIN Users u ON h.user_id = u.user_id
+WHERE
+  u.is_deleted = FALSE
+GROUP BY
+  u.user_name
+HAVING
+  COUNT(*) >= 2
+ORDER BY
+  growth DESC, max_jump DESC, u.user_name
+LIMIT sqlc.arg(max_users)::int;
+
 -- name: InsertURL :one
 -- @desc: Deprecated. insert url if not existed and return url_id
 WITH insert_result AS (
-	INSERT INTO URLs (url_address, category_code)
-	VALUES ($1, $2)
-	ON CONFLICT (url_address, category_code) DO NOTHING
+	INSERT INTO URLs (url_address, url_hash, category_code)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (url_hash) DO NOTHING
 	RETURNING url_id
 )
 SELECT url_id FROM insert_result
 UNION ALL
-SELECT url_id FROM URLs WHERE url_address = $1 AND category_code = $2 LIMIT 1;
+SELECT url_id FROM URLs WHERE url_hash = $2 LIMIT 1;
 
 -- name: InsertURLs :copyfrom
 -- @desc: Deprecated. insert urls if not existed
 INSERT INTO
-  URLs (url_address, category_code)
+  URLs (url_address, url_hash, category_code)
 VALUES
-  ($1, $2);
+  ($1, $2, $3);
 
 -- name: BulkInsertUrls :exec
--- @desc: insert urls by stored procedure. conflicts must be ignored. arg1: array of urls, arg2: array of category, arg3: array of isAll flag.
+-- @desc: insert urls by stored procedure. conflicts must be ignored. arg1: array of urls, arg2: array of url hashes, arg3: array of category, arg4: array of isAll flag.
 -- name: BulkInsertURLs :exec
-CALL bulk_insert_urls($1, $2, $3);
+CALL bulk_insert_urls($1, $2, $3, $4);
 
 -- name: UpsertURL :one
 -- @desc: insert url if not existed, update url with is_deleted=false if existed
-INSERT INTO URLs (url_address, title, bookmark_count, named_user_count, private_user_rate) 
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (url_address) 
+INSERT INTO URLs (url_address, url_hash, title, bookmark_count, named_user_count, private_user_rate) 
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (url_hash) 
 DO UPDATE SET
-    bookmark_count = $3,
-    named_user_count = $4,
-    private_user_rate = $5,
+    bookmark_count = $4,
+    named_user_count = $5,
+    private_user_rate = $6,
     is_deleted = FALSE,
     updated_at = EXCLUDED.updated_at 
 RETURNING url_id;
@@ -162,25 +244,87 @@ ON CONFLICT (user_name) DO NOTHING
 RETURNING
   user_id;
 
--- name: UpsertUser :one
--- @desc: insert user if not existed, update user with is_deleted=false if existed
-INSERT INTO Users (user_name) 
-VALUES ($1)
-ON CONFLICT (user_name) 
-DO UPDATE SET 
-    is_deleted = FALSE,
-    updated_at = EXCLUDED.updated_at 
-RETURNING user_id;
+-- name: InsertUserURLStagings :copyfrom
+-- @desc: copy bookmarked users of url into staging table
+INSERT INTO UserURLStagings (url_id, user_name, comment, is_deleted)
+VALUES ($1, $2, $3, $4);
 
--- name: UpsertUserURLs :exec
--- @desc: insert UserURLs if not existed, update UserURLs with is_deleted=false if existed
-INSERT INTO UserURLs (user_id, url_id) 
-VALUES ($1, $2)
-ON CONFLICT (user_id, url_id) 
-DO UPDATE SET 
-    is_deleted = FALSE,
+-- name: UpsertUsersFromStagings :exec
+-- @desc: insert Users in staging table of url. users are sorted to keep lock order among concurrent transactions
+-- existing users are not updated, so that updated_at keeps when bookmark_count was refreshed.
+-- deletion is set only when user's page returns 404 and is never cleared here,
+-- because staging includes users who removed bookmark and are kept in MongoDB forever
+INSERT INTO Users (user_name)
+SELECT DISTINCT
+  s.user_name
+FROM
+  UserURLStagings s
+WHERE
+  s.url_id = $1
+ORDER BY
+  s.user_name
+ON CONFLICT (user_name) DO NOTHING;
+
+-- name: UpsertUserURLsFromStagings :exec
+-- @desc: upsert UserURLs in staging table of url. comment is overwritten with fetched one, so edited or cleared comment is reflected.
+-- removed_at keeps first detected time while bookmark is removed, and is cleared when user bookmarks again
+INSERT INTO UserURLs (user_id, url_id, comment, is_deleted, removed_at)
+SELECT
+  u.user_id, s.url_id, s.comment, s.is_deleted,
+  CASE WHEN s.is_deleted THEN CURRENT_TIMESTAMP END
+FROM
+  UserURLStagings s
+  INNER JOIN Users u ON s.user_name = u.user_name
+WHERE
+  s.url_id = $1
+ORDER BY
+  u.user_id
+ON CONFLICT (user_id, url_id)
+DO UPDATE SET
+    comment = EXCLUDED.comment,
+    is_deleted = EXCLUDED.is_deleted,
+    removed_at = CASE
+      WHEN EXCLUDED.is_deleted THEN COALESCE(UserURLs.removed_at, EXCLUDED.removed_at)
+    END,
     updated_at = EXCLUDED.updated_at;
 
+-- name: DeleteUserURLStagings :exec
+-- @desc: delete rows of url in staging table
+DELETE FROM UserURLStagings WHERE url_id = $1;
+
+-- name: GetAllComments :many
+-- @desc: get all bookmark comments with user's bookmark count
+SELECT
+  url.url_address, u.user_name, u.bookmark_count, uu.comment
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND uu.comment <> ''
+ORDER BY
+  url.url_address, u.user_name;
+
+-- name: GetCommentsByURLs :many
+-- @desc: get bookmark comments with user's bookmark count by multiple urls
+SELECT
+  url.url_address, u.user_name, u.bookmark_count, uu.comment
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND uu.comment <> ''
+  AND url.url_hash = ANY($1::text[])
+ORDER BY
+  url.url_address, u.user_name;
+
 -- name: GetBookmarkedUsersURLCounts :many
 -- @desc: Not used. Count each user's bookmarked urls
 SELECT 
@@ -223,3 +367,385 @@ WHERE
   is_deleted = FALSE
 GROUP BY 
   category_code;
+
+-- name: GetMaxSharedUserCount :one
+-- @desc: get max number of users shared with another url
+SELECT
+  COALESCE(MAX(shared.user_count), 0)::int AS max_shared_user_count
+FROM
+  (
+    SELECT
+      uu2.url_id,
+      COUNT(uu2.user_id) AS user_count
+    FROM
+      UserURLs uu1
+      INNER JOIN UserURLs uu2 ON uu1.user_id = uu2.user_id AND uu1.url_id <> uu2.url_id
+    WHERE
+      uu1.url_id = $1
+      AND uu1.is_deleted = FALSE
+      AND uu2.is_deleted = FALSE
+    GROUP BY
+      uu2.url_id
+  ) AS shared;
+
+-- name: UpsertURLScore :exec
+-- @desc: insert or update suspicion score of url
+INSERT INTO URLScores (
+  url_id, score, private_user_rate, new_user_rate, deleted_user_rate, burstiness, user_overlap
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (url_id)
+DO UPDATE SET
+    score = EXCLUDED.score,
+    private_user_rate = EXCLUDED.private_user_rate,
+    new_user_rate = EXCLUDED.new_user_rate,
+    deleted_user_rate = EXCLUDED.deleted_user_rate,
+    burstiness = EXCLUDED.burstiness,
+    user_overlap = EXCLUDED.user_overlap;
+
+-- name: GetAllURLScores :many
+-- @desc: get suspicion scores of all urls
+SELECT
+  url.url_id, url.url_address, url.title,
+  s.score, s.private_user_rate, s.new_user_rate, s.deleted_user_rate, s.burstiness, s.user_overlap
+FROM
+  URLScores s
+  INNER JOIN URLs url ON s.url_id = url.url_id
+WHERE
+  url.is_deleted = FALSE
+ORDER BY
+  s.score DESC;
+
+-- name: GetUserBookmarkCountsByCategory :many
+-- @desc: get bookmark count of users who bookmarked urls in category
+SELECT
+  u.bookmark_count
+FROM
+  Users u
+  INNER JOIN UserURLs uu ON u.user_id = uu.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+WHERE
+  u.is_deleted = FALSE
+  AND url.is_deleted = FALSE
+  AND uu.is_deleted = FALSE
+  AND url.category_code = $1;
+
+-- name: GetURLsByFilter :many
+-- @desc: get urls by category, created date range, order and pagination. null parameter means no filter
+SELECT
+  u.url_id, u.url_address, u.url_hash, u.category_code, u.title, u.bookmark_count, u.named_user_count, u.private_user_rate
+FROM
+  URLs u
+WHERE
+  u.is_deleted = FALSE
+  AND (sqlc.narg('category_code')::text IS NULL OR u.category_code = sqlc.narg('category_code')::text)
+  AND (sqlc.narg('from_date')::timestamp IS NULL OR u.created_at >= sqlc.narg('from_date')::timestamp)
+  AND (sqlc.narg('to_date')::timestamp IS NULL OR u.created_at < sqlc.narg('to_date')::timestamp)
+ORDER BY
+  CASE WHEN sqlc.arg('order_by')::text = 'bookmark_count' THEN u.bookmark_count END DESC NULLS LAST,
+  CASE WHEN sqlc.arg('order_by')::text = 'private_user_rate' THEN u.private_user_rate END DESC NULLS LAST,
+  u.url_id
+LIMIT sqlc.narg('page_limit')::int
+OFFSET sqlc.arg('page_offset')::int;
+
+-- name: GetAllURLAddresses :many
+-- @desc: get all url addresses including deleted urls to find duplicated urls
+SELECT
+  u.url_id, u.url_address, u.url_hash, u.bookmark_count
+FROM
+  URLs u
+ORDER BY
+  u.url_id;
+
+-- name: MergeUserURLs :exec
+-- @desc: move UserURLs of duplicated url to kept url. non-empty comment of kept url has priority
+INSERT INTO UserURLs (user_id, url_id, comment, is_deleted, removed_at)
+SELECT
+  uu.user_id, sqlc.arg(to_url_id)::int, uu.comment, uu.is_deleted, uu.removed_at
+FROM
+  UserURLs uu
+WHERE
+  uu.url_id = sqlc.arg(from_url_id)::int
+ON CONFLICT (user_id, url_id)
+DO UPDATE SET
+    comment = COALESCE(NULLIF(UserURLs.comment, ''), EXCLUDED.comment),
+    is_deleted = UserURLs.is_deleted AND EXCLUDED.is_deleted,
+    removed_at = CASE
+      WHEN UserURLs.is_deleted AND EXCLUDED.is_deleted THEN LEAST(UserURLs.removed_at, EXCLUDED.removed_at)
+    END;
+
+-- name: DeleteUserURLsByURLID :exec
+-- @desc: delete UserURLs of url
+DELETE FROM UserURLs WHERE url_id = $1;
+
+-- name: DeleteURLScore :exec
+-- @desc: delete suspicion score of url
+DELETE FROM URLScores WHERE url_id = $1;
+
+-- name: MoveAlerts :exec
+-- @desc: move alerts of duplicated url to kept url to keep deduplication
+UPDATE Alerts
+  SET url_id = sqlc.arg(to_url_id)::int
+WHERE url_id = sqlc.arg(from_url_id)::int;
+
+-- name: DeleteURL :exec
+-- @desc: delete url. UserURLs, URLScores and Alerts of url must be moved or deleted before
+DELETE FROM URLs WHERE url_id = $1;
+
+-- name: UpdateURLHash :exec
+-- @desc: update url hash with hash of normalized address. url address is kept as it is
+UPDATE URLs
+SET
+    url_hash = $2
+WHERE
+    url_id = $1;
+
+-- name: GetUserByName :one
+-- @desc: get user by user name
+SELECT
+  u.user_id, u.user_name, u.bookmark_count, u.is_deleted, u.created_at, u.updated_at
+FROM
+  Users u
+WHERE
+  u.user_name = $1;
+
+-- name: GetUserURLsByUserName :many
+-- @desc: get urls bookmarked by user including removed bookmarks. created_at is when bookmark was found first
+SELECT
+  url.url_id, url.url_address, url.title, url.category_code,
+  uu.comment, uu.is_deleted, uu.removed_at, uu.created_at,
+  COALESCE(s.score, 0)::float AS score
+FROM
+  UserURLs uu
+  INNER JOIN Users u ON uu.user_id = u.user_id
+  INNER JOIN URLs url ON uu.url_id = url.url_id
+  LEFT JOIN URLScores s ON uu.url_id = s.url_id
+WHERE
+  u.user_name = $1
+  AND url.is_deleted = FALSE
+ORDER BY
+  uu.created_at DESC, url.url_id DESC;
+
+-- name: GetUsersSharingSuspiciousURLs :many
+-- @desc: get other users who bookmarked same suspicious urls as the user. suspicious url has score greater than or equal to min_score
+SELECT
+  u2.user_name, COUNT(DISTINCT uu2.url_id)::int AS shared_url_count
+FROM
+  Users u1
+  INNER JOIN UserURLs uu1 ON u1.user_id = uu1.user_id
+  INNER JOIN URLScores s ON uu1.url_id = s.url_id
+  INNER JOIN UserURLs uu2 ON uu1.url_id = uu2.url_id AND uu1.user_id <> uu2.user_id
+  INNER JOIN Users u2 ON uu2.user_id = u2.user_id
+WHERE
+  u1.user_name = sqlc.arg(user_name)
+  AND s.score >= sqlc.arg(min_score)::float
+  AND uu1.is_deleted = FALSE
+  AND uu2.is_deleted = FALSE
+  AND u2.is_deleted = FALSE
+GROUP BY
+  u2.user_name
+ORDER BY
+  shared_url_count DESC, u2.user_name
+LIMIT sqlc.arg(max_users)::int;
+
+-- name: InsertFetchRun :one
+-- @desc: start run of fetch command
+INSERT INTO FetchRuns (command)
+VALUES ($1)
+RETURNING
+  run_id;
+
+-- name: InsertFetchRunItems :copyfrom
+-- @desc: copy items of run. all items are pending first
+INSERT INTO FetchRunItems (run_id, item)
+VALUES ($1, $2);
+
+-- name: GetFetchRun :one
+-- @desc: get run with counts of items by status
+SELECT
+  r.run_id, r.command, r.status, r.created_at, r.updated_at, r.finished_at,
+  COUNT(i.run_item_id)::int AS total_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'done')::int AS done_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'failed')::int AS failed_count
+FROM
+  FetchRuns r
+  LEFT JOIN FetchRunItems i ON r.run_id = i.run_id
+WHERE
+  r.run_id = $1
+GROUP BY
+  r.run_id;
+
+-- name: GetFetchRuns :many
+-- @desc: get latest runs with counts of items by status
+SELECT
+  r.run_id, r.command, r.status, r.created_at, r.updated_at, r.finished_at,
+  COUNT(i.run_item_id)::int AS total_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'done')::int AS done_count,
+  COUNT(i.run_item_id) FILTER (WHERE i.status = 'failed')::int AS failed_count
+FROM
+  FetchRuns r
+  LEFT JOIN FetchRunItems i ON r.run_id = i.run_id
+GROUP BY
+  r.run_id
+ORDER BY
+  r.run_id DESC
+LIMIT $1;
+
+-- name: GetUnfinishedFetchRunItems :many
+-- @desc: get items of run which are not done yet. failed items are retried
+SELECT
+  item
+FROM
+  FetchRunItems
+WHERE
+  run_id = $1
+  AND status <> 'done'
+ORDER BY
+  run_item_id;
+
+-- name: UpdateFetchRunItemStatus :exec
+-- @desc: update progress of item in run
+UPDATE FetchRunItems
+  SET status = $3, updated_at = CURRENT_TIMESTAMP
+WHERE run_id = $1 AND item = $2;
+
+-- name: UpdateFetchRunStatus :exec
+-- @desc: update status of run. finished_at is set when run is not running
+UPDATE FetchRuns
+  SET status = sqlc.arg(status),
+  updated_at = CURRENT_TIMESTAMP,
+  finished_at = CASE WHEN sqlc.arg(status) = 'running' THEN NULL ELSE CURRENT_TIMESTAMP END
+WHERE run_id = sqlc.arg(run_id);
+
+-- name: InsertApiKey :one
+-- @desc: issue API key. only hash of key is stored
+INSERT INTO ApiKeys (name, key_prefix, key_hash, scope, rate_limit, daily_quota)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING
+  api_key_id;
+
+-- name: GetApiKeyByHash :one
+-- @desc: get API key by hash of key to authenticate request
+SELECT
+  api_key_id, name, key_prefix, scope, rate_limit, daily_quota, is_revoked, created_at, last_used_at, revoked_at
+FROM
+  ApiKeys
+WHERE
+  key_hash = $1;
+
+-- name: GetApiKeys :many
+-- @desc: get all API keys with request count of given date
+SELECT
+  k.api_key_id, k.name, k.key_prefix, k.scope, k.rate_limit, k.daily_quota, k.is_revoked,
+  k.created_at, k.last_used_at, k.revoked_at,
+  COALESCE(u.request_count, 0)::int AS request_count
+FROM
+  ApiKeys k
+  LEFT JOIN ApiKeyUsages u ON k.api_key_id = u.api_key_id AND u.usage_date = sqlc.arg(usage_date)::date
+ORDER BY
+  k.api_key_id;
+
+-- name: RevokeApiKey :execrows
+-- @desc: revoke API key. revoked key is kept to show history
+UPDATE ApiKeys
+  SET is_revoked = TRUE, revoked_at = CURRENT_TIMESTAMP
+WHERE api_key_id = $1 AND is_revoked = FALSE;
+
+-- name: IncrementApiKeyUsage :one
+-- @desc: count request of API key per day. no row is returned when daily quota is exceeded
+INSERT INTO ApiKeyUsages (api_key_id, usage_date, request_count)
+VALUES (sqlc.arg(api_key_id), sqlc.arg(usage_date)::date, 1)
+ON CONFLICT (api_key_id, usage_date)
+DO UPDATE SET
+  request_count = ApiKeyUsages.request_count + 1
+WHERE
+  sqlc.arg(daily_quota)::int = 0 OR ApiKeyUsages.request_count < sqlc.arg(daily_quota)::int
+RETURNING
+  request_count;
+
+-- name: UpdateApiKeyLastUsed :exec
+-- @desc: record last time API key was used
+UPDATE ApiKeys
+  SET last_used_at = CURRENT_TIMESTAMP
+WHERE api_key_id = $1;
+
+-- name: InsertAlert :exec
+-- @desc: record alert sent for url
+INSERT INTO Alerts (url_id, rule, value, threshold)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetAlertedRules :many
+-- @desc: get rules alerted for url since given time to deduplicate alerts
+SELECT DISTINCT
+  rule
+FROM
+  Alerts
+WHERE
+  url_id = $1
+  AND created_at >= sqlc.arg(since)::timestamp;
+
+-- name: InsertWatchedURL :execrows
+-- @desc: watch url. no row is inserted if url is already watched
+INSERT INTO WatchedURLs (url_address, url_hash, note)
+VALUES ($1, $2, $3)
+ON CONFLICT (url_hash) DO NOTHING;
+
+-- name: DeleteWatchedURL :execrows
+-- @desc: unwatch url
+DELETE FROM WatchedURLs WHERE url_hash = $1;
+
+-- name: GetWatchedURLs :many
+-- @desc: get watched urls with stored data of url if it's fetched. stored address has priority
+SELECT
+  COALESCE(u.url_address, w.url_address)::text AS url_address, w.note, w.created_at,
+  COALESCE(u.title, '')::text AS title,
+  COALESCE(u.bookmark_count, 0)::int AS bookmark_count,
+  u.updated_at AS fetched_at
+FROM
+  WatchedURLs w
+  LEFT JOIN URLs u ON w.url_hash = u.url_hash
+ORDER BY
+  w.watched_url_id;
+
+-- name: InsertWatchedUser :execrows
+-- @desc: watch user. no row is inserted if user is already watched
+INSERT INTO WatchedUsers (user_name, note)
+VALUES ($1, $2)
+ON CONFLICT (user_name) DO NOTHING;
+
+-- name: DeleteWatchedUser :execrows
+-- @desc: unwatch user
+DELETE FROM WatchedUsers WHERE user_name = $1;
+
+-- name: GetWatchedUsers :many
+-- @desc: get watched users with bookmark count and count of bookmarks stored after user is watched
+SELECT
+  w.user_name, w.note, w.created_at,
+  COALESCE(us.bookmark_count, 0)::int AS bookmark_count,
+  COUNT(uu.user_url_id)::int AS new_bookmark_count
+FROM
+  WatchedUsers w
+  LEFT JOIN Users us ON w.user_name = us.user_name
+  LEFT JOIN UserURLs uu ON us.user_id = uu.user_id
+    AND uu.is_deleted = FALSE
+    AND uu.created_at >= w.created_at
+GROUP BY
+  w.watched_user_id, us.bookmark_count
+ORDER BY
+  w.watched_user_id;
+
+-- name: GetWatchedUserNewBookmarks :many
+-- @desc: get bookmarks of watched users stored after they are watched and since given time
+SELECT
+  w.user_name, u.url_id, u.url_address, u.title, uu.comment, uu.created_at
+FROM
+  WatchedUsers w
+  INNER JOIN Users us ON w.user_name = us.user_name
+  INNER JOIN UserURLs uu ON us.user_id = uu.user_id
+  INNER JOIN URLs u ON uu.url_id = u.url_id
+WHERE
+  uu.is_deleted = FALSE
+  AND uu.created_at >= GREATEST(w.created_at, sqlc.arg(since)::timestamp)
+ORDER BY
+  uu.created_at DESC, w.user_name;
+